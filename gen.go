@@ -7,6 +7,9 @@ package main
 // Builds the Vagrant server Go GRPC
 //go:generate sh -c "protoc -I`go list -m -f \"{{.Dir}}\" github.com/mitchellh/protostructure` -I`go list -m -f \"{{.Dir}}\" github.com/hashicorp/vagrant-plugin-sdk`/proto/vagrant_plugin_sdk -I./thirdparty/proto/api-common-protos -I./internal/server --go-grpc_out=require_unimplemented_servers=false:./internal/server/proto/vagrant_server --go-grpc_opt=module=github.com/hashicorp/vagrant/internal/server/proto/vagrant_server --go_out=./internal/server/proto/vagrant_server --go_opt=module=github.com/hashicorp/vagrant/internal/server/proto/vagrant_server internal/server/proto/vagrant_server/*.proto"
 
+// Builds the JSON/HTTP gateway and its OpenAPI document for the Vagrant server
+//go:generate sh -c "protoc -I`go list -m -f \"{{.Dir}}\" github.com/mitchellh/protostructure` -I`go list -m -f \"{{.Dir}}\" github.com/hashicorp/vagrant-plugin-sdk`/proto/vagrant_plugin_sdk -I./thirdparty/proto/api-common-protos -I./internal/server --grpc-gateway_out=./internal/server/proto/vagrant_server --grpc-gateway_opt=module=github.com/hashicorp/vagrant/internal/server/proto/vagrant_server,grpc_api_configuration=internal/server/proto/vagrant_server/gateway.yaml --openapiv2_out=./internal/server --openapiv2_opt=grpc_api_configuration=internal/server/proto/vagrant_server/gateway.yaml,openapi_configuration=internal/server/proto/vagrant_server/openapi.yaml internal/server/proto/vagrant_server/*.proto"
+
 // Builds the Ruby Vagrant Go GRPC for legacy Vagrant interactions
 //go:generate sh -c "protoc -I./thirdparty/proto/api-common-protos -I./internal/server -I`go list -m -f \"{{.Dir}}\" github.com/mitchellh/protostructure` -I`go list -m -f \"{{.Dir}}\" github.com/hashicorp/vagrant-plugin-sdk`/proto/vagrant_plugin_sdk --go-grpc_out=./internal/server/proto/ruby_vagrant --go-grpc_opt=module=github.com/hashicorp/vagrant/internal/server/proto/ruby_vagrant --go_out=./internal/server/proto/ruby_vagrant --go_opt=module=github.com/hashicorp/vagrant/internal/server/proto/ruby_vagrant internal/server/proto/ruby_vagrant/*.proto"
 
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+type AuditListCommand struct {
+	*baseCommand
+
+	flagUser     *component.CommandFlag
+	flagEndpoint *component.CommandFlag
+	flagSince    *component.CommandFlag
+	flagLimit    *component.CommandFlag
+}
+
+func (c *AuditListCommand) Run(args []string) int {
+	conn, err := c.initServerCommand(args, c.Flags())
+	if err != nil {
+		return 1
+	}
+	defer conn.Close()
+
+	if len(c.args) != 0 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	req := &vagrant_server.ListAuditEventsRequest{
+		User:     c.stringFlag(c.flagUser),
+		Endpoint: c.stringFlag(c.flagEndpoint),
+	}
+	if v := c.stringFlag(c.flagSince); v != "" {
+		dur, err := time.ParseDuration(v)
+		if err != nil {
+			c.logError(c.Log, "invalid value for --since", err)
+			return 1
+		}
+		req.Since = timestamppb.New(time.Now().Add(-dur))
+	}
+	if v := c.stringFlag(c.flagLimit); v != "" {
+		var limit uint32
+		if _, err := fmt.Sscan(v, &limit); err != nil {
+			c.logError(c.Log, "invalid value for --limit", err)
+			return 1
+		}
+		req.Limit = limit
+	}
+
+	resp, err := vagrant_server.NewVagrantClient(conn).ListAuditEvents(c.Ctx, req)
+	if err != nil {
+		c.logError(c.Log, "failed to list audit events", err)
+		return 1
+	}
+
+	if len(resp.Events) == 0 {
+		c.ui.Output("No matching audit events.")
+		return 0
+	}
+
+	tbl := terminal.NewTable("TIME", "USER", "ENDPOINT", "RESULT")
+	for _, ev := range resp.Events {
+		result := "ok"
+		if ev.Error != nil {
+			result = fmt.Sprintf("%s: %s", codes.Code(ev.Error.Code), ev.Error.Message)
+		}
+
+		tbl.Rich([]string{
+			formatTime(ev.Time, ""),
+			ev.User,
+			ev.Endpoint,
+			result,
+		}, nil)
+	}
+	c.ui.Table(tbl)
+
+	return 0
+}
+
+func (c *AuditListCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagUser = &component.CommandFlag{
+			LongName:    "user",
+			Description: "Only list events of this user",
+			Type:        component.FlagString,
+		}
+		c.flagEndpoint = &component.CommandFlag{
+			LongName:    "endpoint",
+			Description: "Only list events for this endpoint, such as \"QueueJob\"",
+			Type:        component.FlagString,
+		}
+		c.flagSince = &component.CommandFlag{
+			LongName:    "since",
+			Description: "Only list events within this duration, such as \"24h\"",
+			Type:        component.FlagString,
+		}
+		c.flagLimit = &component.CommandFlag{
+			LongName:     "limit",
+			Description:  "The maximum number of events to list, 0 lists all events",
+			DefaultValue: "50",
+			Type:         component.FlagString,
+		}
+
+		return append(set, c.flagUser, c.flagEndpoint, c.flagSince, c.flagLimit)
+	})
+}
+
+func (c *AuditListCommand) Primary() bool {
+	return false
+}
+
+func (c *AuditListCommand) Synopsis() string {
+	return "List the audit log of the server"
+}
+
+func (c *AuditListCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant audit list [options]
+  List the calls made to the server of the current context which mutate
+  data, most recent first.
+
+` + c.Flags().Display())
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/hashicorp/vagrant-plugin-sdk/component"
-	"github.com/hashicorp/vagrant-plugin-sdk/helper/paths"
 	"github.com/hashicorp/vagrant-plugin-sdk/internal-shared/cleanup"
 	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
 	"github.com/hashicorp/vagrant/internal/clicontext"
@@ -61,6 +60,13 @@ type baseCommand struct {
 	project *clientpkg.Project
 	// optional target to run operations against
 	target *clientpkg.Target
+	// optional targets to run operations against, set instead of
+	// target when multiple targets are given
+	targets []*clientpkg.Target
+
+	// dataSource is set when the project workspace has been uploaded
+	// for use by a remote runner.
+	dataSource *vagrant_server.Job_DataSource
 
 	// clientContext is set to the context information for the current
 	// connection. This might not exist in the contextStorage yet if this
@@ -86,15 +92,27 @@ type baseCommand struct {
 	// a local runner.
 	flagRemote bool
 
-	// flagTarget is the machine to target.
+	// flagTarget is the machine to target, or machines separated by
+	// commas.
 	flagTarget string
 
+	// flagParallelism is the maximum number of targets an operation is
+	// run against at once.
+	flagParallelism uint32
+
+	// flagContinueOnError is whether an operation is still started on
+	// the remaining targets after it fails on a target.
+	flagContinueOnError bool
+
 	// flagInteractive is whether the output is interactive
 	flagInteractive bool
 
 	// flagMachineReadable is whether the terminal ui should only output machine readable data
 	flagMachineReadable bool
 
+	// flagVagrantfileCache is whether cached parses of Ruby Vagrantfiles are used
+	flagVagrantfileCache bool
+
 	// flagConnection contains manual flag-based connection info.
 	flagConnection clicontext.Config
 
@@ -206,20 +224,10 @@ warning set the environment variable 'VAGRANT_SUPPRESS_GO_EXPERIMENTAL_WARNING'.
 `, terminal.WithWarningStyle())
 	}
 
-	homeConfigPath, err := paths.NamedVagrantConfig(bc.flagBasis)
-	if err != nil {
-		return nil, err
-	}
-	bc.Log.Info("vagrant home directory defined",
-		"path", homeConfigPath)
-
 	// Setup our directory for context storage
-	contextStorage, err := clicontext.NewStorage(
-		clicontext.WithDir(homeConfigPath.Join("context")))
-	if err != nil {
+	if bc.contextStorage, err = bc.initContextStorage(); err != nil {
 		return nil, err
 	}
-	bc.contextStorage = contextStorage
 
 	// We use our flag-based connection info if the user set an addr.
 	var flagConnection *clicontext.Config
@@ -245,6 +253,7 @@ warning set the environment variable 'VAGRANT_SUPPRESS_GO_EXPERIMENTAL_WARNING'.
 	clientOpts := []clientpkg.Option{
 		clientpkg.WithLogger(bc.Log.ResetNamed("vagrant.client")),
 		clientpkg.WithClientConnect(connectOpts...),
+		clientpkg.WithVagrantfileCache(bc.flagVagrantfileCache),
 	}
 	if !bc.flagRemote {
 		clientOpts = append(clientOpts, clientpkg.WithLocal())
@@ -298,8 +307,19 @@ warning set the environment variable 'VAGRANT_SUPPRESS_GO_EXPERIMENTAL_WARNING'.
 			return nil, fmt.Errorf("cannot load target without valid project")
 		}
 
-		if bc.target, err = bc.project.LoadTarget(bc.flagTarget); err != nil {
-			return nil, err
+		names := splitList(bc.flagTarget)
+		if len(names) == 1 {
+			if bc.target, err = bc.project.LoadTarget(names[0]); err != nil {
+				return nil, err
+			}
+		} else {
+			for _, n := range names {
+				t, err := bc.project.LoadTarget(n)
+				if err != nil {
+					return nil, err
+				}
+				bc.targets = append(bc.targets, t)
+			}
 		}
 	}
 
@@ -355,9 +375,12 @@ func (c *baseCommand) Init(opts ...Option) (err error) {
 	}
 
 	// Parse the configuration (config does not need to exist)
-	// TODO: This should be `c.initConfig(true)`,
-	//       need to set the basis path first
-	c.cfg = &config.Config{}
+	if baseCfg.Config {
+		if c.cfg, err = c.initConfig(true); err != nil {
+			c.logError(c.Log, "failed to load Vagrant configuration", err)
+			return err
+		}
+	}
 
 	// Validate remote vs. local operations.
 	if c.flagRemote && c.target == nil {
@@ -373,6 +396,15 @@ func (c *baseCommand) Init(opts ...Option) (err error) {
 		}
 	}
 
+	// Remote runners can't see the local project directory, so upload
+	// the current workspace for them to operate on.
+	if c.flagRemote && c.project != nil {
+		if c.dataSource, err = c.project.UploadWorkspace(); err != nil {
+			c.logError(c.Log, "failed to upload project workspace", err)
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -411,6 +443,9 @@ func (c *baseCommand) Modifier() client.JobModifier {
 		if c.target != nil {
 			j.Target = c.target.Ref()
 		}
+		if c.dataSource != nil {
+			j.DataSource = c.dataSource
+		}
 	}
 }
 
@@ -446,7 +481,7 @@ func (c *baseCommand) flagSet(bit flagSetBit, f func([]*component.CommandFlag) [
 		},
 		{
 			LongName:     "target",
-			Description:  "Target to apply command",
+			Description:  "Target to apply command, or targets separated by commas",
 			DefaultValue: "",
 			Type:         component.FlagString,
 		},
@@ -462,6 +497,12 @@ func (c *baseCommand) flagSet(bit flagSetBit, f func([]*component.CommandFlag) [
 			DefaultValue: "false",
 			Type:         component.FlagBool,
 		},
+		{
+			LongName:     "vagrantfile-cache",
+			Description:  "Reuse the cached parse of unchanged Ruby Vagrantfiles. Files and environment variables read by the Vagrantfile itself are not checked for changes",
+			DefaultValue: "false",
+			Type:         component.FlagBool,
+		},
 	}
 
 	if bit&flagSetOperation != 0 {
@@ -477,6 +518,18 @@ func (c *baseCommand) flagSet(bit flagSetBit, f func([]*component.CommandFlag) [
 				Description: "Override how remote runners source data",
 				Type:        component.FlagString,
 			},
+			&component.CommandFlag{
+				LongName:     "parallelism",
+				Description:  "Maximum number of targets to run against at once",
+				DefaultValue: "1",
+				Type:         component.FlagString,
+			},
+			&component.CommandFlag{
+				LongName:     "continue-on-error",
+				Description:  "Keep running against the remaining targets after failing on a target",
+				DefaultValue: "false",
+				Type:         component.FlagBool,
+			},
 		)
 	}
 
@@ -550,6 +603,12 @@ func (c *baseCommand) Parse(
 			c.flagInteractive = pf.DefaultValue().(bool)
 		case "machine-readable":
 			c.flagMachineReadable = pf.DefaultValue().(bool)
+		case "vagrantfile-cache":
+			c.flagVagrantfileCache = pf.DefaultValue().(bool)
+		case "parallelism":
+			c.flagParallelism = 1
+		case "continue-on-error":
+			c.flagContinueOnError = pf.DefaultValue().(bool)
 		}
 		if !pf.Updated() {
 			continue
@@ -568,12 +627,44 @@ func (c *baseCommand) Parse(
 			c.flagInteractive = pf.Value().(bool)
 		case "machine-readable":
 			c.flagMachineReadable = pf.Value().(bool)
+		case "vagrantfile-cache":
+			c.flagVagrantfileCache = pf.Value().(bool)
+		case "parallelism":
+			v, err := strconv.ParseUint(pf.Value().(string), 10, 32)
+			if err != nil || v == 0 {
+				return nil, fmt.Errorf("invalid parallelism %q, must be a positive number",
+					pf.Value())
+			}
+			c.flagParallelism = uint32(v)
+		case "continue-on-error":
+			c.flagContinueOnError = pf.Value().(bool)
 		}
 		c.flagData[f] = pf.Value()
 	}
 	return remainArgs, nil
 }
 
+// stringFlag returns the value of the given string flag, or its
+// default value if the flag was not set.
+func (c *baseCommand) stringFlag(f *component.CommandFlag) string {
+	if v, ok := c.flagData[f].(string); ok {
+		return v
+	}
+
+	return f.DefaultValue
+}
+
+// boolFlag returns the value of the given bool flag, or its default
+// value if the flag was not set.
+func (c *baseCommand) boolFlag(f *component.CommandFlag) bool {
+	if v, ok := c.flagData[f].(bool); ok {
+		return v
+	}
+
+	v, _ := strconv.ParseBool(f.DefaultValue)
+	return v
+}
+
 func (c *baseCommand) generateCliFlags(set []*component.CommandFlag) *flags.Set {
 	fs := flags.NewSet("flags",
 		flags.SetErrorMode(flags.ReturnOnError),
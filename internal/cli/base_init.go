@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/paths"
+	"github.com/hashicorp/vagrant/internal/clicontext"
 	clientpkg "github.com/hashicorp/vagrant/internal/client"
 	configpkg "github.com/hashicorp/vagrant/internal/config"
 )
@@ -30,11 +33,21 @@ func (c *baseCommand) initConfig(optional bool) (*configpkg.Config, error) {
 
 // initConfigPath returns the configuration path to load.
 func (c *baseCommand) initConfigPath() (string, error) {
-	// This configuarion is for the Vagrant process, not the same as a Vagrantfile
-	path, err := configpkg.FindPath(c.basis.Path(), "vagrant-config.hcl")
+	// This configuarion is for the Vagrant process, not the same as a Vagrantfile.
+	// It is found at or above the project directory, or the working directory
+	// when there is no project.
+	var dir path.Path
+	if c.project != nil {
+		dir = c.project.Path()
+	}
+	path, err := configpkg.FindPath(dir, configpkg.ConfigFilename)
 	if err != nil {
 		return "", fmt.Errorf("error looking for a Vagrant configuration: %s", err)
 	}
+	if path == nil {
+		return "", fmt.Errorf("no Vagrant configuration file (%s) found",
+			configpkg.ConfigFilename)
+	}
 
 	return path.String(), nil
 }
@@ -64,3 +77,17 @@ func (c *baseCommand) initClient() (*clientpkg.Client, error) {
 	// Create our client
 	return clientpkg.New(c.Ctx, opts...)
 }
+
+// initContextStorage initializes the storage for CLI contexts within the
+// Vagrant home directory of the basis.
+func (c *baseCommand) initContextStorage() (*clicontext.Storage, error) {
+	homeConfigPath, err := paths.NamedVagrantConfig(c.flagBasis)
+	if err != nil {
+		return nil, err
+	}
+	c.Log.Info("vagrant home directory defined",
+		"path", homeConfigPath)
+
+	return clicontext.NewStorage(
+		clicontext.WithDir(homeConfigPath.Join("context")))
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	configpkg "github.com/hashicorp/vagrant/internal/config"
+)
+
+func TestBaseCommandInitRemote(t *testing.T) {
+	init := func(t *testing.T, config string) (*baseCommand, error) {
+		dir := t.TempDir()
+		if config != "" {
+			require.NoError(t, ioutil.WriteFile(
+				filepath.Join(dir, configpkg.ConfigFilename), []byte(config), 0644))
+		}
+		t.Setenv("VAGRANT_CWD", dir)
+
+		c := &baseCommand{
+			Ctx:      context.Background(),
+			Log:      hclog.NewNullLogger(),
+			flagData: map[*component.CommandFlag]interface{}{},
+		}
+		return c, c.Init(
+			WithArgs([]string{"--remote"}),
+			WithFlags(c.flagSet(flagSetOperation, nil)),
+			WithUI(terminal.NonInteractiveUI(context.Background())),
+		)
+	}
+
+	t.Run("without a configuration", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := init(t, "")
+		require.Error(err)
+		require.Contains(err.Error(), "remote operations are not supported")
+	})
+
+	t.Run("with runners disabled", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := init(t, `labels = { "env" = "test" }`)
+		require.Error(err)
+		require.Contains(err.Error(), "remote operations are not supported")
+	})
+
+	t.Run("with runners enabled", func(t *testing.T) {
+		require := require.New(t)
+
+		c, err := init(t, `
+runner {
+  enabled = true
+}
+`)
+		require.NoError(err)
+		require.True(c.flagRemote)
+		require.NotNil(c.cfg)
+		require.True(c.cfg.Runner.Enabled)
+	})
+
+	t.Run("with an invalid configuration", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := init(t, `runner {`)
+		require.Error(err)
+	})
+}
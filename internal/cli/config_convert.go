@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	vconfig "github.com/hashicorp/vagrant-plugin-sdk/config"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	configpkg "github.com/hashicorp/vagrant/internal/config"
+	"github.com/hashicorp/vagrant/internal/serverclient"
+)
+
+type ConfigConvertCommand struct {
+	*baseCommand
+
+	flagOutput *component.CommandFlag
+	flagForce  *component.CommandFlag
+}
+
+func (c *ConfigConvertCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	// Use the path given or find the Ruby Vagrantfile
+	var path string
+	switch len(c.args) {
+	case 0:
+		p, err := vconfig.FindPath(nil, []string{vconfig.RubyFilename})
+		if err != nil {
+			c.logError(c.Log, "", err)
+			return 1
+		}
+		if p == nil {
+			c.ui.Output("No Vagrantfile was found to convert.", terminal.WithErrorStyle())
+			return 1
+		}
+		path = p.String()
+	case 1:
+		var err error
+		if path, err = filepath.Abs(c.args[0]); err != nil {
+			c.logError(c.Log, "", err)
+			return 1
+		}
+	default:
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	if ext := filepath.Ext(path); ext == ".hcl" || ext == ".json" {
+		c.ui.Output("The Vagrantfile at %s is not a Ruby Vagrantfile.", path,
+			terminal.WithErrorStyle())
+		return 1
+	}
+
+	output := c.stringFlag(c.flagOutput)
+	if output == "" {
+		output = filepath.Join(filepath.Dir(path), vconfig.HCLFilename)
+	}
+	if output != "-" && !c.boolFlag(c.flagForce) {
+		if _, err := os.Stat(output); err == nil {
+			c.ui.Output("%s already exists. Use -force to overwrite it.", output,
+				terminal.WithErrorStyle())
+			return 1
+		}
+	}
+
+	raw, err := c.basis.VagrantRubyRuntime().Dispense("vagrantrubyruntime")
+	if err != nil {
+		c.logError(c.Log, "failed to load ruby runtime", err)
+		return 1
+	}
+
+	rubyClient := raw.(serverclient.RubyVagrantClient)
+	unfinalized, err := rubyClient.ParseVagrantfile(path)
+	if err != nil {
+		c.logError(c.Log, "failed to parse Vagrantfile", err)
+		return 1
+	}
+
+	result, skipped, err := configpkg.ConvertVagrantfile(unfinalized)
+	if err != nil {
+		c.logError(c.Log, "failed to convert Vagrantfile", err)
+		return 1
+	}
+	result = append([]byte(fmt.Sprintf("# Converted from %s\n\n", path)), result...)
+
+	// Make sure the result can be loaded before it is written
+	if _, err := configpkg.ParseVagrantfile(result, vconfig.HCLFilename); err != nil {
+		c.logError(c.Log, "converted Vagrantfile could not be loaded", err)
+		return 1
+	}
+
+	if output == "-" {
+		stdout, _, err := c.ui.OutputWriters()
+		if err == nil {
+			_, err = stdout.Write(result)
+		}
+		if err != nil {
+			c.logError(c.Log, "failed to write HCL Vagrantfile", err)
+			return 1
+		}
+	} else {
+		if err := os.WriteFile(output, result, 0644); err != nil {
+			c.logError(c.Log, "failed to write HCL Vagrantfile", err)
+			return 1
+		}
+
+		c.ui.Output("Converted %s to %s.", path, output, terminal.WithSuccessStyle())
+	}
+
+	if skipped > 0 {
+		c.ui.Output("%d value(s) could not be converted and are marked with a "+
+			"\"# vagrant:\" comment. They must be converted by hand.", skipped,
+			terminal.WithWarningStyle())
+	}
+
+	return 0
+}
+
+func (c *ConfigConvertCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagOutput = &component.CommandFlag{
+			LongName:    "output",
+			Description: "Path to write the HCL Vagrantfile to, or \"-\" for stdout. Defaults to Vagrantfile.hcl next to the Vagrantfile",
+			Type:        component.FlagString,
+		}
+		c.flagForce = &component.CommandFlag{
+			LongName:     "force",
+			Description:  "Overwrite the output file if it exists",
+			DefaultValue: "false",
+			Type:         component.FlagBool,
+		}
+
+		return append(set, c.flagOutput, c.flagForce)
+	})
+}
+
+func (c *ConfigConvertCommand) Primary() bool {
+	return false
+}
+
+func (c *ConfigConvertCommand) Synopsis() string {
+	return "Convert a Ruby Vagrantfile to HCL"
+}
+
+func (c *ConfigConvertCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant config convert [options] [PATH]
+  Convert a Ruby Vagrantfile to an HCL Vagrantfile.
+
+  If PATH is not given, the Vagrantfile is searched for starting at the
+  current directory. The Vagrantfile is evaluated by the Ruby runtime and
+  the resulting configuration is written as HCL, so any Ruby logic in the
+  Vagrantfile is replaced by the values it produced. The HCL Vagrantfile is
+  loaded instead of the Ruby Vagrantfile once the Ruby Vagrantfile is removed.
+
+  Values which cannot be represented in HCL, such as procs and Ruby objects,
+  are left out and marked with a "# vagrant:" comment in the output.
+
+` + c.Flags().Display())
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+type ConfigLintCommand struct {
+	*baseCommand
+
+	flagStrict *component.CommandFlag
+}
+
+func (c *ConfigLintCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+	); err != nil {
+		return 1
+	}
+
+	if c.project == nil {
+		c.ui.Output("No Vagrantfile was found to lint.", terminal.WithErrorStyle())
+		return 1
+	}
+
+	result, err := c.client.Validate(c.Ctx,
+		&vagrant_server.Job_ValidateOp{
+			Targets: c.args,
+		},
+		c.Modifier(),
+	)
+	if err != nil {
+		c.logError(c.Log, "failed to validate Vagrantfile", err)
+		return 1
+	}
+
+	var errors, warnings int
+	for _, t := range result.Targets {
+		if len(t.Errors) == 0 && len(t.Warnings) == 0 {
+			continue
+		}
+
+		c.ui.Output(t.Name, terminal.WithHeaderStyle())
+		for _, p := range t.Errors {
+			c.ui.Output("%s", formatProblem(p), terminal.WithErrorStyle())
+		}
+		for _, p := range t.Warnings {
+			c.ui.Output("%s", formatProblem(p), terminal.WithWarningStyle())
+		}
+
+		errors += len(t.Errors)
+		warnings += len(t.Warnings)
+	}
+
+	if errors > 0 || (warnings > 0 && c.boolFlag(c.flagStrict)) {
+		c.ui.Output("The Vagrantfile has %d error(s) and %d warning(s).",
+			errors, warnings, terminal.WithErrorStyle())
+		return 1
+	}
+
+	if warnings > 0 {
+		c.ui.Output("The Vagrantfile is valid with %d warning(s).", warnings,
+			terminal.WithWarningStyle())
+		return 0
+	}
+
+	c.ui.Output("The Vagrantfile is valid.", terminal.WithSuccessStyle())
+	return 0
+}
+
+func formatProblem(p *vagrant_server.Job_ValidateResult_Problem) string {
+	if p.Namespace == "" {
+		return p.Message
+	}
+
+	return p.Namespace + ": " + p.Message
+}
+
+func (c *ConfigLintCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagStrict = &component.CommandFlag{
+			LongName:     "strict",
+			Description:  "Fail if any warnings are found",
+			DefaultValue: "false",
+			Type:         component.FlagBool,
+		}
+
+		return append(set, c.flagStrict)
+	})
+}
+
+func (c *ConfigLintCommand) Primary() bool {
+	return false
+}
+
+func (c *ConfigLintCommand) Synopsis() string {
+	return "Check the Vagrantfile for problems"
+}
+
+func (c *ConfigLintCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant config lint [options] [TARGET...]
+  Check the configuration of targets for problems.
+
+  If no TARGET is given, every target defined in the Vagrantfile is checked.
+  Errors are reported for problems such as unknown options, duplicate
+  forwarded ports and providers which are not installed. Warnings are
+  reported for problems which will not stop a target from starting, such
+  as a box which still needs to be downloaded.
+
+  The command exits with a non-zero status if any errors are found, or if
+  any warnings are found and -strict is set.
+
+` + c.Flags().Display())
+}
@@ -0,0 +1,306 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	configpkg "github.com/hashicorp/vagrant/internal/config"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+type ConfigShowCommand struct {
+	*baseCommand
+
+	flagFormat   *component.CommandFlag
+	flagDiff     *component.CommandFlag
+	flagProvider *component.CommandFlag
+}
+
+func (c *ConfigShowCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) > 1 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	if c.project == nil {
+		c.ui.Output("No Vagrantfile was found to show the configuration of.",
+			terminal.WithErrorStyle())
+		return 1
+	}
+
+	var name string
+	if len(c.args) == 1 {
+		name = c.args[0]
+	}
+
+	format := c.stringFlag(c.flagFormat)
+	if format != "hcl" && format != "json" {
+		c.ui.Output("Unknown format %q, must be \"hcl\" or \"json\".", format,
+			terminal.WithErrorStyle())
+		return 1
+	}
+
+	result, err := c.targetConfig(name)
+	if err != nil {
+		c.logError(c.Log, "failed to load target configuration", err)
+		return 1
+	}
+
+	if other := c.stringFlag(c.flagDiff); other != "" {
+		otherResult, err := c.targetConfig(other)
+		if err != nil {
+			c.logError(c.Log, "failed to load target configuration", err)
+			return 1
+		}
+
+		if err := c.showDiff(result, otherResult); err != nil {
+			c.logError(c.Log, "failed to compare target configuration", err)
+			return 1
+		}
+
+		return 0
+	}
+
+	var output []byte
+	switch format {
+	case "json":
+		output, err = configJSON(result)
+	default:
+		output, err = configHCL(result)
+	}
+	if err != nil {
+		c.logError(c.Log, "failed to format target configuration", err)
+		return 1
+	}
+
+	stdout, _, err := c.ui.OutputWriters()
+	if err == nil {
+		_, err = stdout.Write(output)
+	}
+	if err != nil {
+		c.logError(c.Log, "failed to write target configuration", err)
+		return 1
+	}
+
+	return 0
+}
+
+// targetConfig requests the finalized configuration of the named target,
+// or the primary target if no name is given.
+func (c *ConfigShowCommand) targetConfig(name string) (*vagrant_server.Job_ConfigResult, error) {
+	return c.client.Config(c.Ctx,
+		&vagrant_server.Job_ConfigOp{
+			Target:   name,
+			Provider: c.stringFlag(c.flagProvider),
+		},
+		c.Modifier(),
+	)
+}
+
+// showDiff writes a table of the options which differ between the
+// configuration of two targets.
+func (c *ConfigShowCommand) showDiff(a, b *vagrant_server.Job_ConfigResult) error {
+	aOptions, err := configOptionValues(a)
+	if err != nil {
+		return err
+	}
+	bOptions, err := configOptionValues(b)
+	if err != nil {
+		return err
+	}
+
+	keys := map[string]struct{}{}
+	for k := range aOptions {
+		keys[k] = struct{}{}
+	}
+	for k := range bOptions {
+		keys[k] = struct{}{}
+	}
+
+	var differ []string
+	for k := range keys {
+		if aOptions[k] != bOptions[k] {
+			differ = append(differ, k)
+		}
+	}
+	sort.Strings(differ)
+
+	if len(differ) == 0 {
+		c.ui.Output("The configuration of %s and %s is the same.", a.Target, b.Target,
+			terminal.WithSuccessStyle())
+		return nil
+	}
+
+	tbl := terminal.NewTable("OPTION", strings.ToUpper(a.Target), strings.ToUpper(b.Target))
+	for _, k := range differ {
+		tbl.Rich([]string{
+			k,
+			diffValue(aOptions[k], a.Sources[k]),
+			diffValue(bOptions[k], b.Sources[k]),
+		}, nil)
+	}
+	c.ui.Table(tbl)
+
+	return nil
+}
+
+func (c *ConfigShowCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagFormat = &component.CommandFlag{
+			LongName:     "format",
+			Description:  "Format of the output, \"hcl\" or \"json\"",
+			DefaultValue: "hcl",
+			Type:         component.FlagString,
+		}
+		c.flagDiff = &component.CommandFlag{
+			LongName:    "diff",
+			Description: "Name of another target to compare the configuration with",
+			Type:        component.FlagString,
+		}
+		c.flagProvider = &component.CommandFlag{
+			LongName:    "provider",
+			Description: "Provider to include the configuration overrides of",
+			Type:        component.FlagString,
+		}
+
+		return append(set, c.flagFormat, c.flagDiff, c.flagProvider)
+	})
+}
+
+func (c *ConfigShowCommand) Primary() bool {
+	return false
+}
+
+func (c *ConfigShowCommand) Synopsis() string {
+	return "Show the configuration of a target"
+}
+
+func (c *ConfigShowCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant config show [options] [TARGET]
+  Show the finalized configuration of a target.
+
+  If TARGET is not given, the primary target is shown. The configuration is
+  shown after the box, basis, project, target and provider Vagrantfiles are
+  merged, and each option is annotated with the Vagrantfile which last set
+  it. Options no Vagrantfile sets have their default value.
+
+  With -diff, the options which differ between TARGET and the other target
+  are listed instead.
+
+` + c.Flags().Display())
+}
+
+// configHCL formats the configuration as HCL, with a comment above each
+// option naming the Vagrantfile which set it.
+func configHCL(result *vagrant_server.Job_ConfigResult) ([]byte, error) {
+	output, _, err := configpkg.ConvertVagrantfile(result.Finalized,
+		configpkg.WithAnnotation(func(name string) string {
+			if !strings.Contains(name, ".") {
+				return ""
+			}
+			if source, ok := result.Sources[name]; ok {
+				return fmt.Sprintf("set by the %s Vagrantfile", source)
+			}
+
+			return "default"
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(fmt.Sprintf("# Configuration of %s\n\n", result.Target)), output...), nil
+}
+
+// configJSON formats the configuration as JSON. The Vagrantfile which
+// set each option, and the reason any option couldn't be included, are
+// given alongside the configuration.
+func configJSON(result *vagrant_server.Job_ConfigResult) ([]byte, error) {
+	value, skipped, err := configpkg.VagrantfileValue(result.Finalized)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := ctyjson.Marshal(value, value.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := json.MarshalIndent(struct {
+		Target  string            `json:"target"`
+		Config  json.RawMessage   `json:"config"`
+		Sources map[string]string `json:"sources"`
+		Skipped map[string]string `json:"skipped,omitempty"`
+	}{
+		Target:  result.Target,
+		Config:  config,
+		Sources: result.Sources,
+		Skipped: skipped,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(output, '\n'), nil
+}
+
+// configOptionValues returns the value of each option of the configuration
+// keyed by name, such as "vm.box". Values are formatted as HCL.
+func configOptionValues(result *vagrant_server.Job_ConfigResult) (map[string]string, error) {
+	value, skipped, err := configpkg.VagrantfileValue(result.Finalized)
+	if err != nil {
+		return nil, err
+	}
+
+	options := map[string]string{}
+	for namespace, ns := range value.AsValueMap() {
+		if ns.IsNull() || !ns.Type().IsObjectType() {
+			options[namespace] = formatConfigValue(ns)
+			continue
+		}
+
+		for key, v := range ns.AsValueMap() {
+			options[namespace+"."+key] = formatConfigValue(v)
+		}
+	}
+	for name := range skipped {
+		options[name] = "(not representable)"
+	}
+
+	return options, nil
+}
+
+func formatConfigValue(v cty.Value) string {
+	return strings.TrimSpace(string(hclwrite.TokensForValue(v).Bytes()))
+}
+
+// diffValue formats an option value for the diff table.
+func diffValue(value, source string) string {
+	if value == "" {
+		return "(unset)"
+	}
+	if source == "" {
+		source = "default"
+	}
+
+	return fmt.Sprintf("%s (%s)", value, source)
+}
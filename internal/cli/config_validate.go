@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	configpkg "github.com/hashicorp/vagrant/internal/config"
+)
+
+type ConfigValidateCommand struct {
+	*baseCommand
+}
+
+func (c *ConfigValidateCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+		WithNoConfig(),
+		WithClient(false),
+	); err != nil {
+		return 1
+	}
+
+	// Use the path given or find the configuration file
+	var path string
+	switch len(c.args) {
+	case 0:
+		var err error
+		if path, err = c.initConfigPath(); err != nil {
+			c.logError(c.Log, "", err)
+			return 1
+		}
+	case 1:
+		var err error
+		if path, err = filepath.Abs(c.args[0]); err != nil {
+			c.logError(c.Log, "", err)
+			return 1
+		}
+	default:
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	cfg, err := configpkg.Load(path, filepath.Dir(path))
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		diags, ok := err.(hcl.Diagnostics)
+		if !ok {
+			c.logError(c.Log, "", err)
+			return 1
+		}
+
+		var files map[string]*hcl.File
+		if cfg != nil {
+			files = cfg.Files()
+		}
+		if err := c.writeDiagnostics(files, diags); err != nil {
+			c.logError(c.Log, "", err)
+		}
+
+		c.ui.Output("The Vagrant configuration at %s is invalid.", path,
+			terminal.WithErrorStyle())
+		return 1
+	}
+
+	c.ui.Output("The Vagrant configuration at %s is valid.", path,
+		terminal.WithSuccessStyle())
+	return 0
+}
+
+// writeDiagnostics writes the diagnostics to the UI including the
+// source snippets for any of the given files.
+func (c *baseCommand) writeDiagnostics(files map[string]*hcl.File, diags hcl.Diagnostics) error {
+	_, stderr, err := c.ui.OutputWriters()
+	if err != nil {
+		return err
+	}
+
+	return hcl.NewDiagnosticTextWriter(stderr, files, 78, c.flagColor).WriteDiagnostics(diags)
+}
+
+func (c *ConfigValidateCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *ConfigValidateCommand) Primary() bool {
+	return false
+}
+
+func (c *ConfigValidateCommand) Synopsis() string {
+	return "Validate the Vagrant configuration file"
+}
+
+func (c *ConfigValidateCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant config validate [PATH]
+  Validate the Vagrant configuration file.
+
+  If PATH is not given, the vagrant-config.hcl file is searched for starting
+  at the current basis directory. Any problems are reported with the file and
+  line they were found on.
+`)
+}
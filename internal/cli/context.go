@@ -0,0 +1,600 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/clicontext"
+	"github.com/hashicorp/vagrant/internal/protocolversion"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	"github.com/hashicorp/vagrant/internal/serverclient"
+	"github.com/hashicorp/vagrant/internal/serverconfig"
+)
+
+// contextVerifyTimeout is how long to wait when connecting to the server
+// of a context being verified.
+const contextVerifyTimeout = 10 * time.Second
+
+// initContextCommand initializes a context command. Context commands only
+// operate on the stored contexts so they do not connect to a server.
+func (c *baseCommand) initContextCommand(args []string, flags component.CommandFlags) error {
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flags),
+		WithNoConfig(),
+		WithClient(false),
+	); err != nil {
+		return err
+	}
+
+	if c.contextStorage == nil {
+		var err error
+		if c.contextStorage, err = c.initContextStorage(); err != nil {
+			c.logError(c.Log, "failed to load contexts", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// contextName returns the context name given in the arguments, or the
+// default context if no name was given.
+func (c *baseCommand) contextName() (string, error) {
+	if len(c.args) > 0 {
+		return c.args[0], nil
+	}
+
+	name, err := c.contextStorage.Default()
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", fmt.Errorf("No context name given and no default context is set")
+	}
+
+	return name, nil
+}
+
+// initServerCommand initializes a command which manages the server and
+// connects to the server of the current context. The returned connection
+// must be closed by the caller.
+func (c *baseCommand) initServerCommand(args []string, flags component.CommandFlags) (*grpc.ClientConn, error) {
+	if err := c.initContextCommand(args, flags); err != nil {
+		return nil, err
+	}
+
+	conn, err := serverclient.Connect(c.Ctx,
+		serverclient.FromContext(c.contextStorage, ""),
+		serverclient.FromEnv(),
+	)
+	if err != nil {
+		c.logError(c.Log, "failed to connect to the server", err)
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// outputToken writes a token to stdout as is, so it can be captured by
+// scripts without the indentation of regular output.
+func (c *baseCommand) outputToken(token string) error {
+	stdout, _, err := c.ui.OutputWriters()
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(stdout, token)
+	return err
+}
+
+// formatTime formats a timestamp for display. If the timestamp is not
+// set, unset is returned instead.
+func formatTime(ts *timestamppb.Timestamp, unset string) string {
+	if ts == nil {
+		return unset
+	}
+
+	return ts.AsTime().Local().Format(time.RFC3339)
+}
+
+type ContextListCommand struct {
+	*baseCommand
+}
+
+func (c *ContextListCommand) Run(args []string) int {
+	if err := c.initContextCommand(args, c.Flags()); err != nil {
+		return 1
+	}
+
+	names, err := c.contextStorage.List()
+	if err == nil {
+		sort.Strings(names)
+	}
+	def, derr := c.contextStorage.Default()
+	if err == nil {
+		err = derr
+	}
+	if err != nil {
+		c.logError(c.Log, "failed to list contexts", err)
+		return 1
+	}
+
+	if len(names) == 0 {
+		c.ui.Output("No contexts. Create one with `vagrant context create`.")
+		return 0
+	}
+
+	tbl := terminal.NewTable("", "NAME", "SERVER ADDRESS")
+	for _, name := range names {
+		addr := ""
+		if cfg, err := c.contextStorage.Load(name); err == nil {
+			addr = cfg.Server.Address
+		} else {
+			c.Log.Warn("failed to load context", "name", name, "error", err)
+		}
+
+		current := ""
+		if name == def {
+			current = "*"
+		}
+
+		tbl.Rich([]string{current, name, addr}, nil)
+	}
+	c.ui.Table(tbl)
+
+	return 0
+}
+
+func (c *ContextListCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *ContextListCommand) Primary() bool {
+	return false
+}
+
+func (c *ContextListCommand) Synopsis() string {
+	return "List contexts"
+}
+
+func (c *ContextListCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant context list
+  List the available contexts. The default context is marked with "*".
+`)
+}
+
+type ContextCreateCommand struct {
+	*baseCommand
+
+	flagAddr          *component.CommandFlag
+	flagTls           *component.CommandFlag
+	flagTlsSkipVerify *component.CommandFlag
+	flagTlsCert       *component.CommandFlag
+	flagTlsKey        *component.CommandFlag
+	flagTlsCA         *component.CommandFlag
+	flagAuthToken     *component.CommandFlag
+	flagSetDefault    *component.CommandFlag
+}
+
+func (c *ContextCreateCommand) Run(args []string) int {
+	if err := c.initContextCommand(args, c.Flags()); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+	name := c.args[0]
+
+	addr := c.stringFlag(c.flagAddr)
+	if addr == "" {
+		c.logError(c.Log, "", fmt.Errorf("The --server-addr flag is required"))
+		return 1
+	}
+
+	token := c.stringFlag(c.flagAuthToken)
+	cfg := &clicontext.Config{
+		Server: serverconfig.Client{
+			Address:       addr,
+			Tls:           c.boolFlag(c.flagTls),
+			TlsSkipVerify: c.boolFlag(c.flagTlsSkipVerify),
+			TlsCertFile:   c.stringFlag(c.flagTlsCert),
+			TlsKeyFile:    c.stringFlag(c.flagTlsKey),
+			TlsCAFile:     c.stringFlag(c.flagTlsCA),
+			RequireAuth:   token != "",
+			AuthToken:     token,
+		},
+	}
+
+	if err := c.contextStorage.Set(name, cfg); err != nil {
+		c.logError(c.Log, "failed to create context", err)
+		return 1
+	}
+	if c.boolFlag(c.flagSetDefault) {
+		if err := c.contextStorage.SetDefault(name); err != nil {
+			c.logError(c.Log, "failed to set default context", err)
+			return 1
+		}
+	}
+
+	c.ui.Output("Context %q created.", name, terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *ContextCreateCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagAddr = &component.CommandFlag{
+			LongName:    "server-addr",
+			Description: "Address of the server",
+			Type:        component.FlagString,
+		}
+		c.flagTls = &component.CommandFlag{
+			LongName:     "server-tls",
+			Description:  "Connect to the server with TLS",
+			DefaultValue: "true",
+			Type:         component.FlagBool,
+		}
+		c.flagTlsSkipVerify = &component.CommandFlag{
+			LongName:     "server-tls-skip-verify",
+			Description:  "Skip verification of the TLS certificate advertised by the server",
+			DefaultValue: "false",
+			Type:         component.FlagBool,
+		}
+		c.flagTlsCert = &component.CommandFlag{
+			LongName:    "server-tls-cert",
+			Description: "Path to the client certificate presented to the server",
+			Type:        component.FlagString,
+		}
+		c.flagTlsKey = &component.CommandFlag{
+			LongName:    "server-tls-key",
+			Description: "Path to the private key of the client certificate",
+			Type:        component.FlagString,
+		}
+		c.flagTlsCA = &component.CommandFlag{
+			LongName:    "server-tls-ca",
+			Description: "Path to the CA certificate used to verify the server",
+			Type:        component.FlagString,
+		}
+		c.flagAuthToken = &component.CommandFlag{
+			LongName:    "server-auth-token",
+			Description: "Token to authenticate with the server",
+			Type:        component.FlagString,
+		}
+		c.flagSetDefault = &component.CommandFlag{
+			LongName:     "set-default",
+			Description:  "Set this context as the default context",
+			DefaultValue: "false",
+			Type:         component.FlagBool,
+		}
+
+		return append(set, c.flagAddr, c.flagTls, c.flagTlsSkipVerify,
+			c.flagTlsCert, c.flagTlsKey, c.flagTlsCA, c.flagAuthToken,
+			c.flagSetDefault)
+	})
+}
+
+func (c *ContextCreateCommand) Primary() bool {
+	return false
+}
+
+func (c *ContextCreateCommand) Synopsis() string {
+	return "Create a context"
+}
+
+func (c *ContextCreateCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant context create --server-addr=ADDR [options] NAME
+  Create a context to connect to a Vagrant server.
+
+  If a context with the same name exists it is replaced. If there is no
+  default context the new context becomes the default.
+`)
+}
+
+type ContextUseCommand struct {
+	*baseCommand
+}
+
+func (c *ContextUseCommand) Run(args []string) int {
+	if err := c.initContextCommand(args, c.Flags()); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+	name := c.args[0]
+
+	if err := c.contextStorage.SetDefault(name); err != nil {
+		c.logError(c.Log, fmt.Sprintf("failed to use context %q", name), err)
+		return 1
+	}
+
+	c.ui.Output("Context %q is now the default.", name, terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *ContextUseCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *ContextUseCommand) Primary() bool {
+	return false
+}
+
+func (c *ContextUseCommand) Synopsis() string {
+	return "Set the default context"
+}
+
+func (c *ContextUseCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant context use NAME
+  Set the default context used to connect to a Vagrant server.
+`)
+}
+
+type ContextRenameCommand struct {
+	*baseCommand
+}
+
+func (c *ContextRenameCommand) Run(args []string) int {
+	if err := c.initContextCommand(args, c.Flags()); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 2 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+	from, to := c.args[0], c.args[1]
+
+	if err := c.contextStorage.Rename(from, to); err != nil {
+		c.logError(c.Log, "failed to rename context", err)
+		return 1
+	}
+
+	c.ui.Output("Context %q renamed to %q.", from, to, terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *ContextRenameCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *ContextRenameCommand) Primary() bool {
+	return false
+}
+
+func (c *ContextRenameCommand) Synopsis() string {
+	return "Rename a context"
+}
+
+func (c *ContextRenameCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant context rename FROM TO
+  Rename a context. If FROM is the default context, TO becomes the default.
+  If TO already exists it is replaced.
+`)
+}
+
+type ContextDeleteCommand struct {
+	*baseCommand
+}
+
+func (c *ContextDeleteCommand) Run(args []string) int {
+	if err := c.initContextCommand(args, c.Flags()); err != nil {
+		return 1
+	}
+
+	if len(c.args) == 0 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	for _, name := range c.args {
+		if err := c.contextStorage.Delete(name); err != nil {
+			c.logError(c.Log, fmt.Sprintf("failed to delete context %q", name), err)
+			return 1
+		}
+
+		c.ui.Output("Context %q deleted.", name, terminal.WithSuccessStyle())
+	}
+
+	return 0
+}
+
+func (c *ContextDeleteCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *ContextDeleteCommand) Primary() bool {
+	return false
+}
+
+func (c *ContextDeleteCommand) Synopsis() string {
+	return "Delete contexts"
+}
+
+func (c *ContextDeleteCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant context delete NAME...
+  Delete one or more contexts. If the default context is deleted there is
+  no default context until one is set with "vagrant context use".
+`)
+}
+
+type ContextInspectCommand struct {
+	*baseCommand
+}
+
+func (c *ContextInspectCommand) Run(args []string) int {
+	if err := c.initContextCommand(args, c.Flags()); err != nil {
+		return 1
+	}
+
+	if len(c.args) > 1 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	name, err := c.contextName()
+	if err != nil {
+		c.logError(c.Log, "", err)
+		return 1
+	}
+	cfg, err := c.contextStorage.Load(name)
+	if err != nil {
+		c.logError(c.Log, fmt.Sprintf("failed to load context %q", name), err)
+		return 1
+	}
+
+	def, err := c.contextStorage.Default()
+	if err != nil {
+		c.logError(c.Log, "failed to load default context", err)
+		return 1
+	}
+
+	token := "none"
+	if cfg.Server.AuthToken != "" {
+		token = "set"
+	}
+
+	c.ui.Output("Context %q", name, terminal.WithHeaderStyle())
+	c.ui.NamedValues([]terminal.NamedValue{
+		{Name: "default", Value: strconv.FormatBool(name == def)},
+		{Name: "server address", Value: cfg.Server.Address},
+		{Name: "tls", Value: strconv.FormatBool(cfg.Server.Tls)},
+		{Name: "tls skip verify", Value: strconv.FormatBool(cfg.Server.TlsSkipVerify)},
+		{Name: "tls client cert", Value: cfg.Server.TlsCertFile},
+		{Name: "tls ca", Value: cfg.Server.TlsCAFile},
+		{Name: "require auth", Value: strconv.FormatBool(cfg.Server.RequireAuth)},
+		{Name: "auth token", Value: token},
+	})
+
+	return 0
+}
+
+func (c *ContextInspectCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *ContextInspectCommand) Primary() bool {
+	return false
+}
+
+func (c *ContextInspectCommand) Synopsis() string {
+	return "Show the settings of a context"
+}
+
+func (c *ContextInspectCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant context inspect [NAME]
+  Show the settings of a context. If NAME is not given the default context
+  is shown. The auth token itself is never shown.
+`)
+}
+
+type ContextVerifyCommand struct {
+	*baseCommand
+}
+
+func (c *ContextVerifyCommand) Run(args []string) int {
+	if err := c.initContextCommand(args, c.Flags()); err != nil {
+		return 1
+	}
+
+	if len(c.args) > 1 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	name, err := c.contextName()
+	if err != nil {
+		c.logError(c.Log, "", err)
+		return 1
+	}
+	cfg, err := c.contextStorage.Load(name)
+	if err != nil {
+		c.logError(c.Log, fmt.Sprintf("failed to load context %q", name), err)
+		return 1
+	}
+
+	sg := c.ui.StepGroup()
+	defer sg.Wait()
+
+	s := sg.Add("Connecting to %s with context %q...", cfg.Server.Address, name)
+	defer func() { s.Abort() }()
+
+	conn, err := serverclient.Connect(c.Ctx,
+		serverclient.FromContextConfig(cfg),
+		serverclient.Timeout(contextVerifyTimeout),
+	)
+	if err != nil {
+		s.Update("Failed to connect to the server with context %q", name)
+		s.Status(terminal.StatusError)
+		s.Done()
+		c.logError(c.Log, "", err)
+		return 1
+	}
+	defer conn.Close()
+
+	resp, err := vagrant_server.NewVagrantClient(conn).GetVersionInfo(c.Ctx, &emptypb.Empty{})
+	if err == nil {
+		_, err = protocolversion.Negotiate(protocolversion.Current().Api, resp.Info.Api)
+	}
+	if err != nil {
+		s.Update("Failed to verify the server with context %q", name)
+		s.Status(terminal.StatusError)
+		s.Done()
+		c.logError(c.Log, "", err)
+		return 1
+	}
+
+	s.Update("Context %q connected successfully", name)
+	s.Done()
+	sg.Wait()
+
+	info := resp.Info
+	c.ui.NamedValues([]terminal.NamedValue{
+		{Name: "server version", Value: info.Version},
+		{Name: "api protocol", Value: fmt.Sprintf("%d (min %d)",
+			info.Api.GetCurrent(), info.Api.GetMinimum())},
+		{Name: "entrypoint protocol", Value: fmt.Sprintf("%d (min %d)",
+			info.Entrypoint.GetCurrent(), info.Entrypoint.GetMinimum())},
+	})
+
+	return 0
+}
+
+func (c *ContextVerifyCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *ContextVerifyCommand) Primary() bool {
+	return false
+}
+
+func (c *ContextVerifyCommand) Synopsis() string {
+	return "Verify the server connection of a context"
+}
+
+func (c *ContextVerifyCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant context verify [NAME]
+  Connect to the server of a context using its TLS and auth settings and
+  show the server version. If NAME is not given the default context is
+  verified.
+`)
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/status"
@@ -90,6 +91,15 @@ func (c *DynamicCommand) Run(args []string) int {
 			}
 		}
 
+		// Multiple targets are run from the project
+		if len(c.targets) > 0 {
+			for _, target := range c.targets {
+				t.Targets = append(t.Targets, target.Ref())
+			}
+			t.Parallelism = c.flagParallelism
+			t.ContinueOnError = c.flagContinueOnError
+		}
+
 		r, err = cl.Task(ctx,
 			&vagrant_server.Job_RunOp{Task: t},
 			modifier,
@@ -97,28 +107,12 @@ func (c *DynamicCommand) Run(args []string) int {
 
 		// If nothing failed but we didn't get a Result back, something may
 		// have gone wrong on the far side so we need to interpret the error.
-		if err == nil && !r.RunResult {
-			runErrorStatus := status.FromProto(r.RunError)
-			details := runErrorStatus.Details()
-			userError := false
-			for _, msg := range details {
-				switch m := msg.(type) {
-				case *errdetails.LocalizedMessage:
-					// Errors from Ruby with LocalizedMessages are user-facing,
-					// so can be output directly.
-					userError = true
-					cl.UI().Output(m.Message, terminal.WithErrorStyle())
-					// All user-facing errors from Ruby use a 1 exit code. See
-					// Vagrant::Errors::VagrantError.
-					r.ExitCode = 1
-				}
-			}
-			// If there wasn't a user-facing error, just assign the returned
-			// error (if any) from the response and assign that back out so it
-			// can be displayed as an unexpected error.
-			if !userError {
-				err = runErrorStatus.Err()
-			}
+		if err == nil && len(r.TargetResults) > 0 {
+			// Each target reports its own errors, so only the
+			// summary of the targets is output
+			outputTargetResults(cl.UI(), r)
+		} else if err == nil {
+			err = runResultError(cl.UI(), r)
 		}
 
 		if err != nil {
@@ -140,6 +134,65 @@ func (c *DynamicCommand) Run(args []string) int {
 	return int(r.ExitCode)
 }
 
+// outputTargetResults outputs the result of a run operation for each
+// target it was run against.
+func outputTargetResults(ui terminal.UI, r *vagrant_server.Job_RunResult) {
+	tbl := terminal.NewTable("TARGET", "RESULT", "EXIT CODE")
+	for _, tr := range r.TargetResults {
+		result, color, exitCode := "success", terminal.Green, "0"
+		switch {
+		case tr.Skipped:
+			result, color, exitCode = "skipped", terminal.Yellow, "-"
+		case !tr.RunResult:
+			result, color = "failed", terminal.Red
+			exitCode = strconv.Itoa(int(tr.ExitCode))
+		}
+		tbl.Rich(
+			[]string{tr.Target.Name, result, exitCode},
+			[]string{"", color, ""},
+		)
+	}
+	ui.Table(tbl)
+
+	if !r.RunResult {
+		ui.Output(status.FromProto(r.RunError).Message(), terminal.WithErrorStyle())
+	}
+}
+
+// runResultError interprets the result of a run operation which did not
+// succeed. User-facing errors are output directly and set the exit code
+// of the result, and any other error is returned so it can be displayed
+// as an unexpected error.
+func runResultError(ui terminal.UI, r *vagrant_server.Job_RunResult) error {
+	if r.RunResult {
+		return nil
+	}
+
+	runErrorStatus := status.FromProto(r.RunError)
+	details := runErrorStatus.Details()
+	userError := false
+	for _, msg := range details {
+		switch m := msg.(type) {
+		case *errdetails.LocalizedMessage:
+			// Errors from Ruby with LocalizedMessages are user-facing,
+			// so can be output directly.
+			userError = true
+			ui.Output(m.Message, terminal.WithErrorStyle())
+			// All user-facing errors from Ruby use a 1 exit code. See
+			// Vagrant::Errors::VagrantError.
+			r.ExitCode = 1
+		}
+	}
+	// If there wasn't a user-facing error, just assign the returned
+	// error (if any) from the response and assign that back out so it
+	// can be displayed as an unexpected error.
+	if !userError {
+		return runErrorStatus.Err()
+	}
+
+	return nil
+}
+
 func (c *DynamicCommand) Synopsis() string {
 	return c.synopsis
 }
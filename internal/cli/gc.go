@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+)
+
+type GCCommand struct {
+	*baseCommand
+
+	flagDryRun *component.CommandFlag
+}
+
+func (c *GCCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	dryRun := c.boolFlag(c.flagDryRun)
+	result, err := c.client.GarbageCollect(c.Ctx, dryRun)
+	if err != nil {
+		c.logError(c.Log, "failed to remove stale projects and targets", err)
+		return 1
+	}
+
+	if len(result.Orphaned) > 0 {
+		c.ui.Output("These targets are no longer defined by their project but may still\n"+
+			"exist, so they were not removed:",
+			terminal.WithWarningStyle())
+		tbl := terminal.NewTable("NAME", "PATH")
+		for _, t := range result.Orphaned {
+			tbl.Rich([]string{t.Name, t.Project.GetPath()}, nil)
+		}
+		c.ui.Table(tbl)
+	}
+
+	if len(result.Projects) == 0 && len(result.Targets) == 0 {
+		c.ui.Output("No stale projects or targets were found.", terminal.WithSuccessStyle())
+		return 0
+	}
+
+	tbl := terminal.NewTable("TYPE", "NAME", "PATH")
+	for _, p := range result.Projects {
+		tbl.Rich([]string{"project", p.Name, p.Path}, nil)
+	}
+	for _, t := range result.Targets {
+		tbl.Rich([]string{"target", t.Name, t.Project.GetPath()}, nil)
+	}
+	c.ui.Table(tbl)
+
+	if dryRun {
+		c.ui.Output("%d project(s) and %d target(s) would be removed.",
+			len(result.Projects), len(result.Targets))
+	} else {
+		c.ui.Output("Removed %d project(s) and %d target(s).",
+			len(result.Projects), len(result.Targets), terminal.WithSuccessStyle())
+	}
+
+	return 0
+}
+
+func (c *GCCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagDryRun = &component.CommandFlag{
+			LongName:     "dry-run",
+			Description:  "List what would be removed without removing anything",
+			DefaultValue: "false",
+			Type:         component.FlagBool,
+		}
+
+		return append(set, c.flagDryRun)
+	})
+}
+
+func (c *GCCommand) Primary() bool {
+	return false
+}
+
+func (c *GCCommand) Synopsis() string {
+	return "Remove stale projects and targets"
+}
+
+func (c *GCCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant gc [options]
+  Remove stale projects and targets from the Vagrant server.
+
+  A project is stale when its directory or Vagrantfile no longer exists,
+  and all of its targets are stale. A target is stale when the Ruby
+  Vagrantfile of its project no longer defines it, as of the last time the
+  project was loaded.
+
+  Stale targets are only removed if they were never created or have been
+  destroyed. Other stale targets may still exist, so they are listed as
+  orphaned and kept so they can still be managed and destroyed. A stale
+  project is kept as long as it has orphaned targets.
+
+  Stale projects are also removed automatically when the local server
+  shuts down if VAGRANT_LOCAL_SERVER_GC is set to a true value.
+
+` + c.Flags().Display())
+}
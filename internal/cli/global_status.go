@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+type GlobalStatusCommand struct {
+	*baseCommand
+
+	flagProvider *component.CommandFlag
+	flagState    *component.CommandFlag
+	flagPrune    *component.CommandFlag
+	flagFormat   *component.CommandFlag
+}
+
+func (c *GlobalStatusCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 0 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	format := c.stringFlag(c.flagFormat)
+	if format != "table" && format != "json" {
+		c.ui.Output("Unknown format %q, must be \"table\" or \"json\".", format,
+			terminal.WithErrorStyle())
+		return 1
+	}
+
+	op := &vagrant_server.Job_GlobalStatusOp{
+		Providers: splitList(c.stringFlag(c.flagProvider)),
+		Prune:     c.boolFlag(c.flagPrune),
+	}
+	for _, s := range splitList(c.stringFlag(c.flagState)) {
+		state, ok := vagrant_server.Operation_PhysicalState_value[strings.ToUpper(s)]
+		if !ok {
+			c.ui.Output("Unknown state %q.", s, terminal.WithErrorStyle())
+			return 1
+		}
+		op.States = append(op.States, vagrant_server.Operation_PhysicalState(state))
+	}
+
+	result, err := c.client.GlobalStatus(c.Ctx, op, c.Modifier())
+	if err != nil {
+		c.logError(c.Log, "failed to list targets", err)
+		return 1
+	}
+
+	if format == "json" {
+		output, err := globalStatusJSON(result)
+		if err != nil {
+			c.logError(c.Log, "failed to format targets", err)
+			return 1
+		}
+
+		stdout, _, err := c.ui.OutputWriters()
+		if err == nil {
+			_, err = stdout.Write(output)
+		}
+		if err != nil {
+			c.logError(c.Log, "failed to write targets", err)
+			return 1
+		}
+
+		return 0
+	}
+
+	for _, e := range result.Pruned {
+		c.ui.Output("Removed %s (%s) as it is no longer valid.", e.Name, e.ProjectPath,
+			terminal.WithWarningStyle())
+	}
+	for _, e := range result.Orphaned {
+		c.ui.Output("Kept %s (%s) as it may still exist. Destroy it to remove it.",
+			e.Name, e.ProjectPath, terminal.WithWarningStyle())
+	}
+
+	if len(result.Targets) == 0 {
+		c.ui.Output("No targets were found.")
+		return 0
+	}
+
+	tbl := terminal.NewTable("ID", "NAME", "PROVIDER", "STATE", "PROJECT", "UPDATED")
+	for _, e := range result.Targets {
+		tbl.Rich([]string{
+			shortID(e.Uuid),
+			e.Name,
+			e.Provider,
+			formatState(e.State),
+			e.ProjectPath,
+			formatTime(e.UpdatedAt, "-"),
+		}, nil)
+	}
+	c.ui.Table(tbl)
+
+	return 0
+}
+
+func (c *GlobalStatusCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagProvider = &component.CommandFlag{
+			LongName:    "provider",
+			Description: "Only list targets using these providers, separated by commas",
+			Type:        component.FlagString,
+		}
+		c.flagState = &component.CommandFlag{
+			LongName:    "state",
+			Description: "Only list targets in these states, separated by commas, such as \"created,halted\"",
+			Type:        component.FlagString,
+		}
+		c.flagPrune = &component.CommandFlag{
+			LongName:     "prune",
+			Description:  "Remove targets which are no longer valid",
+			DefaultValue: "false",
+			Type:         component.FlagBool,
+		}
+		c.flagFormat = &component.CommandFlag{
+			LongName:     "format",
+			Description:  "Format of the output, \"table\" or \"json\"",
+			DefaultValue: "table",
+			Type:         component.FlagString,
+		}
+
+		return append(set, c.flagProvider, c.flagState, c.flagPrune, c.flagFormat)
+	})
+}
+
+func (c *GlobalStatusCommand) Primary() bool {
+	return true
+}
+
+func (c *GlobalStatusCommand) Synopsis() string {
+	return "Outputs status of Vagrant environments for this user"
+}
+
+func (c *GlobalStatusCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant global-status [options]
+  List the targets of every project known to Vagrant.
+
+  The state of each target is the state recorded the last time Vagrant
+  ran against it, so it may be out of date if the target was changed
+  outside of Vagrant.
+
+  With -prune, targets whose project directory no longer exists, or whose
+  project Vagrantfile no longer defines them, are removed. Only targets
+  which were not created or were destroyed are removed; others are kept
+  and reported, as they may still exist. Projects whose Vagrantfile fails
+  to load are skipped.
+
+` + c.Flags().Display())
+}
+
+// globalStatusJSON formats the targets, and any targets which were
+// pruned or kept when pruning, as JSON.
+func globalStatusJSON(result *vagrant_server.Job_GlobalStatusResult) ([]byte, error) {
+	type entry struct {
+		ID        string     `json:"id"`
+		Name      string     `json:"name"`
+		Provider  string     `json:"provider"`
+		State     string     `json:"state"`
+		Project   string     `json:"project"`
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	}
+	entries := func(list []*vagrant_server.Job_GlobalStatusResult_Entry) []entry {
+		out := make([]entry, 0, len(list))
+		for _, e := range list {
+			v := entry{
+				ID:       e.Uuid,
+				Name:     e.Name,
+				Provider: e.Provider,
+				State:    formatState(e.State),
+				Project:  e.ProjectPath,
+			}
+			if e.UpdatedAt != nil {
+				t := e.UpdatedAt.AsTime()
+				v.UpdatedAt = &t
+			}
+			out = append(out, v)
+		}
+		return out
+	}
+
+	output, err := json.MarshalIndent(struct {
+		Targets  []entry `json:"targets"`
+		Pruned   []entry `json:"pruned,omitempty"`
+		Orphaned []entry `json:"orphaned,omitempty"`
+	}{
+		Targets:  entries(result.Targets),
+		Pruned:   entries(result.Pruned),
+		Orphaned: entries(result.Orphaned),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(output, '\n'), nil
+}
+
+// formatState returns the name of a target state, such as "not_created",
+// as accepted by the -state flag.
+func formatState(s vagrant_server.Operation_PhysicalState) string {
+	return strings.ToLower(s.String())
+}
+
+// shortID returns the first seven characters of a target ID, which
+// is enough to tell targets apart in a listing.
+func shortID(id string) string {
+	if len(id) > 7 {
+		return id[:7]
+	}
+
+	return id
+}
+
+// splitList splits a comma separated flag value, ignoring empty items.
+func splitList(v string) []string {
+	var result []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,279 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+type HistoryCommand struct {
+	*baseCommand
+
+	flagLimit *component.CommandFlag
+}
+
+func (c *HistoryCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 0 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	limit, err := strconv.ParseUint(c.stringFlag(c.flagLimit), 10, 32)
+	if err != nil {
+		c.ui.Output("Invalid limit %q, must be a number.", c.stringFlag(c.flagLimit),
+			terminal.WithErrorStyle())
+		return 1
+	}
+
+	req := &vagrant_server.ListTasksRequest{
+		Order: &vagrant_server.OperationOrder{
+			Order: vagrant_server.OperationOrder_START_TIME,
+			Desc:  true,
+			Limit: uint32(limit),
+		},
+	}
+	switch {
+	case c.target != nil:
+		req.Scope = &vagrant_server.ListTasksRequest_Target{Target: c.target.Ref()}
+	case c.project != nil:
+		req.Scope = &vagrant_server.ListTasksRequest_Project{Project: c.project.Ref()}
+	case c.basis != nil:
+		req.Scope = &vagrant_server.ListTasksRequest_Basis{Basis: c.basis.Ref()}
+	}
+
+	tasks, err := c.client.ListTasks(c.Ctx, req)
+	if err != nil {
+		c.logError(c.Log, "failed to list tasks", err)
+		return 1
+	}
+
+	if len(tasks) == 0 {
+		c.ui.Output("No tasks have been run.")
+		return 0
+	}
+
+	tbl := terminal.NewTable("ID", "COMMAND", "STATUS", "EXIT", "STARTED", "DURATION")
+	for _, t := range tasks {
+		tbl.Rich([]string{
+			t.Id,
+			taskCommandLine(t),
+			taskStatus(t),
+			taskExitCode(t),
+			formatTime(t.Status.GetStartTime(), "-"),
+			taskDuration(t),
+		}, nil)
+	}
+	c.ui.Table(tbl)
+
+	return 0
+}
+
+func (c *HistoryCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagLimit = &component.CommandFlag{
+			LongName:     "limit",
+			Description:  "Maximum number of tasks to list, or 0 to list all tasks",
+			DefaultValue: "20",
+			Type:         component.FlagString,
+		}
+
+		return append(set, c.flagLimit)
+	})
+}
+
+func (c *HistoryCommand) Primary() bool {
+	return false
+}
+
+func (c *HistoryCommand) Synopsis() string {
+	return "Lists commands previously run in this environment"
+}
+
+func (c *HistoryCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant history [options]
+  List the commands previously run, most recent first.
+
+  With -target, only commands run against that target are listed.
+  Otherwise the commands run against the project are listed.
+
+  Use "vagrant history show" to view the full arguments of a command,
+  and "vagrant rerun" to run it again.
+
+` + c.Flags().Display())
+}
+
+type HistoryShowCommand struct {
+	*baseCommand
+}
+
+func (c *HistoryShowCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+		WithNoConfig(),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	t, err := c.client.GetTask(c.Ctx, c.args[0])
+	if err != nil {
+		c.logError(c.Log, "failed to load task", err)
+		return 1
+	}
+
+	values := []terminal.NamedValue{
+		{Name: "command", Value: taskCommandLine(t)},
+		{Name: "scope", Value: taskScope(t)},
+		{Name: "job", Value: t.JobId},
+		{Name: "user", Value: t.User},
+		{Name: "status", Value: taskStatus(t)},
+		{Name: "exit code", Value: taskExitCode(t)},
+		{Name: "started", Value: formatTime(t.Status.GetStartTime(), "-")},
+		{Name: "completed", Value: formatTime(t.Status.GetCompleteTime(), "-")},
+		{Name: "duration", Value: taskDuration(t)},
+	}
+	if msg := t.Status.GetError().GetMessage(); msg != "" {
+		values = append(values, terminal.NamedValue{Name: "error", Value: msg})
+	}
+
+	c.ui.Output("Task %q", t.Id, terminal.WithHeaderStyle())
+	c.ui.NamedValues(values)
+
+	if args := t.CliArgs.GetArgs(); len(args) > 0 {
+		c.ui.Output("Arguments", terminal.WithHeaderStyle())
+		for _, a := range args {
+			c.ui.Output(a)
+		}
+	}
+
+	if flags := taskFlags(t.CliArgs); len(flags) > 0 {
+		c.ui.Output("Flags", terminal.WithHeaderStyle())
+		values := make([]terminal.NamedValue, 0, len(flags))
+		for _, f := range flags {
+			values = append(values, terminal.NamedValue{
+				Name:  f.Name,
+				Value: taskFlagValue(f),
+			})
+		}
+		c.ui.NamedValues(values)
+	}
+
+	return 0
+}
+
+func (c *HistoryShowCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *HistoryShowCommand) Synopsis() string {
+	return "Shows the details of a previously run command"
+}
+
+func (c *HistoryShowCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant history show TASK-ID
+  Show the details of a previously run command, including every argument
+  and flag it was run with.
+
+` + c.Flags().Display())
+}
+
+// taskCommandLine returns the command, arguments and flags of a task
+// in the form they would be given on the command line.
+func taskCommandLine(t *vagrant_server.Task) string {
+	parts := []string{t.CommandName}
+	if parts[0] == "" {
+		parts[0] = t.Task
+	}
+	for _, f := range taskFlags(t.CliArgs) {
+		parts = append(parts, fmt.Sprintf("--%s=%s", f.Name, taskFlagValue(f)))
+	}
+	parts = append(parts, t.CliArgs.GetArgs()...)
+
+	return strings.Join(parts, " ")
+}
+
+// taskFlags returns the flags of a task sorted by name. Flags are sent
+// in no particular order since they are collected from a map.
+func taskFlags(args *vagrant_plugin_sdk.Command_Arguments) []*vagrant_plugin_sdk.Command_Arguments_Flag {
+	flags := append([]*vagrant_plugin_sdk.Command_Arguments_Flag{}, args.GetFlags()...)
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Name < flags[j].Name
+	})
+
+	return flags
+}
+
+func taskFlagValue(f *vagrant_plugin_sdk.Command_Arguments_Flag) string {
+	switch v := f.Value.(type) {
+	case *vagrant_plugin_sdk.Command_Arguments_Flag_Bool:
+		return strconv.FormatBool(v.Bool)
+	case *vagrant_plugin_sdk.Command_Arguments_Flag_String_:
+		return strconv.Quote(v.String_)
+	}
+
+	return ""
+}
+
+// taskScope describes the target, project or basis a task was run in.
+func taskScope(t *vagrant_server.Task) string {
+	switch s := t.Scope.(type) {
+	case *vagrant_server.Task_Target:
+		return fmt.Sprintf("target %s (%s)", s.Target.Name, s.Target.Project.GetName())
+	case *vagrant_server.Task_Project:
+		return fmt.Sprintf("project %s", s.Project.Name)
+	case *vagrant_server.Task_Basis:
+		return fmt.Sprintf("basis %s", s.Basis.Name)
+	}
+
+	return "-"
+}
+
+func taskStatus(t *vagrant_server.Task) string {
+	return strings.ToLower(t.Status.GetState().String())
+}
+
+// taskExitCode returns the exit code of a task, which is only known once
+// the task is complete.
+func taskExitCode(t *vagrant_server.Task) string {
+	if t.Status.GetCompleteTime() == nil {
+		return "-"
+	}
+
+	return strconv.Itoa(int(t.ExitCode))
+}
+
+func taskDuration(t *vagrant_server.Task) string {
+	start, end := t.Status.GetStartTime(), t.Status.GetCompleteTime()
+	if start == nil || end == nil {
+		return "-"
+	}
+
+	return end.AsTime().Sub(start.AsTime()).Round(time.Millisecond).String()
+}
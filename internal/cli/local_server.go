@@ -0,0 +1,35 @@
+package cli
+
+import (
+	clientpkg "github.com/hashicorp/vagrant/internal/client"
+)
+
+// LocalServerCommand runs the background local server that is shared
+// between Vagrant processes. It is started automatically by the client
+// and is not intended to be run directly.
+type LocalServerCommand struct {
+	*baseCommand
+}
+
+func (c *LocalServerCommand) Run(args []string) int {
+	err := clientpkg.RunLocalServer(c.Ctx, c.Log.ResetNamed("vagrant.server"),
+		clientpkg.LocalServerIdleTimeout())
+	if err != nil {
+		c.Log.Error("local server failed", "error", err)
+		return 1
+	}
+
+	return 0
+}
+
+func (c *LocalServerCommand) Primary() bool {
+	return false
+}
+
+func (c *LocalServerCommand) Synopsis() string {
+	return "Run the background local server."
+}
+
+func (c *LocalServerCommand) Help() string {
+	return ""
+}
@@ -17,10 +17,12 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/mitchellh/go-glint"
 
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
 	"github.com/hashicorp/vagrant-plugin-sdk/internal-shared/protomappers"
 	"github.com/hashicorp/vagrant-plugin-sdk/localizer"
 	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
 	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	clientpkg "github.com/hashicorp/vagrant/internal/client"
 	"github.com/hashicorp/vagrant/internal/pkg/signalcontext"
 	"github.com/hashicorp/vagrant/internal/version"
 )
@@ -49,7 +51,8 @@ var (
 
 	// hiddenCommands are not shown in CLI help output.
 	hiddenCommands = map[string]struct{}{
-		"plugin-run": {},
+		"plugin-run":                 {},
+		clientpkg.LocalServerCommand: {},
 	}
 
 	ExposeDocs bool
@@ -135,6 +138,7 @@ func Commands(
 		Ctx:       ctx,
 		Log:       log,
 		LogOutput: logOutput,
+		flagData:  map[*component.CommandFlag]interface{}{},
 	}
 	// fetch plugin builtin commands
 	commands["plugin-run"] = func() (cli.Command, error) {
@@ -143,8 +147,116 @@ func Commands(
 		}, nil
 	}
 
+	// the background local server must not connect to a server itself
+	commands[clientpkg.LocalServerCommand] = func() (cli.Command, error) {
+		return &LocalServerCommand{
+			baseCommand: bc,
+		}, nil
+	}
+
+	// the server runs standalone so it must not connect to a server either
+	commands["server run"] = func() (cli.Command, error) {
+		return &ServerRunCommand{
+			baseCommand: bc,
+		}, nil
+	}
+
+	// contexts are managed locally and may point at servers which
+	// cannot be reached, so they must not connect to a server
+	commands["context list"] = func() (cli.Command, error) {
+		return &ContextListCommand{
+			baseCommand: bc,
+		}, nil
+	}
+	commands["context create"] = func() (cli.Command, error) {
+		return &ContextCreateCommand{
+			baseCommand: bc,
+		}, nil
+	}
+	commands["context use"] = func() (cli.Command, error) {
+		return &ContextUseCommand{
+			baseCommand: bc,
+		}, nil
+	}
+	commands["context rename"] = func() (cli.Command, error) {
+		return &ContextRenameCommand{
+			baseCommand: bc,
+		}, nil
+	}
+	commands["context delete"] = func() (cli.Command, error) {
+		return &ContextDeleteCommand{
+			baseCommand: bc,
+		}, nil
+	}
+	commands["context inspect"] = func() (cli.Command, error) {
+		return &ContextInspectCommand{
+			baseCommand: bc,
+		}, nil
+	}
+	commands["context verify"] = func() (cli.Command, error) {
+		return &ContextVerifyCommand{
+			baseCommand: bc,
+		}, nil
+	}
+
+	// tokens, users and the audit log are managed directly against the
+	// server of the current context, so they do not need the full setup either
+	commands["token list"] = func() (cli.Command, error) {
+		return &TokenListCommand{
+			baseCommand: bc,
+		}, nil
+	}
+	commands["token create"] = func() (cli.Command, error) {
+		return &TokenCreateCommand{
+			baseCommand: bc,
+		}, nil
+	}
+	commands["token exchange"] = func() (cli.Command, error) {
+		return &TokenExchangeCommand{
+			baseCommand: bc,
+		}, nil
+	}
+	commands["token revoke"] = func() (cli.Command, error) {
+		return &TokenRevokeCommand{
+			baseCommand: bc,
+		}, nil
+	}
+	commands["token rotate-key"] = func() (cli.Command, error) {
+		return &TokenRotateKeyCommand{
+			baseCommand: bc,
+		}, nil
+	}
+
+	commands["user list"] = func() (cli.Command, error) {
+		return &UserListCommand{
+			baseCommand: bc,
+		}, nil
+	}
+	commands["user invite"] = func() (cli.Command, error) {
+		return &UserInviteCommand{
+			baseCommand: bc,
+		}, nil
+	}
+	commands["user delete"] = func() (cli.Command, error) {
+		return &UserDeleteCommand{
+			baseCommand: bc,
+		}, nil
+	}
+	commands["audit list"] = func() (cli.Command, error) {
+		return &AuditListCommand{
+			baseCommand: bc,
+		}, nil
+	}
+
 	// If running a builtin don't do all the setup
-	if len(args) > 1 && args[1] == "plugin-run" {
+	if len(args) > 1 && (args[1] == "plugin-run" || args[1] == clientpkg.LocalServerCommand) {
+		return bc, commands, nil
+	}
+	if len(args) > 2 && args[1] == "server" && args[2] == "run" {
+		return bc, commands, nil
+	}
+	if len(args) > 1 && (args[1] == "context" || args[1] == "token" ||
+		args[1] == "user" || args[1] == "audit") {
 		return bc, commands, nil
 	}
 
@@ -171,6 +283,11 @@ func Commands(
 	aliases := map[string]string{}
 
 	// fetch remaining builtin commands
+	commands["gc"] = func() (cli.Command, error) {
+		return &GCCommand{
+			baseCommand: baseCommand,
+		}, nil
+	}
 	commands["version"] = func() (cli.Command, error) {
 		return &VersionCommand{
 			baseCommand: baseCommand,
@@ -198,6 +315,66 @@ func Commands(
 			VersionInfo: version.GetVersion(),
 		}, nil
 	}
+	commands["global-status"] = func() (cli.Command, error) {
+		return &GlobalStatusCommand{
+			baseCommand: baseCommand,
+		}, nil
+	}
+	commands["provider list"] = func() (cli.Command, error) {
+		return &ProviderListCommand{
+			baseCommand: baseCommand,
+		}, nil
+	}
+	commands["history"] = func() (cli.Command, error) {
+		return &HistoryCommand{
+			baseCommand: baseCommand,
+		}, nil
+	}
+	commands["history show"] = func() (cli.Command, error) {
+		return &HistoryShowCommand{
+			baseCommand: baseCommand,
+		}, nil
+	}
+	commands["rerun"] = func() (cli.Command, error) {
+		return &RerunCommand{
+			baseCommand: baseCommand,
+		}, nil
+	}
+	commands["config convert"] = func() (cli.Command, error) {
+		return &ConfigConvertCommand{
+			baseCommand: baseCommand,
+		}, nil
+	}
+	commands["config lint"] = func() (cli.Command, error) {
+		return &ConfigLintCommand{
+			baseCommand: baseCommand,
+		}, nil
+	}
+	commands["config show"] = func() (cli.Command, error) {
+		return &ConfigShowCommand{
+			baseCommand: baseCommand,
+		}, nil
+	}
+	commands["config validate"] = func() (cli.Command, error) {
+		return &ConfigValidateCommand{
+			baseCommand: baseCommand,
+		}, nil
+	}
+	commands["server install"] = func() (cli.Command, error) {
+		return &ServerInstallCommand{
+			baseCommand: baseCommand,
+		}, nil
+	}
+	commands["server upgrade"] = func() (cli.Command, error) {
+		return &ServerUpgradeCommand{
+			baseCommand: baseCommand,
+		}, nil
+	}
+	commands["server uninstall"] = func() (cli.Command, error) {
+		return &ServerUninstallCommand{
+			baseCommand: baseCommand,
+		}, nil
+	}
 
 	// register our aliases
 	for from, to := range aliases {
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+)
+
+type ProviderListCommand struct {
+	*baseCommand
+}
+
+func (c *ProviderListCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 0 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	result, err := c.client.ProviderList(c.Ctx, nil, c.Modifier())
+	if err != nil {
+		c.logError(c.Log, "failed to list providers", err)
+		return 1
+	}
+
+	if len(result.Providers) == 0 {
+		c.ui.Output("No provider plugins are installed.")
+		return 1
+	}
+
+	tbl := terminal.NewTable("", "NAME", "PRIORITY", "INSTALLED", "USABLE", "REASON")
+	for _, p := range result.Providers {
+		chosen := ""
+		if p.Chosen {
+			chosen = "*"
+		}
+
+		tbl.Rich([]string{
+			chosen,
+			p.Name,
+			strconv.Itoa(int(p.Priority)),
+			yesNo(p.Installed),
+			yesNo(p.Usable),
+			p.Reason,
+		}, nil)
+	}
+	c.ui.Table(tbl)
+
+	if result.DefaultProvider == "" {
+		c.ui.Output("No default provider could be chosen.", terminal.WithErrorStyle())
+		return 1
+	}
+	c.ui.Output("Default provider: %s", result.DefaultProvider, terminal.WithSuccessStyle())
+
+	return 0
+}
+
+func (c *ProviderListCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *ProviderListCommand) Primary() bool {
+	return false
+}
+
+func (c *ProviderListCommand) Synopsis() string {
+	return "List providers and which is chosen by default"
+}
+
+func (c *ProviderListCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant provider list
+  List the installed provider plugins, whether each is usable on this
+  host, and why it was or was not chosen as the default provider. The
+  default provider is marked with "*".
+
+  The default provider is chosen in this order:
+
+    1. The VAGRANT_DEFAULT_PROVIDER environment variable.
+    2. A provider configured in the Vagrantfile which is also preferred.
+    3. The first provider configured in the Vagrantfile.
+    4. The first preferred provider.
+    5. The usable provider with the highest plugin priority.
+
+  Preferred providers are those listed in the VAGRANT_PREFERRED_PROVIDERS
+  environment variable, followed by the priority of the provider block
+  in vagrant-config.hcl. Only installed and usable providers are chosen.
+`)
+}
+
+// yesNo formats a boolean for display in a table.
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+)
+
+type RerunCommand struct {
+	*baseCommand
+}
+
+func (c *RerunCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) != 1 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	t, err := c.client.GetTask(c.Ctx, c.args[0])
+	if err != nil {
+		c.logError(c.Log, "failed to load task", err)
+		return 1
+	}
+
+	c.ui.Output("Running %s", taskCommandLine(t), terminal.WithHeaderStyle())
+
+	r, err := c.client.Rerun(c.Ctx, t, c.Modifier())
+	if err == nil && len(r.TargetResults) > 0 {
+		outputTargetResults(c.ui, r)
+	} else if err == nil {
+		err = runResultError(c.ui, r)
+	}
+	if err != nil {
+		c.logError(c.Log, "failed to run task", err)
+		return int(-1)
+	}
+
+	return int(r.ExitCode)
+}
+
+func (c *RerunCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *RerunCommand) Synopsis() string {
+	return "Runs a previously run command again"
+}
+
+func (c *RerunCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant rerun TASK-ID
+  Run a previously run command again, with the same arguments and flags,
+  against the same target or project.
+
+  Use "vagrant history" to list the IDs of previously run commands.
+
+` + c.Flags().Display())
+}
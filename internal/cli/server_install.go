@@ -0,0 +1,523 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/clicontext"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	"github.com/hashicorp/vagrant/internal/serverclient"
+	"github.com/hashicorp/vagrant/internal/serverinstall"
+)
+
+// serverConnectTimeout is how long to wait for an installed server to
+// accept connections.
+const serverConnectTimeout = 2 * time.Minute
+
+// serverInstallFlags are the flags shared by the server install,
+// upgrade and uninstall commands to configure the platform.
+type serverInstallFlags struct {
+	platform            *component.CommandFlag
+	serverImage         *component.CommandFlag
+	k8sNamespace        *component.CommandFlag
+	k8sOpenShift        *component.CommandFlag
+	k8sPullSecret       *component.CommandFlag
+	k8sCPURequest       *component.CommandFlag
+	k8sMemRequest       *component.CommandFlag
+	k8sStorageRequest   *component.CommandFlag
+	nomadRegion         *component.CommandFlag
+	nomadDatacenters    *component.CommandFlag
+	nomadNamespace      *component.CommandFlag
+	nomadPolicyOverride *component.CommandFlag
+}
+
+func (f *serverInstallFlags) flags() []*component.CommandFlag {
+	def := serverinstall.DefaultConfig()
+
+	f.platform = &component.CommandFlag{
+		LongName:    "platform",
+		Description: "Platform to install the server to: " + strings.Join(serverPlatformNames(), ", "),
+		Type:        component.FlagString,
+	}
+	f.serverImage = &component.CommandFlag{
+		LongName:     "server-image",
+		Description:  "Docker image for the server",
+		DefaultValue: def.ServerImage,
+		Type:         component.FlagString,
+	}
+	f.k8sNamespace = &component.CommandFlag{
+		LongName:     "k8s-namespace",
+		Description:  "Kubernetes namespace to install to",
+		DefaultValue: def.Namespace,
+		Type:         component.FlagString,
+	}
+	f.k8sOpenShift = &component.CommandFlag{
+		LongName:     "k8s-openshift",
+		Description:  "Enable OpenShift compatibility for the Kubernetes install",
+		DefaultValue: "false",
+		Type:         component.FlagBool,
+	}
+	f.k8sPullSecret = &component.CommandFlag{
+		LongName:    "k8s-pull-secret",
+		Description: "Secret used to pull the server image in Kubernetes",
+		Type:        component.FlagString,
+	}
+	f.k8sCPURequest = &component.CommandFlag{
+		LongName:     "k8s-cpu-request",
+		Description:  "CPU requested by the server in Kubernetes",
+		DefaultValue: def.CPURequest,
+		Type:         component.FlagString,
+	}
+	f.k8sMemRequest = &component.CommandFlag{
+		LongName:     "k8s-mem-request",
+		Description:  "Memory requested by the server in Kubernetes",
+		DefaultValue: def.MemRequest,
+		Type:         component.FlagString,
+	}
+	f.k8sStorageRequest = &component.CommandFlag{
+		LongName:     "k8s-storage-request",
+		Description:  "Storage requested for the server data in Kubernetes",
+		DefaultValue: def.StorageRequest,
+		Type:         component.FlagString,
+	}
+	f.nomadRegion = &component.CommandFlag{
+		LongName:     "nomad-region",
+		Description:  "Nomad region to install to",
+		DefaultValue: def.NomadRegion,
+		Type:         component.FlagString,
+	}
+	f.nomadDatacenters = &component.CommandFlag{
+		LongName:     "nomad-dc",
+		Description:  "Comma separated list of Nomad datacenters to install to",
+		DefaultValue: strings.Join(def.NomadDatacenters, ","),
+		Type:         component.FlagString,
+	}
+	f.nomadNamespace = &component.CommandFlag{
+		LongName:     "nomad-namespace",
+		Description:  "Nomad namespace to install to",
+		DefaultValue: def.NomadNamespace,
+		Type:         component.FlagString,
+	}
+	f.nomadPolicyOverride = &component.CommandFlag{
+		LongName:     "nomad-policy-override",
+		Description:  "Override the Nomad sentinel policy if using enterprise Nomad",
+		DefaultValue: "false",
+		Type:         component.FlagBool,
+	}
+
+	return []*component.CommandFlag{
+		f.platform,
+		f.serverImage,
+		f.k8sNamespace,
+		f.k8sOpenShift,
+		f.k8sPullSecret,
+		f.k8sCPURequest,
+		f.k8sMemRequest,
+		f.k8sStorageRequest,
+		f.nomadRegion,
+		f.nomadDatacenters,
+		f.nomadNamespace,
+		f.nomadPolicyOverride,
+	}
+}
+
+// config returns the installation configuration and the platform
+// selected by the flags.
+func (f *serverInstallFlags) config(c *baseCommand) (*serverinstall.Config, *serverinstall.Platform, error) {
+	name := c.stringFlag(f.platform)
+	p, ok := serverinstall.Platforms[name]
+	if !ok {
+		if name == "" {
+			return nil, nil, fmt.Errorf("The --platform flag is required. Valid platforms are: %s",
+				strings.Join(serverPlatformNames(), ", "))
+		}
+
+		return nil, nil, fmt.Errorf("Unknown platform %q. Valid platforms are: %s",
+			name, strings.Join(serverPlatformNames(), ", "))
+	}
+
+	cfg := serverinstall.DefaultConfig()
+	cfg.ServerImage = c.stringFlag(f.serverImage)
+	cfg.Namespace = c.stringFlag(f.k8sNamespace)
+	cfg.OpenShift = c.boolFlag(f.k8sOpenShift)
+	cfg.ImagePullSecret = c.stringFlag(f.k8sPullSecret)
+	cfg.CPURequest = c.stringFlag(f.k8sCPURequest)
+	cfg.MemRequest = c.stringFlag(f.k8sMemRequest)
+	cfg.StorageRequest = c.stringFlag(f.k8sStorageRequest)
+	cfg.NomadRegion = c.stringFlag(f.nomadRegion)
+	cfg.NomadDatacenters = strings.Split(c.stringFlag(f.nomadDatacenters), ",")
+	cfg.NomadNamespace = c.stringFlag(f.nomadNamespace)
+	cfg.NomadPolicyOverride = c.boolFlag(f.nomadPolicyOverride)
+
+	return cfg, p, nil
+}
+
+// serverPlatformNames returns the sorted names of the platforms the
+// server can be installed to.
+func serverPlatformNames() []string {
+	var names []string
+	for name := range serverinstall.Platforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+type ServerInstallCommand struct {
+	*baseCommand
+
+	platformFlags      serverInstallFlags
+	flagContextName    *component.CommandFlag
+	flagContextDefault *component.CommandFlag
+	flagK8sManifests   *component.CommandFlag
+}
+
+func (c *ServerInstallCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+		WithNoConfig(),
+		WithClient(false),
+	); err != nil {
+		return 1
+	}
+
+	scfg, p, err := c.platformFlags.config(c.baseCommand)
+	if err != nil {
+		c.logError(c.Log, "", err)
+		return 1
+	}
+
+	// Only output the manifests if requested so they can be reviewed
+	// or applied separately.
+	if c.boolFlag(c.flagK8sManifests) {
+		if p != serverinstall.Platforms["kubernetes"] {
+			c.logError(c.Log, "", fmt.Errorf("The --k8s-manifests flag requires the kubernetes platform"))
+			return 1
+		}
+
+		stdout, _, err := c.ui.OutputWriters()
+		if err == nil {
+			err = scfg.WriteManifests(stdout)
+		}
+		if err != nil {
+			c.logError(c.Log, "failed to write manifests", err)
+			return 1
+		}
+
+		return 0
+	}
+
+	clicfg, advertiseAddr, httpAddr, err := p.Install(c.Ctx, c.ui, scfg)
+	if err != nil {
+		c.logError(c.Log, "failed to install server", err)
+		return 1
+	}
+
+	// Bootstrap the server so the CLI is logged in
+	token, err := bootstrapServer(c.Ctx, clicfg)
+	if err != nil {
+		c.logError(c.Log, "failed to bootstrap server", err)
+		return 1
+	}
+	if token != "" {
+		clicfg.Server.RequireAuth = true
+		clicfg.Server.AuthToken = token
+
+		if err := setServerConfig(c.Ctx, clicfg, advertiseAddr); err != nil {
+			c.logError(c.Log, "failed to configure server", err)
+			return 1
+		}
+	} else {
+		c.ui.Output("The server has already been bootstrapped. Set the %s environment\n"+
+			"variable to an existing token to authenticate with it.",
+			serverclient.EnvServerToken, terminal.WithWarningStyle())
+	}
+
+	name := c.stringFlag(c.flagContextName)
+	if name == "" {
+		name = fmt.Sprintf("install-%d", time.Now().Unix())
+	}
+	if err := c.contextStorage.Set(name, clicfg); err != nil {
+		c.logError(c.Log, "failed to save context", err)
+		return 1
+	}
+	if c.boolFlag(c.flagContextDefault) {
+		if err := c.contextStorage.SetDefault(name); err != nil {
+			c.logError(c.Log, "failed to set default context", err)
+			return 1
+		}
+	}
+
+	c.ui.Output("Vagrant server successfully installed!", terminal.WithSuccessStyle())
+	c.ui.Output("The CLI has been configured to connect to the server with the context %q.",
+		name, terminal.WithInfoStyle())
+	c.ui.Output("Server address: %s", clicfg.Server.Address, terminal.WithInfoStyle())
+	if httpAddr != "" {
+		c.ui.Output("HTTP address: %s", httpAddr, terminal.WithInfoStyle())
+	}
+
+	return 0
+}
+
+// bootstrapServer connects to the installed server and returns its
+// bootstrap token. If the server has already been bootstrapped, no token
+// is returned.
+func bootstrapServer(ctx context.Context, clicfg *clicontext.Config) (string, error) {
+	conn, err := serverclient.Connect(ctx,
+		serverclient.FromContextConfig(clicfg),
+		serverclient.Timeout(serverConnectTimeout),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	resp, err := vagrant_server.NewVagrantClient(conn).BootstrapToken(ctx, &emptypb.Empty{})
+	if status.Code(err) == codes.PermissionDenied {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Token, nil
+}
+
+// setServerConfig sets the address the server advertises to deployments.
+func setServerConfig(
+	ctx context.Context,
+	clicfg *clicontext.Config,
+	addr *vagrant_server.ServerConfig_AdvertiseAddr,
+) error {
+	conn, err := serverclient.Connect(ctx,
+		serverclient.FromContextConfig(clicfg),
+		serverclient.Timeout(serverConnectTimeout),
+	)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = vagrant_server.NewVagrantClient(conn).SetServerConfig(ctx, &vagrant_server.SetServerConfigRequest{
+		Config: &vagrant_server.ServerConfig{
+			AdvertiseAddrs: []*vagrant_server.ServerConfig_AdvertiseAddr{addr},
+		},
+	})
+	return err
+}
+
+func (c *ServerInstallCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagContextName = &component.CommandFlag{
+			LongName:    "context-name",
+			Description: "Name of the context to create for the server",
+			Type:        component.FlagString,
+		}
+		c.flagContextDefault = &component.CommandFlag{
+			LongName:     "context-set-default",
+			Description:  "Set the created context as the default context",
+			DefaultValue: "true",
+			Type:         component.FlagBool,
+		}
+		c.flagK8sManifests = &component.CommandFlag{
+			LongName:     "k8s-manifests",
+			Description:  "Output the Kubernetes manifests instead of installing",
+			DefaultValue: "false",
+			Type:         component.FlagBool,
+		}
+
+		set = append(set, c.platformFlags.flags()...)
+		return append(set, c.flagContextName, c.flagContextDefault, c.flagK8sManifests)
+	})
+}
+
+func (c *ServerInstallCommand) Primary() bool {
+	return false
+}
+
+func (c *ServerInstallCommand) Synopsis() string {
+	return "Install a Vagrant server to a platform"
+}
+
+func (c *ServerInstallCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant server install --platform=PLATFORM [options]
+  Install a Vagrant server to Docker, Kubernetes or Nomad.
+
+  Once the server is running it is bootstrapped and a context is created
+  so the CLI is immediately logged in to it.
+
+  With the kubernetes platform, --k8s-manifests outputs the manifests
+  instead of installing them so they can be reviewed or applied later.
+`)
+}
+
+type ServerUpgradeCommand struct {
+	*baseCommand
+
+	platformFlags   serverInstallFlags
+	flagContextName *component.CommandFlag
+}
+
+func (c *ServerUpgradeCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+		WithNoConfig(),
+		WithClient(false),
+	); err != nil {
+		return 1
+	}
+
+	scfg, p, err := c.platformFlags.config(c.baseCommand)
+	if err != nil {
+		c.logError(c.Log, "", err)
+		return 1
+	}
+
+	name := c.stringFlag(c.flagContextName)
+	if name == "" {
+		if name, err = c.contextStorage.Default(); err != nil {
+			c.logError(c.Log, "failed to load default context", err)
+			return 1
+		}
+	}
+
+	clicfg, _, httpAddr, err := p.Upgrade(c.Ctx, c.ui, scfg)
+	if err != nil {
+		c.logError(c.Log, "failed to upgrade server", err)
+		return 1
+	}
+
+	// Keep the existing credentials and update the connection details
+	// in case they changed during the upgrade.
+	if name != "" {
+		existing, err := c.contextStorage.Load(name)
+		if err != nil {
+			c.logError(c.Log, "failed to load context", err)
+			return 1
+		}
+
+		existing.Server.Address = clicfg.Server.Address
+		existing.Server.Tls = clicfg.Server.Tls
+		existing.Server.TlsSkipVerify = clicfg.Server.TlsSkipVerify
+		if err := c.contextStorage.Set(name, existing); err != nil {
+			c.logError(c.Log, "failed to save context", err)
+			return 1
+		}
+	}
+
+	c.ui.Output("Vagrant server successfully upgraded!", terminal.WithSuccessStyle())
+	c.ui.Output("Server address: %s", clicfg.Server.Address, terminal.WithInfoStyle())
+	if httpAddr != "" {
+		c.ui.Output("HTTP address: %s", httpAddr, terminal.WithInfoStyle())
+	}
+
+	return 0
+}
+
+func (c *ServerUpgradeCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagContextName = &component.CommandFlag{
+			LongName:    "context-name",
+			Description: "Name of the context to update, defaults to the default context",
+			Type:        component.FlagString,
+		}
+
+		set = append(set, c.platformFlags.flags()...)
+		return append(set, c.flagContextName)
+	})
+}
+
+func (c *ServerUpgradeCommand) Primary() bool {
+	return false
+}
+
+func (c *ServerUpgradeCommand) Synopsis() string {
+	return "Upgrade an installed Vagrant server"
+}
+
+func (c *ServerUpgradeCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant server upgrade --platform=PLATFORM [options]
+  Upgrade a Vagrant server installed with "vagrant server install".
+
+  The latest version of the server image is deployed and the server data
+  is kept. The context for the server is updated with its new address.
+`)
+}
+
+type ServerUninstallCommand struct {
+	*baseCommand
+
+	platformFlags serverInstallFlags
+}
+
+func (c *ServerUninstallCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+		WithNoConfig(),
+		WithClient(false),
+	); err != nil {
+		return 1
+	}
+
+	scfg, p, err := c.platformFlags.config(c.baseCommand)
+	if err != nil {
+		c.logError(c.Log, "", err)
+		return 1
+	}
+
+	if err := p.Uninstall(c.Ctx, c.ui, scfg); err != nil {
+		c.logError(c.Log, "failed to uninstall server", err)
+		return 1
+	}
+
+	c.ui.Output("Vagrant server successfully uninstalled.", terminal.WithSuccessStyle())
+	return 0
+}
+
+func (c *ServerUninstallCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		return append(set, c.platformFlags.flags()...)
+	})
+}
+
+func (c *ServerUninstallCommand) Primary() bool {
+	return false
+}
+
+func (c *ServerUninstallCommand) Synopsis() string {
+	return "Uninstall a Vagrant server and its data"
+}
+
+func (c *ServerUninstallCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant server uninstall --platform=PLATFORM [options]
+  Uninstall a Vagrant server installed with "vagrant server install".
+
+  The server and its data are removed. Contexts for the server are not
+  removed.
+`)
+}
@@ -0,0 +1,337 @@
+package cli
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/pkg/signalcontext"
+	"github.com/hashicorp/vagrant/internal/server"
+	"github.com/hashicorp/vagrant/internal/server/singleprocess"
+	"github.com/hashicorp/vagrant/internal/serverconfig"
+)
+
+type ServerRunCommand struct {
+	*baseCommand
+
+	flagConfig           *component.CommandFlag
+	flagDB               *component.CommandFlag
+	flagListenGRPC       *component.CommandFlag
+	flagListenHTTP       *component.CommandFlag
+	flagDrain            *component.CommandFlag
+	flagDisableBootstrap *component.CommandFlag
+}
+
+// defaultDrainTimeout is how long a terminated server waits for running
+// jobs if no drain timeout is configured.
+const defaultDrainTimeout = 5 * time.Minute
+
+func (c *ServerRunCommand) Run(args []string) int {
+	flagSet := c.Flags()
+
+	// Initialize. If we fail, we just exit since Init handles the UI.
+	if err := c.Init(
+		WithArgs(args),
+		WithFlags(flagSet),
+		WithNoConfig(),
+		WithClient(false),
+	); err != nil {
+		return 1
+	}
+
+	if len(c.args) > 0 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	log := c.Log.ResetNamed("vagrant.server")
+
+	cfg, err := c.serverConfig()
+	if err == nil {
+		err = validateServerConfig(cfg)
+	}
+	if err != nil {
+		c.logError(log, "failed to load server configuration", err)
+		return 1
+	}
+
+	drainTimeout := defaultDrainTimeout
+	if cfg.DrainTimeout != "" {
+		if drainTimeout, err = time.ParseDuration(cfg.DrainTimeout); err != nil {
+			c.logError(log, "invalid drain timeout", err)
+			return 1
+		}
+	}
+
+	// Shut down gracefully on interrupt. Termination drains the server
+	// before shutting down, a second termination shuts down immediately.
+	ctx, closer := signalcontext.WithInterrupt(c.Ctx, log)
+	defer closer()
+
+	termCh := make(chan os.Signal, 1)
+	signal.Notify(termCh, syscall.SIGTERM)
+	defer signal.Stop(termCh)
+
+	drainCh := make(chan struct{})
+	go func() {
+		select {
+		case <-termCh:
+		case <-ctx.Done():
+			return
+		}
+
+		log.Warn("termination signal received, draining server")
+		close(drainCh)
+
+		select {
+		case <-termCh:
+			log.Warn("termination signal received again, shutting down")
+			closer()
+		case <-ctx.Done():
+		}
+	}()
+
+	log.Debug("opening DB", "path", cfg.DBPath)
+	db, err := bolt.Open(cfg.DBPath, 0600, &bolt.Options{
+		Timeout: 2 * time.Second,
+	})
+	if err != nil {
+		c.logError(log, "failed to open database", err)
+		return 1
+	}
+	defer db.Close()
+
+	impl, err := singleprocess.New(
+		singleprocess.WithDB(db),
+		singleprocess.WithConfig(cfg),
+		singleprocess.WithLogger(log.Named("singleprocess")),
+	)
+	if err != nil {
+		c.logError(log, "failed to create server", err)
+		return 1
+	}
+
+	// TLS for gRPC is handled by the server rather than the listener
+	// so client certificates are available for authentication.
+	grpcTLS, err := c.tlsConfig(cfg.GRPC)
+	if err != nil {
+		c.logError(log, "failed to configure gRPC TLS", err)
+		return 1
+	}
+	grpcLn, err := net.Listen("tcp", cfg.GRPC.Addr)
+	if err != nil {
+		c.logError(log, "failed to start gRPC listener", err)
+		return 1
+	}
+	defer grpcLn.Close()
+
+	opts := []server.Option{
+		server.WithContext(ctx),
+		server.WithLogger(log),
+		server.WithGRPC(grpcLn),
+		server.WithImpl(impl),
+		server.WithClientCertAuth(cfg.GRPC.TLSClientCertAuth),
+		server.WithDrain(drainCh, drainTimeout),
+	}
+	if grpcTLS != nil {
+		opts = append(opts, server.WithGRPCTLS(grpcTLS))
+	}
+
+	if cfg.HTTP.Addr != "" {
+		httpTLS, err := c.tlsConfig(cfg.HTTP)
+		if err != nil {
+			c.logError(log, "failed to configure HTTP TLS", err)
+			return 1
+		}
+		httpLn, err := net.Listen("tcp", cfg.HTTP.Addr)
+		if err != nil {
+			c.logError(log, "failed to start HTTP listener", err)
+			return 1
+		}
+		defer httpLn.Close()
+
+		if httpTLS != nil {
+			httpLn = tls.NewListener(httpLn, httpTLS)
+		}
+		opts = append(opts, server.WithHTTP(httpLn))
+	}
+
+	if ac, ok := impl.(server.AuthChecker); ok {
+		opts = append(opts, server.WithAuthentication(ac))
+	}
+
+	// The first time the server is started we generate the bootstrap
+	// token. This is the only time it is available. Installers disable
+	// this so they can request the token themselves.
+	if b, ok := impl.(interface{ Bootstrapped() bool }); ok && !b.Bootstrapped() &&
+		!c.boolFlag(c.flagDisableBootstrap) {
+		resp, err := impl.BootstrapToken(ctx, &emptypb.Empty{})
+		if err != nil {
+			c.logError(log, "failed to bootstrap server", err)
+			return 1
+		}
+
+		c.ui.Output("Server bootstrapped", terminal.WithHeaderStyle())
+		c.ui.Output("The bootstrap token for this server is shown below. It will not be\n"+
+			"shown again, so store it somewhere safe.\n", terminal.WithInfoStyle())
+		c.ui.Output(resp.Token)
+	}
+
+	c.ui.Output("Vagrant server running", terminal.WithHeaderStyle())
+	c.ui.Output("gRPC address: %s", grpcLn.Addr().String(), terminal.WithInfoStyle())
+	if cfg.HTTP.Addr != "" {
+		c.ui.Output("HTTP address: %s", cfg.HTTP.Addr, terminal.WithInfoStyle())
+	}
+
+	if err := server.Run(opts...); err != nil && !errors.Is(err, ctx.Err()) {
+		c.logError(log, "server failed", err)
+		return 1
+	}
+
+	c.ui.Output("Vagrant server stopped", terminal.WithInfoStyle())
+	return 0
+}
+
+// serverConfig loads the server configuration file if one is given and
+// applies any settings given by flags on top of it.
+func (c *ServerRunCommand) serverConfig() (*serverconfig.Config, error) {
+	cfg := &serverconfig.Config{}
+	if path := c.stringFlag(c.flagConfig); path != "" {
+		var err error
+		if cfg, err = serverconfig.Load(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if v := c.stringFlag(c.flagDB); v != "" {
+		cfg.DBPath = v
+	}
+	if v := c.stringFlag(c.flagListenGRPC); v != "" {
+		cfg.GRPC.Addr = v
+	}
+	if v := c.stringFlag(c.flagListenHTTP); v != "" {
+		cfg.HTTP.Addr = v
+	}
+	if v := c.stringFlag(c.flagDrain); v != "" {
+		cfg.DrainTimeout = v
+	}
+
+	return cfg, nil
+}
+
+// validateServerConfig checks the settings required to run a server.
+func validateServerConfig(cfg *serverconfig.Config) error {
+	if cfg.DBPath == "" {
+		return errors.New("db_path must be set")
+	}
+	if cfg.GRPC.Addr == "" {
+		return errors.New("grpc address must be set")
+	}
+	if cfg.GRPC.TLSClientCertAuth && (cfg.GRPC.TLSDisable || cfg.GRPC.TLSClientCAFile == "") {
+		return errors.New("grpc tls_client_cert_auth requires TLS and tls_client_ca_file")
+	}
+
+	return nil
+}
+
+// tlsConfig returns the TLS configuration for the given listener, or
+// nil if TLS is disabled. A self-signed certificate is used if none is
+// configured.
+func (c *ServerRunCommand) tlsConfig(cfg serverconfig.Listener) (*tls.Config, error) {
+	if cfg.TLSDisable {
+		return nil, nil
+	}
+
+	if cfg.TLSCertFile == "" && cfg.TLSKeyFile == "" {
+		c.Log.Warn("no TLS certificate configured, using a self-signed certificate",
+			"addr", cfg.Addr)
+	}
+
+	return server.TLSConfig(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile)
+}
+
+func (c *ServerRunCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagConfig = &component.CommandFlag{
+			LongName:    "config",
+			Description: "Path to the server configuration file",
+			Type:        component.FlagString,
+		}
+		c.flagDB = &component.CommandFlag{
+			LongName:    "db",
+			Description: "Path to the database file, overrides the configuration file",
+			Type:        component.FlagString,
+		}
+		c.flagListenGRPC = &component.CommandFlag{
+			LongName:    "listen-grpc",
+			Description: "Address to bind the gRPC listener to, overrides the configuration file",
+			Type:        component.FlagString,
+		}
+		c.flagListenHTTP = &component.CommandFlag{
+			LongName:    "listen-http",
+			Description: "Address to bind the HTTP listener to, overrides the configuration file",
+			Type:        component.FlagString,
+		}
+
+		c.flagDrain = &component.CommandFlag{
+			LongName:    "drain-timeout",
+			Description: "How long to wait for running jobs when terminated, overrides the configuration file",
+			Type:        component.FlagString,
+		}
+
+		c.flagDisableBootstrap = &component.CommandFlag{
+			LongName:     "disable-bootstrap",
+			Description:  "Don't generate the bootstrap token on the first start",
+			DefaultValue: "false",
+			Type:         component.FlagBool,
+		}
+
+		return append(set, c.flagConfig, c.flagDB, c.flagListenGRPC, c.flagListenHTTP, c.flagDrain,
+			c.flagDisableBootstrap)
+	})
+}
+
+func (c *ServerRunCommand) Primary() bool {
+	return false
+}
+
+func (c *ServerRunCommand) Synopsis() string {
+	return "Run a Vagrant server"
+}
+
+func (c *ServerRunCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant server run [--config=PATH] [options]
+  Run a long-lived Vagrant server.
+
+  The server is configured by the HCL file given with --config, which sets
+  the database path and the gRPC and HTTP listeners. The --db, --listen-grpc
+  and --listen-http flags override the file, and can be used without one.
+  Listeners use TLS unless tls_disable is set. If no certificate is
+  configured a self-signed certificate is generated.
+
+  The first time the server is started a bootstrap token is printed. This
+  token is only shown once. With --disable-bootstrap no token is generated,
+  and the token can be requested once through the BootstrapToken API
+  instead. "vagrant server install" uses this to log the CLI in.
+
+  The HTTP listener serves a JSON API under "/v1/", described by the OpenAPI
+  document at "/v1/openapi.json". Requests authenticate with a token in the
+  Authorization header.
+
+  The HTTP listener serves "/healthz" and "/readyz", and the gRPC listener
+  serves the standard gRPC health service. On SIGTERM the server drains:
+  it stops assigning jobs, reports that it is not ready, and waits for
+  running jobs to complete for up to the drain timeout (default 5m) before
+  exiting. An interrupt or a second SIGTERM exits immediately.
+`)
+}
@@ -0,0 +1,336 @@
+package cli
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+type TokenListCommand struct {
+	*baseCommand
+}
+
+func (c *TokenListCommand) Run(args []string) int {
+	conn, err := c.initServerCommand(args, c.Flags())
+	if err != nil {
+		return 1
+	}
+	defer conn.Close()
+
+	resp, err := vagrant_server.NewVagrantClient(conn).ListTokens(c.Ctx, &emptypb.Empty{})
+	if err != nil {
+		c.logError(c.Log, "failed to list tokens", err)
+		return 1
+	}
+
+	if len(resp.Tokens) == 0 {
+		c.ui.Output("No tokens have been issued.")
+		return 0
+	}
+
+	tokens := resp.Tokens
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].IssuedAt.AsTime().Before(tokens[j].IssuedAt.AsTime())
+	})
+
+	tbl := terminal.NewTable("ID", "TYPE", "KEY", "ISSUED", "EXPIRES", "REVOKED")
+	for _, t := range tokens {
+		kind := "login"
+		if t.Invite {
+			kind = "invite"
+		}
+
+		tbl.Rich([]string{
+			t.Id,
+			kind,
+			t.KeyId,
+			formatTime(t.IssuedAt, "unknown"),
+			formatTime(t.ValidUntil, "never"),
+			formatTime(t.RevokedAt, ""),
+		}, nil)
+	}
+	c.ui.Table(tbl)
+
+	return 0
+}
+
+func (c *TokenListCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *TokenListCommand) Primary() bool {
+	return false
+}
+
+func (c *TokenListCommand) Synopsis() string {
+	return "List the tokens issued by the server"
+}
+
+func (c *TokenListCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant token list
+  List the tokens issued by the server of the current context. The tokens
+  themselves are never stored, only their id and validity.
+`)
+}
+
+type TokenCreateCommand struct {
+	*baseCommand
+
+	flagDuration *component.CommandFlag
+}
+
+func (c *TokenCreateCommand) Run(args []string) int {
+	conn, err := c.initServerCommand(args, c.Flags())
+	if err != nil {
+		return 1
+	}
+	defer conn.Close()
+
+	if len(c.args) != 0 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	resp, err := vagrant_server.NewVagrantClient(conn).GenerateLoginToken(c.Ctx,
+		&vagrant_server.LoginTokenRequest{
+			Duration: c.stringFlag(c.flagDuration),
+		},
+	)
+	if err != nil {
+		c.logError(c.Log, "failed to create token", err)
+		return 1
+	}
+
+	if err := c.outputToken(resp.Token); err != nil {
+		c.logError(c.Log, "failed to write token", err)
+		return 1
+	}
+
+	return 0
+}
+
+func (c *TokenCreateCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagDuration = &component.CommandFlag{
+			LongName:    "duration",
+			Description: "How long the token is valid for, such as \"24h\". If unset the token never expires",
+			Type:        component.FlagString,
+		}
+
+		return append(set, c.flagDuration)
+	})
+}
+
+func (c *TokenCreateCommand) Primary() bool {
+	return false
+}
+
+func (c *TokenCreateCommand) Synopsis() string {
+	return "Create a new login token"
+}
+
+func (c *TokenCreateCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant token create [options]
+  Create a new login token with the server of the current context. The token
+  is written to stdout.
+
+` + c.Flags().Display())
+}
+
+type TokenExchangeCommand struct {
+	*baseCommand
+}
+
+func (c *TokenExchangeCommand) Run(args []string) int {
+	conn, err := c.initServerCommand(args, c.Flags())
+	if err != nil {
+		return 1
+	}
+	defer conn.Close()
+
+	if len(c.args) != 1 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	resp, err := vagrant_server.NewVagrantClient(conn).ConvertInviteToken(c.Ctx,
+		&vagrant_server.ConvertInviteTokenRequest{
+			Token: c.args[0],
+		},
+	)
+	if err != nil {
+		c.logError(c.Log, "failed to exchange invite token", err)
+		return 1
+	}
+
+	if err := c.outputToken(resp.Token); err != nil {
+		c.logError(c.Log, "failed to write token", err)
+		return 1
+	}
+
+	return 0
+}
+
+func (c *TokenExchangeCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *TokenExchangeCommand) Primary() bool {
+	return false
+}
+
+func (c *TokenExchangeCommand) Synopsis() string {
+	return "Exchange an invite token for a login token"
+}
+
+func (c *TokenExchangeCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant token exchange INVITE
+  Exchange an invite token, as created by "vagrant user invite", for a login
+  token with the server of the current context. The login token is written
+  to stdout and is for the user the invite was created for.
+`)
+}
+
+type TokenRevokeCommand struct {
+	*baseCommand
+
+	flagToken *component.CommandFlag
+}
+
+func (c *TokenRevokeCommand) Run(args []string) int {
+	conn, err := c.initServerCommand(args, c.Flags())
+	if err != nil {
+		return 1
+	}
+	defer conn.Close()
+
+	if len(c.args) == 0 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	client := vagrant_server.NewVagrantClient(conn)
+	byValue := c.boolFlag(c.flagToken)
+	for _, arg := range c.args {
+		req := &vagrant_server.RevokeTokenRequest{
+			Target: &vagrant_server.RevokeTokenRequest_Id{Id: arg},
+		}
+		name := arg
+		if byValue {
+			req.Target = &vagrant_server.RevokeTokenRequest_Token{Token: arg}
+			name = "given"
+		}
+
+		if _, err := client.RevokeToken(c.Ctx, req); err != nil {
+			c.logError(c.Log, "failed to revoke token", err)
+			return 1
+		}
+
+		c.ui.Output("Token %s revoked.", name, terminal.WithSuccessStyle())
+	}
+
+	return 0
+}
+
+func (c *TokenRevokeCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagToken = &component.CommandFlag{
+			LongName:     "token",
+			Description:  "Treat the arguments as tokens rather than token ids",
+			DefaultValue: "false",
+			Type:         component.FlagBool,
+		}
+
+		return append(set, c.flagToken)
+	})
+}
+
+func (c *TokenRevokeCommand) Primary() bool {
+	return false
+}
+
+func (c *TokenRevokeCommand) Synopsis() string {
+	return "Revoke tokens"
+}
+
+func (c *TokenRevokeCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant token revoke [options] ID...
+  Revoke one or more tokens by id, as shown by "vagrant token list". With
+  --token the arguments are the tokens themselves, which allows revoking
+  tokens that the server has no record of.
+
+` + c.Flags().Display())
+}
+
+type TokenRotateKeyCommand struct {
+	*baseCommand
+
+	flagGracePeriod *component.CommandFlag
+}
+
+func (c *TokenRotateKeyCommand) Run(args []string) int {
+	conn, err := c.initServerCommand(args, c.Flags())
+	if err != nil {
+		return 1
+	}
+	defer conn.Close()
+
+	if len(c.args) != 0 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	resp, err := vagrant_server.NewVagrantClient(conn).RotateHMACKey(c.Ctx,
+		&vagrant_server.RotateHMACKeyRequest{
+			GracePeriod: c.stringFlag(c.flagGracePeriod),
+		},
+	)
+	if err != nil {
+		c.logError(c.Log, "failed to rotate key", err)
+		return 1
+	}
+
+	c.ui.Output("Tokens are now signed with key %q.", resp.KeyId, terminal.WithSuccessStyle())
+	c.ui.Output("Tokens signed with previous keys are valid until %s.",
+		formatTime(resp.PreviousValidUntil, "now"))
+	return 0
+}
+
+func (c *TokenRotateKeyCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagGracePeriod = &component.CommandFlag{
+			LongName:     "grace-period",
+			Description:  "How long tokens signed with the previous keys remain valid",
+			DefaultValue: "24h",
+			Type:         component.FlagString,
+		}
+
+		return append(set, c.flagGracePeriod)
+	})
+}
+
+func (c *TokenRotateKeyCommand) Primary() bool {
+	return false
+}
+
+func (c *TokenRotateKeyCommand) Synopsis() string {
+	return "Rotate the key used to sign tokens"
+}
+
+func (c *TokenRotateKeyCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant token rotate-key [options]
+  Create a new key to sign tokens with. Tokens signed with the previous keys
+  remain valid for the grace period, after which they are rejected. Issue new
+  tokens to clients before the grace period ends.
+
+` + c.Flags().Display())
+}
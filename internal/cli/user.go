@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+type UserListCommand struct {
+	*baseCommand
+}
+
+func (c *UserListCommand) Run(args []string) int {
+	conn, err := c.initServerCommand(args, c.Flags())
+	if err != nil {
+		return 1
+	}
+	defer conn.Close()
+
+	resp, err := vagrant_server.NewVagrantClient(conn).ListUsers(c.Ctx, &emptypb.Empty{})
+	if err != nil {
+		c.logError(c.Log, "failed to list users", err)
+		return 1
+	}
+
+	users := resp.Users
+	sort.SliceStable(users, func(i, j int) bool {
+		return users[i].CreatedAt.AsTime().Before(users[j].CreatedAt.AsTime())
+	})
+
+	tbl := terminal.NewTable("USERNAME", "CREATED")
+	for _, u := range users {
+		tbl.Rich([]string{u.Username, formatTime(u.CreatedAt, "")}, nil)
+	}
+	c.ui.Table(tbl)
+
+	return 0
+}
+
+func (c *UserListCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *UserListCommand) Primary() bool {
+	return false
+}
+
+func (c *UserListCommand) Synopsis() string {
+	return "List the users of the server"
+}
+
+func (c *UserListCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant user list
+  List the users of the server of the current context.
+`)
+}
+
+type UserInviteCommand struct {
+	*baseCommand
+
+	flagDuration *component.CommandFlag
+}
+
+func (c *UserInviteCommand) Run(args []string) int {
+	conn, err := c.initServerCommand(args, c.Flags())
+	if err != nil {
+		return 1
+	}
+	defer conn.Close()
+
+	if len(c.args) != 1 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	resp, err := vagrant_server.NewVagrantClient(conn).GenerateInviteToken(c.Ctx,
+		&vagrant_server.InviteTokenRequest{
+			Duration: c.stringFlag(c.flagDuration),
+			Username: c.args[0],
+		},
+	)
+	if err != nil {
+		c.logError(c.Log, "failed to create invite token", err)
+		return 1
+	}
+
+	if err := c.outputToken(resp.Token); err != nil {
+		c.logError(c.Log, "failed to write token", err)
+		return 1
+	}
+
+	return 0
+}
+
+func (c *UserInviteCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, func(set []*component.CommandFlag) []*component.CommandFlag {
+		c.flagDuration = &component.CommandFlag{
+			LongName:     "duration",
+			Description:  "How long the invite is valid for",
+			DefaultValue: "24h",
+			Type:         component.FlagString,
+		}
+
+		return append(set, c.flagDuration)
+	})
+}
+
+func (c *UserInviteCommand) Primary() bool {
+	return false
+}
+
+func (c *UserInviteCommand) Synopsis() string {
+	return "Invite a user to the server"
+}
+
+func (c *UserInviteCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant user invite [options] USERNAME
+  Create an invite token for the given user. The invite token is written to
+  stdout. The user exchanges it for a login token with "vagrant token
+  exchange", which creates the user if it does not exist yet. Only the
+  default user can invite users, and only users which do not exist yet.
+
+` + c.Flags().Display())
+}
+
+type UserDeleteCommand struct {
+	*baseCommand
+}
+
+func (c *UserDeleteCommand) Run(args []string) int {
+	conn, err := c.initServerCommand(args, c.Flags())
+	if err != nil {
+		return 1
+	}
+	defer conn.Close()
+
+	if len(c.args) == 0 {
+		c.ui.Output(c.Help(), terminal.WithErrorStyle())
+		return 1
+	}
+
+	client := vagrant_server.NewVagrantClient(conn)
+	for _, name := range c.args {
+		_, err := client.DeleteUser(c.Ctx, &vagrant_server.DeleteUserRequest{Username: name})
+		if err != nil {
+			c.logError(c.Log, "failed to delete user", err)
+			return 1
+		}
+
+		c.ui.Output("User %q deleted.", name, terminal.WithSuccessStyle())
+	}
+
+	return 0
+}
+
+func (c *UserDeleteCommand) Flags() component.CommandFlags {
+	return c.flagSet(0, nil)
+}
+
+func (c *UserDeleteCommand) Primary() bool {
+	return false
+}
+
+func (c *UserDeleteCommand) Synopsis() string {
+	return "Delete users"
+}
+
+func (c *UserDeleteCommand) Help() string {
+	return formatHelp(`
+Usage: vagrant user delete USERNAME...
+  Delete one or more users. The tokens of deleted users are rejected by the
+  server from then on. Only the default user can delete users, and the
+  default user cannot be deleted.
+`)
+}
@@ -130,14 +130,7 @@ func (b *Basis) LoadVagrantfile() error {
 
 	l.Trace("attempting to load basis vagrantfile")
 
-	raw, err := b.VagrantRubyRuntime().Dispense("vagrantrubyruntime")
-	if err != nil {
-		return err
-	}
-
-	p, err := LoadVagrantfile(
-		vpath, l, raw.(serverclient.RubyVagrantClient))
-
+	p, err := b.client.loadVagrantfile(vpath, b.path, l)
 	if err != nil {
 		return err
 	}
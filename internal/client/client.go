@@ -41,6 +41,10 @@ type Client struct {
 	runner      *runner.Runner
 	runnerRef   *vagrant_server.Ref_Runner
 	ui          terminal.UI
+
+	// vagrantfileCache is whether parsed Ruby Vagrantfiles are cached
+	// in the server and reused while unchanged.
+	vagrantfileCache bool
 }
 
 func New(ctx context.Context, opts ...Option) (c *Client, err error) {
@@ -265,6 +269,17 @@ func WithCleanup(f func() error) Option {
 	}
 }
 
+// WithVagrantfileCache sets whether parsed Ruby Vagrantfiles are cached in
+// the server. When enabled, an unchanged Vagrantfile is not parsed again by
+// the Ruby runtime. This is disabled by default since changes to files or
+// environment variables read by the Vagrantfile itself are not detected.
+func WithVagrantfileCache(enabled bool) Option {
+	return func(c *Client, _ *clientConfig) error {
+		c.vagrantfileCache = enabled
+		return nil
+	}
+}
+
 func WithConfig(cfg *config.Config) Option {
 	return func(c *Client, _ *clientConfig) error {
 		c.config = cfg
@@ -295,7 +310,17 @@ func LoadVagrantfile(
 	}
 
 	switch ext {
-	case ".hcl", ".json":
+	case ".hcl":
+		if p.Raw, err = os.ReadFile(file.String()); err != nil {
+			return nil, err
+		}
+
+		// The SDK does not load HCL Vagrantfiles yet, so they are parsed
+		// into the same form as Ruby Vagrantfiles here.
+		if p.Unfinalized, err = config.ParseVagrantfile(p.Raw, file.String()); err != nil {
+			return nil, err
+		}
+	case ".json":
 		f, err := os.Open(file.String())
 		if err != nil {
 			return nil, err
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant/internal/config"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	"github.com/hashicorp/vagrant/internal/serverclient"
+)
+
+func TestLoadVagrantfile_convertedHCL(t *testing.T) {
+	require := require.New(t)
+
+	original := config.TestHash(t,
+		"vm", config.TestConfigData(t, "VagrantPlugins::Kernel_V2::VMConfig",
+			"box", wrapperspb.String("hashicorp/bionic64"),
+			"graceful_halt_timeout", wrapperspb.Int64(60),
+			"networks", &vagrant_plugin_sdk.Args_Array{List: []*anypb.Any{
+				config.TestAny(t, config.TestHash(t,
+					"type", &vagrant_plugin_sdk.Args_Symbol{Str: "private_network"},
+					"ip", wrapperspb.String("192.168.33.10"),
+				)),
+			}},
+			"__defined_vm_keys", &vagrant_plugin_sdk.Args_Array{List: []*anypb.Any{
+				config.TestAny(t, &vagrant_plugin_sdk.Args_Symbol{Str: "web"}),
+			}},
+		),
+		"ssh", config.TestConfigData(t, "VagrantPlugins::Kernel_V2::SSHConfig",
+			"forward_agent", wrapperspb.Bool(true),
+			"proxy_command", &vagrant_plugin_sdk.Args_Null{},
+		),
+	)
+
+	// Convert the configuration and load the result back
+	src, skipped, err := config.ConvertVagrantfile(original)
+	require.NoError(err)
+	require.Zero(skipped)
+
+	file := filepath.Join(t.TempDir(), "Vagrantfile.hcl")
+	require.NoError(os.WriteFile(file, src, 0644))
+
+	vagrantfile, err := LoadVagrantfile(path.NewPath(file), hclog.NewNullLogger(), serverclient.RubyVagrantClient{})
+	require.NoError(err)
+	require.Equal(vagrant_server.Vagrantfile_HCL, vagrantfile.Format)
+	require.Equal(src, vagrantfile.Raw)
+
+	// The loaded configuration has the same options and values
+	options, err := config.VagrantfileOptions(vagrantfile.Unfinalized)
+	require.NoError(err)
+	expected, err := config.VagrantfileOptions(original)
+	require.NoError(err)
+	require.Equal(expected, options)
+
+	value, skippedValues, err := config.VagrantfileValue(vagrantfile.Unfinalized)
+	require.NoError(err)
+	require.Empty(skippedValues)
+	expectedValue, _, err := config.VagrantfileValue(original)
+	require.NoError(err)
+	require.True(expectedValue.Equals(value).True(), "%#v", value)
+
+	names, ok, err := config.VagrantfileTargetNames(vagrantfile.Unfinalized)
+	require.NoError(err)
+	require.True(ok)
+	require.Equal([]string{"web"}, names)
+}
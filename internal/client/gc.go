@@ -0,0 +1,19 @@
+package client
+
+import (
+	"context"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// GarbageCollect removes projects which no longer exist and targets
+// which their project no longer defines. If dryRun is set nothing is
+// removed, and the result lists what would have been.
+func (c *Client) GarbageCollect(
+	ctx context.Context,
+	dryRun bool,
+) (*vagrant_server.GarbageCollectResponse, error) {
+	return c.client.GarbageCollect(ctx, &vagrant_server.GarbageCollectRequest{
+		DryRun: dryRun,
+	})
+}
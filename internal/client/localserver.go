@@ -0,0 +1,262 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/paths"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/vagrant/internal/protocolversion"
+	"github.com/hashicorp/vagrant/internal/server"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	"github.com/hashicorp/vagrant/internal/server/singleprocess"
+	"github.com/hashicorp/vagrant/internal/serverclient"
+)
+
+const (
+	// EnvLocalServerDaemon can be set to a false value to run the local
+	// server within the Vagrant process instead of sharing a background
+	// server between Vagrant processes.
+	EnvLocalServerDaemon = "VAGRANT_LOCAL_SERVER_DAEMON"
+
+	// EnvLocalServerIdleTimeout is the duration the background local
+	// server waits without any activity before shutting down.
+	EnvLocalServerIdleTimeout = "VAGRANT_LOCAL_SERVER_IDLE_TIMEOUT"
+
+	// EnvLocalServerGC can be set to a true value to remove projects
+	// which no longer exist whenever the local server shuts down.
+	EnvLocalServerGC = "VAGRANT_LOCAL_SERVER_GC"
+
+	// LocalServerCommand is the hidden CLI command which runs the
+	// background local server.
+	LocalServerCommand = "local-server"
+
+	// defaultLocalServerIdleTimeout is used when no idle timeout is set.
+	defaultLocalServerIdleTimeout = 5 * time.Minute
+
+	// localServerStartTimeout is how long to wait for a spawned local
+	// server to start accepting connections.
+	localServerStartTimeout = 30 * time.Second
+)
+
+// localServerPaths returns the paths to the database, socket, and log
+// file used by the local server.
+func localServerPaths() (db, sock, log string, err error) {
+	dataPath, err := paths.VagrantData()
+	if err != nil {
+		return
+	}
+
+	return dataPath.Join("data.db").String(),
+		dataPath.Join("server.sock").String(),
+		dataPath.Join("server.log").String(),
+		nil
+}
+
+// localServerDaemon returns if the local server should be run as a shared
+// background process.
+func localServerDaemon() bool {
+	v, ok := os.LookupEnv(EnvLocalServerDaemon)
+	if !ok {
+		return true
+	}
+	enabled, err := strconv.ParseBool(v)
+	return err != nil || enabled
+}
+
+// localServerGarbageCollect removes projects which no longer exist if
+// enabled with EnvLocalServerGC. The local server shares the filesystem
+// with the projects so their paths can be checked.
+func localServerGarbageCollect(ctx context.Context, impl vagrant_server.VagrantServer) error {
+	if enabled, _ := strconv.ParseBool(os.Getenv(EnvLocalServerGC)); !enabled {
+		return nil
+	}
+
+	_, err := impl.GarbageCollect(ctx, &vagrant_server.GarbageCollectRequest{ProjectsOnly: true})
+	return err
+}
+
+// LocalServerIdleTimeout returns the idle timeout to use for the
+// background local server.
+func LocalServerIdleTimeout() time.Duration {
+	if v := os.Getenv(EnvLocalServerIdleTimeout); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+
+	return defaultLocalServerIdleTimeout
+}
+
+// RunLocalServer runs the background local server. It listens on a Unix
+// domain socket in the Vagrant data directory and blocks until the
+// context is cancelled or the server has been idle for idleTimeout. Only
+// one local server can run at a time, which is enforced by the lock on
+// the database.
+func RunLocalServer(ctx context.Context, log hclog.Logger, idleTimeout time.Duration) (err error) {
+	dbPath, sockPath, _, err := localServerPaths()
+	if err != nil {
+		return err
+	}
+
+	log.Debug("opening local mode DB", "path", dbPath)
+	db, err := bolt.Open(dbPath, 0600, &bolt.Options{
+		Timeout: 1 * time.Second,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open local server database (another local server may be running): %w", err)
+	}
+	defer db.Close()
+
+	impl, err := singleprocess.New(
+		singleprocess.WithDB(db),
+		singleprocess.WithLogger(log.Named("singleprocess")),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Prune old jobs before closing the database
+	defer func() {
+		if _, err := impl.PruneOldJobs(context.Background(), nil); err != nil {
+			log.Warn("failed to prune old jobs", "error", err)
+		}
+	}()
+
+	defer func() {
+		if err := localServerGarbageCollect(context.Background(), impl); err != nil {
+			log.Warn("failed to garbage collect projects", "error", err)
+		}
+	}()
+
+	// The server is configured with no advertise address which will
+	// disable the CEB completely.
+	_, err = impl.SetServerConfig(ctx, &vagrant_server.SetServerConfigRequest{
+		Config: &vagrant_server.ServerConfig{
+			AdvertiseAddrs: []*vagrant_server.ServerConfig_AdvertiseAddr{
+				{
+					Addr: "",
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Since we hold the database lock, any existing socket is stale
+	// and can be safely removed.
+	if err := os.Remove(sockPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(sockPath)
+	defer ln.Close()
+	if err := os.Chmod(sockPath, 0600); err != nil {
+		return err
+	}
+
+	log.Info("starting local server", "addr", sockPath, "idle-timeout", idleTimeout)
+	return server.Run(
+		server.WithContext(ctx),
+		server.WithLogger(log),
+		server.WithGRPC(ln),
+		server.WithImpl(impl),
+		server.WithIdleTimeout(idleTimeout),
+	)
+}
+
+// connectLocalServer connects to the background local server, starting
+// it if it is not already running. The server version is negotiated
+// so an incompatible server is reported before it is used.
+func (c *Client) connectLocalServer(ctx context.Context) (*grpc.ClientConn, error) {
+	log := c.logger.ResetNamed("vagrant.server")
+
+	_, sockPath, logPath, err := localServerPaths()
+	if err != nil {
+		return nil, err
+	}
+	addr := "unix:" + sockPath
+
+	var conn *grpc.ClientConn
+	if _, err := os.Stat(sockPath); err == nil {
+		conn, err = serverclient.Connect(ctx,
+			serverclient.WithAddr(addr),
+			serverclient.Timeout(time.Second),
+		)
+		if err != nil {
+			log.Debug("failed to connect to existing local server", "error", err)
+		}
+	}
+
+	if conn == nil {
+		log.Info("starting background local server", "addr", sockPath)
+		if err := spawnLocalServer(logPath); err != nil {
+			return nil, err
+		}
+
+		deadline := time.Now().Add(localServerStartTimeout)
+		for conn == nil {
+			conn, err = serverclient.Connect(ctx,
+				serverclient.WithAddr(addr),
+				serverclient.Timeout(time.Second),
+			)
+			if err != nil && time.Now().After(deadline) {
+				return nil, fmt.Errorf("timeout waiting for local server to start, see %s for details: %w",
+					logPath, err)
+			}
+		}
+	}
+
+	resp, err := vagrant_server.NewVagrantClient(conn).GetVersionInfo(ctx, &emptypb.Empty{})
+	if err == nil {
+		_, err = protocolversion.Negotiate(protocolversion.Current().Api, resp.Info.Api)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("the running local Vagrant server is not compatible with this "+
+			"version of Vagrant. It will stop after being idle for %s: %w",
+			LocalServerIdleTimeout(), err)
+	}
+
+	log.Info("connected to background local server", "addr", sockPath,
+		"version", resp.Info.Version)
+	return conn, nil
+}
+
+// spawnLocalServer starts the background local server as a detached
+// process. Output of the server is written to the log path.
+func spawnLocalServer(logPath string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(exe, LocalServerCommand)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = localServerSysProcAttr()
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	return cmd.Process.Release()
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func TestLocalServer(t *testing.T) {
+	require := require.New(t)
+
+	td := testTempDir(t)
+	t.Setenv("VAGRANT_DATA", td)
+
+	_, sockPath, _, err := localServerPaths()
+	require.NoError(err)
+
+	// Start the local server with a short idle timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- RunLocalServer(ctx, hclog.L(), 3*time.Second)
+	}()
+
+	require.Eventually(func() bool {
+		_, err := os.Stat(sockPath)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Connect to the running server
+	c := &Client{logger: hclog.L()}
+	conn, err := c.connectLocalServer(ctx)
+	require.NoError(err)
+	defer conn.Close()
+
+	resp, err := vagrant_server.NewVagrantClient(conn).GetVersionInfo(ctx, &emptypb.Empty{})
+	require.NoError(err)
+	require.NotNil(resp.Info)
+
+	// A second local server can't be started
+	require.Error(RunLocalServer(ctx, hclog.L(), time.Second))
+
+	// Once idle the server shuts down and removes the socket
+	select {
+	case err := <-errCh:
+		require.NoError(err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("local server did not shut down when idle")
+	}
+
+	_, err = os.Stat(sockPath)
+	require.True(os.IsNotExist(err))
+}
+
+func TestLocalServerDaemon(t *testing.T) {
+	require := require.New(t)
+
+	t.Setenv(EnvLocalServerDaemon, "")
+	os.Unsetenv(EnvLocalServerDaemon)
+	require.True(localServerDaemon())
+
+	t.Setenv(EnvLocalServerDaemon, "false")
+	require.False(localServerDaemon())
+
+	t.Setenv(EnvLocalServerDaemon, "1")
+	require.True(localServerDaemon())
+}
+
+func TestLocalServerIdleTimeout(t *testing.T) {
+	require := require.New(t)
+
+	t.Setenv(EnvLocalServerIdleTimeout, "")
+	require.Equal(defaultLocalServerIdleTimeout, LocalServerIdleTimeout())
+
+	t.Setenv(EnvLocalServerIdleTimeout, "30s")
+	require.Equal(30*time.Second, LocalServerIdleTimeout())
+
+	t.Setenv(EnvLocalServerIdleTimeout, "bogus")
+	require.Equal(defaultLocalServerIdleTimeout, LocalServerIdleTimeout())
+}
@@ -0,0 +1,11 @@
+// +build !windows
+
+package client
+
+import "syscall"
+
+// localServerSysProcAttr detaches the local server from the terminal
+// session so it outlives the Vagrant process that started it.
+func localServerSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
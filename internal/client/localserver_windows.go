@@ -0,0 +1,18 @@
+// +build windows
+
+package client
+
+import "syscall"
+
+const (
+	createNewProcessGroup = 0x00000200
+	detachedProcess       = 0x00000008
+)
+
+// localServerSysProcAttr detaches the local server from the console
+// so it outlives the Vagrant process that started it.
+func localServerSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		CreationFlags: createNewProcessGroup | detachedProcess,
+	}
+}
@@ -78,6 +78,15 @@ func (c *Client) Task(
 		mod(job)
 	}
 
+	// Tasks for multiple targets are run by the project of the targets,
+	// which runs the task against each target
+	if targets := op.Task.GetTargets(); len(targets) > 0 {
+		job.Target = nil
+		if job.Project == nil {
+			job.Project = targets[0].Project
+		}
+	}
+
 	result, err := c.doJob(ctx, job, c.ui)
 	if err != nil {
 		return nil, err
@@ -139,6 +148,34 @@ func (c *Client) Docs(
 	return result.Docs, nil
 }
 
+// Config returns the finalized configuration of a target, and where
+// each option was set.
+func (c *Client) Config(
+	ctx context.Context,
+	op *vagrant_server.Job_ConfigOp,
+	mod JobModifier,
+) (*vagrant_server.Job_ConfigResult, error) {
+	if op == nil {
+		op = &vagrant_server.Job_ConfigOp{}
+	}
+
+	job := c.job()
+	job.Operation = &vagrant_server.Job_Config{
+		Config: op,
+	}
+	if mod != nil {
+		mod(job)
+	}
+
+	// Execute it
+	result, err := c.doJob(ctx, job, c.ui)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Config, nil
+}
+
 // TODO(spox): need to think about how to apply this
 func (c *Client) Logs(ctx context.Context) (component.LogViewer, error) {
 	log := c.logger.Named("logs")
@@ -157,3 +194,55 @@ func (c *Client) Logs(ctx context.Context) (component.LogViewer, error) {
 	// Build our log viewer
 	return &logviewer.Viewer{Stream: client}, nil
 }
+
+func (c *Client) GlobalStatus(
+	ctx context.Context,
+	op *vagrant_server.Job_GlobalStatusOp,
+	mod JobModifier,
+) (*vagrant_server.Job_GlobalStatusResult, error) {
+	if op == nil {
+		op = &vagrant_server.Job_GlobalStatusOp{}
+	}
+
+	job := c.job()
+	job.Operation = &vagrant_server.Job_GlobalStatus{
+		GlobalStatus: op,
+	}
+	if mod != nil {
+		mod(job)
+	}
+
+	// Execute it
+	result, err := c.doJob(ctx, job, c.ui)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.GlobalStatus, nil
+}
+
+func (c *Client) ProviderList(
+	ctx context.Context,
+	op *vagrant_server.Job_ProviderListOp,
+	mod JobModifier,
+) (*vagrant_server.Job_ProviderListResult, error) {
+	if op == nil {
+		op = &vagrant_server.Job_ProviderListOp{}
+	}
+
+	job := c.job()
+	job.Operation = &vagrant_server.Job_ProviderList{
+		ProviderList: op,
+	}
+	if mod != nil {
+		mod(job)
+	}
+
+	// Execute it
+	result, err := c.doJob(ctx, job, c.ui)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.ProviderList, nil
+}
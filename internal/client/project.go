@@ -49,18 +49,7 @@ func (p *Project) LoadVagrantfile() error {
 		return nil
 	}
 
-	raw, err := p.client.rubyRuntime.Dispense("vagrantrubyruntime")
-	if err != nil {
-		l.Warn("failed to load ruby runtime for vagrantfile parsing",
-			"error", err,
-		)
-
-		return err
-	}
-
-	vp, err := LoadVagrantfile(
-		p.vagrantfile, l, raw.(serverclient.RubyVagrantClient))
-
+	vp, err := p.client.loadVagrantfile(p.vagrantfile, p.basis.path, l)
 	if err != nil {
 		return err
 	}
@@ -127,6 +116,11 @@ func (p *Project) Close() error {
 	return p.basis.Close()
 }
 
+// Path returns the directory of the project
+func (p *Project) Path() path.Path {
+	return path.NewPath(p.project.Path)
+}
+
 // Ref returns the raw Vagrant server API client.
 func (p *Project) Ref() *vagrant_plugin_sdk.Ref_Project {
 	return &vagrant_plugin_sdk.Ref_Project{
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"os"
+
 	"github.com/hashicorp/vagrant/internal/runner"
 )
 
@@ -14,6 +16,7 @@ func (c *Client) startRunner() (*runner.Runner, error) {
 		runner.WithLogger(c.logger),
 		runner.ByIdOnly(),      // We'll direct target this
 		runner.WithLocal(c.ui), // Local mode
+		runner.WithMetricsAddr(os.Getenv(runner.EnvMetricsAddr)),
 	)
 	if err != nil {
 		return nil, err
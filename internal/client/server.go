@@ -36,7 +36,8 @@ import (
 //      an existing Vagrant server.
 //
 //   2. If WithLocal was specified and no connection addresses can be
-//      found, this will spin up an in-memory server.
+//      found, this will connect to the local server, starting it if
+//      required.
 //
 func (c *Client) initServerClient(ctx context.Context, cfg *clientConfig) (*grpc.ClientConn, error) {
 	log := c.logger.ResetNamed("vagrant.server")
@@ -66,19 +67,42 @@ func (c *Client) initServerClient(ctx context.Context, cfg *clientConfig) (*grpc
 	// can only be reached if we specified "Optional" to serverclient
 	// which is only possible if we configured this client to support local
 	// mode.
-	log.Info("no server credentials found, using in-memory local server")
+	log.Info("no server credentials found, using local server")
 	return c.initLocalServer(ctx)
 }
 
-// initLocalServer starts the local server and configures p.client to
-// point to it. This also configures p.localClosers so that all the
-// resources are properly cleaned up on Close.
+// initLocalServer connects to the local server. By default the local
+// server runs in a background process shared by all Vagrant processes
+// for the current user so that commands can be run concurrently. If
+// the background server is disabled or can't be started, an in-process
+// server is used instead.
+func (c *Client) initLocalServer(ctx context.Context) (*grpc.ClientConn, error) {
+	log := c.logger.ResetNamed("vagrant.server")
+	c.localServer = true
+
+	if !localServerDaemon() {
+		log.Info("background local server disabled, using in-process server")
+		return c.initInProcessServer(ctx)
+	}
+
+	conn, err := c.connectLocalServer(ctx)
+	if err != nil {
+		log.Error("failed to connect to background local server", "error", err)
+		return nil, err
+	}
+	c.Cleanup(func() error { return conn.Close() })
+
+	return conn, nil
+}
+
+// initInProcessServer starts the local server within this process and
+// configures p.client to point to it. This also configures p.localClosers
+// so that all the resources are properly cleaned up on Close.
 //
 // If this returns an error, all resources associated with this operation
 // will be closed, but the project can retry.
-func (c *Client) initLocalServer(ctx context.Context) (_ *grpc.ClientConn, err error) {
+func (c *Client) initInProcessServer(ctx context.Context) (_ *grpc.ClientConn, err error) {
 	log := c.logger.ResetNamed("vagrant.server")
-	c.localServer = true
 
 	// We use this pointer to accumulate things we need to clean up
 	// in the case of an error. On success we nil this variable which
@@ -126,10 +150,13 @@ func (c *Client) initLocalServer(ctx context.Context) (_ *grpc.ClientConn, err e
 		return err
 	})
 
+	cleanups = append(cleanups, func() error {
+		return localServerGarbageCollect(ctx, impl)
+	})
+
 	cleanups = append(cleanups, func() error { return db.Close() })
 
 	// We listen on a random locally bound port
-	// TODO: we should use Unix domain sockets if supported
 	ln, err := net.Listen("tcp", "127.0.0.1:")
 	if err != nil {
 		return
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// ListTasks returns the tasks run within the scope of the request, most
+// recently started first.
+func (c *Client) ListTasks(
+	ctx context.Context,
+	req *vagrant_server.ListTasksRequest,
+) ([]*vagrant_server.Task, error) {
+	resp, err := c.client.ListTasks(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Tasks, nil
+}
+
+// GetTask returns the task with the given ID.
+func (c *Client) GetTask(
+	ctx context.Context,
+	id string,
+) (*vagrant_server.Task, error) {
+	return c.client.GetTask(ctx, &vagrant_server.GetTaskRequest{
+		Ref: &vagrant_server.Ref_Operation{
+			Target: &vagrant_server.Ref_Operation_Id{Id: id},
+		},
+	})
+}
+
+// Rerun runs a task again with the same command, arguments and scope.
+// The scope of the task replaces any scope set by the modifier.
+func (c *Client) Rerun(
+	ctx context.Context,
+	task *vagrant_server.Task,
+	mod JobModifier,
+) (*vagrant_server.Job_RunResult, error) {
+	// Only the request for the task is sent, not the result of the
+	// previous run
+	run := &vagrant_server.Task{
+		Scope:           task.Scope,
+		Task:            task.Task,
+		Component:       task.Component,
+		CliArgs:         task.CliArgs,
+		CommandName:     task.CommandName,
+		Labels:          task.Labels,
+		Targets:         task.Targets,
+		Parallelism:     task.Parallelism,
+		ContinueOnError: task.ContinueOnError,
+	}
+	run = proto.Clone(run).(*vagrant_server.Task)
+
+	return c.Task(ctx, &vagrant_server.Job_RunOp{Task: run},
+		func(j *vagrant_server.Job) {
+			if mod != nil {
+				mod(j)
+			}
+
+			switch s := run.Scope.(type) {
+			case *vagrant_server.Task_Target:
+				j.Target = s.Target
+				j.Project = s.Target.Project
+				j.Basis = s.Target.Project.GetBasis()
+			case *vagrant_server.Task_Project:
+				j.Project = s.Project
+				j.Basis = s.Project.Basis
+			case *vagrant_server.Task_Basis:
+				j.Basis = s.Basis
+			}
+		},
+	)
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/datasource"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// uploadChunkSize is the size of the chunks sent when uploading a workspace.
+const uploadChunkSize = 1024 * 1024
+
+// UploadWorkspace packages the project directory and uploads it to the
+// server so that remote runners can operate on the current, possibly
+// uncommitted, state of the project. Paths listed in the project's
+// .vagrantignore file are excluded. The returned data source references
+// the uploaded workspace and can be set on jobs.
+func (p *Project) UploadWorkspace() (*vagrant_server.Job_DataSource, error) {
+	dir := p.project.Path
+	l := p.logger.With("path", dir)
+
+	f, err := ioutil.TempFile("", "vagrant-workspace")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	s := p.ui.Status()
+	defer s.Close()
+	s.Update("Packaging project workspace...")
+
+	digest, err := datasource.PackWorkspace(dir, f)
+	if err != nil {
+		s.Step(terminal.StatusError, "Failed to package project workspace")
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	l.Debug("uploading workspace", "digest", digest, "size", info.Size())
+	s.Update("Uploading project workspace...")
+
+	stream, err := p.vagrant.UploadBlob(p.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stream.Send(&vagrant_server.UploadBlobRequest{
+		Event: &vagrant_server.UploadBlobRequest_Open_{
+			Open: &vagrant_server.UploadBlobRequest_Open{
+				Digest: digest,
+				Size:   info.Size(),
+			},
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&vagrant_server.UploadBlobRequest{
+				Event: &vagrant_server.UploadBlobRequest_Chunk{
+					Chunk: buf[:n],
+				},
+			}); err != nil {
+				return nil, err
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		s.Step(terminal.StatusError, "Failed to upload project workspace")
+		return nil, err
+	}
+	s.Step(terminal.StatusOK, "Uploaded project workspace")
+
+	return &vagrant_server.Job_DataSource{
+		Source: &vagrant_server.Job_DataSource_Upload{
+			Upload: &vagrant_server.Job_Upload{
+				Digest: resp.Digest,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	"github.com/hashicorp/vagrant/internal/serverclient"
+	"github.com/hashicorp/vagrant/internal/version"
+)
+
+// loadVagrantfile loads the Vagrantfile at the given path. When the
+// Vagrantfile cache is enabled, a Ruby Vagrantfile is only parsed by the
+// Ruby runtime if the server has no cached parse of it.
+func (c *Client) loadVagrantfile(
+	file path.Path, // path to the Vagrantfile
+	basisPath path.Path, // path of the basis the Vagrantfile is loaded in
+	l hclog.Logger,
+) (*vagrant_server.Vagrantfile, error) {
+	ext := filepath.Ext(file.String())
+	if !c.vagrantfileCache || ext == ".hcl" || ext == ".json" {
+		return c.parseVagrantfile(file, l)
+	}
+
+	key, err := vagrantfileCacheKey(file, basisPath)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.GetVagrantfileCache(c.ctx,
+		&vagrant_server.GetVagrantfileCacheRequest{Key: key})
+	if err == nil {
+		l.Debug("using cached vagrantfile parse", "key", key)
+		return resp.Cache.Vagrantfile, nil
+	}
+	if status.Code(err) != codes.NotFound {
+		l.Warn("failed to read vagrantfile cache", "error", err)
+	}
+
+	v, err := c.parseVagrantfile(file, l)
+	if err != nil {
+		return nil, err
+	}
+
+	// Procs are registered with the Ruby runtime which parsed the
+	// Vagrantfile, so a parse that references any can't be reused.
+	if containsProcRef(v.Unfinalized.ProtoReflect()) {
+		l.Debug("not caching vagrantfile parse as it contains procs")
+		return v, nil
+	}
+
+	_, err = c.client.UpsertVagrantfileCache(c.ctx,
+		&vagrant_server.UpsertVagrantfileCacheRequest{
+			Cache: &vagrant_server.VagrantfileCache{
+				Key:         key,
+				Path:        file.String(),
+				Vagrantfile: v,
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed to cache vagrantfile parse", "error", err)
+	}
+
+	return v, nil
+}
+
+// parseVagrantfile loads the Vagrantfile using the Ruby runtime.
+func (c *Client) parseVagrantfile(
+	file path.Path,
+	l hclog.Logger,
+) (*vagrant_server.Vagrantfile, error) {
+	raw, err := c.rubyRuntime.Dispense("vagrantrubyruntime")
+	if err != nil {
+		l.Warn("failed to load ruby runtime for vagrantfile parsing",
+			"error", err,
+		)
+
+		return nil, err
+	}
+
+	return LoadVagrantfile(file, l, raw.(serverclient.RubyVagrantClient))
+}
+
+// vagrantfileCacheKey returns the key of the cached parse of a Vagrantfile.
+// This is a hash of the Vagrantfile and the other Vagrantfiles Vagrant
+// loads with it, which are the basis Vagrantfile and the Vagrantfiles of
+// installed boxes. Ruby Vagrantfiles often read the environment, so the
+// VAGRANT_ environment variables and the Vagrant version are included too.
+//
+// Files and environment variables the Vagrantfile reads itself, such as
+// with load or YAML.load_file, are not known and so are not part of the
+// key. This is why the cache must be enabled explicitly.
+func vagrantfileCacheKey(file, basisPath path.Path) (string, error) {
+	boxFiles, err := filepath.Glob(
+		filepath.Join(basisPath.String(), "boxes", "*", "*", "*", "Vagrantfile"))
+	if err != nil {
+		return "", err
+	}
+
+	files := append([]string{
+		file.String(),
+		basisPath.Join("Vagrantfile").String(),
+	}, boxFiles...)
+
+	h := sha256.New()
+	io.WriteString(h, version.GetVersion().FullVersionNumber(true)+"\n")
+
+	var env []string
+	for _, e := range os.Environ() {
+		if strings.HasPrefix(e, "VAGRANT_") {
+			env = append(env, e)
+		}
+	}
+	sort.Strings(env)
+	for _, e := range env {
+		io.WriteString(h, e+"\n")
+	}
+
+	for _, f := range files {
+		io.WriteString(h, f+"\n")
+
+		content, err := os.ReadFile(f)
+		if os.IsNotExist(err) {
+			io.WriteString(h, "missing\n")
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		sum := sha256.Sum256(content)
+		io.WriteString(h, hex.EncodeToString(sum[:])+"\n")
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+var procRefName = (&vagrant_plugin_sdk.Args_ProcRef{}).ProtoReflect().Descriptor().FullName()
+
+// containsProcRef returns true if the message references a Ruby proc
+// anywhere within it, including within Any values.
+func containsProcRef(m protoreflect.Message) bool {
+	if !m.IsValid() {
+		return false
+	}
+
+	switch v := m.Interface().(type) {
+	case *vagrant_plugin_sdk.Args_ProcRef:
+		return true
+	case *anypb.Any:
+		if v.MessageName() == procRefName {
+			return true
+		}
+
+		// If the value can't be inspected, assume it may hold a proc
+		inner, err := v.UnmarshalNew()
+		if err != nil {
+			return true
+		}
+
+		return containsProcRef(inner.ProtoReflect())
+	}
+
+	found := false
+	m.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := value.List()
+			for i := 0; i < list.Len() && !found; i++ {
+				found = containsProcRef(list.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			value.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				found = containsProcRef(v.Message())
+				return !found
+			})
+		case fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			found = containsProcRef(value.Message())
+		}
+
+		return !found
+	})
+
+	return found
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant/internal/config"
+)
+
+func TestVagrantfileCacheKey(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	basis := filepath.Join(dir, "home")
+	file := filepath.Join(dir, "Vagrantfile")
+	require.NoError(os.WriteFile(file, []byte("one"), 0644))
+
+	key := func() string {
+		k, err := vagrantfileCacheKey(path.NewPath(file), path.NewPath(basis))
+		require.NoError(err)
+		return k
+	}
+
+	k1 := key()
+	require.Equal(k1, key())
+
+	// Changing the Vagrantfile changes the key
+	require.NoError(os.WriteFile(file, []byte("two"), 0644))
+	k2 := key()
+	require.NotEqual(k1, k2)
+
+	// Creating the basis Vagrantfile changes the key
+	require.NoError(os.MkdirAll(basis, 0755))
+	require.NoError(os.WriteFile(filepath.Join(basis, "Vagrantfile"), []byte("home"), 0644))
+	k3 := key()
+	require.NotEqual(k2, k3)
+
+	// Adding a box Vagrantfile changes the key
+	box := filepath.Join(basis, "boxes", "hashicorp-VAGRANTSLASH-bionic64", "1.0.0", "virtualbox")
+	require.NoError(os.MkdirAll(box, 0755))
+	require.NoError(os.WriteFile(filepath.Join(box, "Vagrantfile"), []byte("box"), 0644))
+	k4 := key()
+	require.NotEqual(k3, k4)
+
+	// VAGRANT_ environment variables change the key
+	t.Setenv("VAGRANT_TEST_CACHE_KEY", "1")
+	require.NotEqual(k4, key())
+}
+
+func TestContainsProcRef(t *testing.T) {
+	require := require.New(t)
+
+	str := wrapperspb.String("value")
+	require.False(containsProcRef(config.TestHash(t, "key", str).ProtoReflect()))
+
+	proc := &vagrant_plugin_sdk.Args_ProcRef{Id: "1"}
+	require.True(containsProcRef(config.TestHash(t, "key", proc).ProtoReflect()))
+
+	// Procs nested in raw Ruby values are found too
+	raw := &vagrant_plugin_sdk.Config_RawRubyValue{
+		Source: &vagrant_plugin_sdk.Args_Class{Name: "Foo"},
+		Data: config.TestHash(t,
+			str, &vagrant_plugin_sdk.Args_Array{List: []*anypb.Any{config.TestAny(t, proc)}},
+		),
+	}
+	require.True(containsProcRef(config.TestHash(t, "key", raw).ProtoReflect()))
+
+	var empty *vagrant_plugin_sdk.Args_Hash
+	require.False(containsProcRef(empty.ProtoReflect()))
+}
@@ -6,7 +6,8 @@ import (
 	"path/filepath"
 
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
 
 	"github.com/hashicorp/vagrant/internal/pkg/defaults"
 )
@@ -15,26 +16,30 @@ import (
 // Vagrant server/runners.
 // This does not include Vagrantfile type config
 type Config struct {
-	Runner *Runner           `hcl:"runner,block" default:"{}"`
-	Labels map[string]string `hcl:"labels,optional"`
+	Runner   *Runner           `hcl:"runner,block" default:"{}"`
+	Labels   map[string]string `hcl:"labels,optional"`
+	Hooks    []*Hook           `hcl:"hook,block"`
+	Provider *Provider         `hcl:"provider,block"`
 
 	pathData map[string]string
 	ctx      *hcl.EvalContext
+	body     hcl.Body
+	files    map[string]*hcl.File
 }
 
 // Runner is the configuration for supporting runners in this project.
 type Runner struct {
 	// Enabled is whether or not runners are enabled. If this is false
 	// then the "-remote" flag will not work.
-	Enabled bool
+	Enabled bool `hcl:"enabled,optional"`
 
 	// DataSource is the default data source when a remote job is queued.
-	DataSource *DataSource
+	DataSource *DataSource `hcl:"data_source,block"`
 }
 
 // DataSource configures the data source for the runner.
 type DataSource struct {
-	Type string
+	Type string   `hcl:",label"`
 	Body hcl.Body `hcl:",remain"`
 }
 
@@ -65,19 +70,43 @@ func Load(path string, pwd string) (*Config, error) {
 		"basisfile": path,
 	}
 
-	// Decode
-	var cfg Config
 	// Build our context
 	ctx := EvalContext(nil, pwd).NewChild()
 	addPathValue(ctx, pathData)
 
+	// Parse. We keep the parsed file around so that validation can
+	// report diagnostics against the original source.
+	parser := hclparse.NewParser()
+	var file *hcl.File
+	var diags hcl.Diagnostics
+	if filepath.Ext(path) == ".json" {
+		file, diags = parser.ParseJSONFile(path)
+	} else {
+		file, diags = parser.ParseHCLFile(path)
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
 	// Decode
-	if err := hclsimple.DecodeFile(path, ctx, &cfg); err != nil {
-		return nil, err
+	var cfg Config
+	if diags := gohcl.DecodeBody(file.Body, ctx, &cfg); diags.HasErrors() {
+		return nil, diags
 	}
 	if err := defaults.Set(&cfg); err != nil {
 		return nil, err
 	}
 
+	cfg.pathData = pathData
+	cfg.ctx = ctx
+	cfg.body = file.Body
+	cfg.files = parser.Files()
+
 	return &cfg, nil
 }
+
+// Files returns the parsed source files of the configuration keyed by
+// filename. This is useful for rendering diagnostics.
+func (c *Config) Files() map[string]*hcl.File {
+	return c.files
+}
@@ -32,7 +32,10 @@ func EvalContext(parent *hcl.EvalContext, pwd string) *hcl.EvalContext {
 	// Add some of our functions
 	addFuncs(funcs.VCSGitFuncs(pwd))
 	addFuncs(funcs.Filesystem(pwd))
+	addFuncs(funcs.Crypto(pwd))
 	addFuncs(funcs.Encoding())
+	addFuncs(funcs.Environment())
+	addFuncs(funcs.Datetime())
 
 	return result
 }
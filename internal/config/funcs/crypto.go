@@ -0,0 +1,111 @@
+package funcs
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+
+	"github.com/google/uuid"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+func Crypto(pwd string) map[string]function.Function {
+	return map[string]function.Function{
+		"filemd5":    MakeFileMd5Func(pwd),
+		"filesha256": MakeFileSha256Func(pwd),
+		"uuidv5":     UUIDV5Func,
+	}
+}
+
+// MakeFileMd5Func constructs a function that is like filesha256 but
+// computes an MD5 hash of the contents of the given file.
+func MakeFileMd5Func(baseDir string) function.Function {
+	return makeFileHashFunction(baseDir, md5.New)
+}
+
+// MakeFileSha256Func constructs a function that takes a file path and
+// returns the hex encoded SHA256 hash of the contents of that file.
+func MakeFileSha256Func(baseDir string) function.Function {
+	return makeFileHashFunction(baseDir, sha256.New)
+}
+
+func makeFileHashFunction(baseDir string, hf func() hash.Hash) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "path",
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			path := args[0].AsString()
+			src, err := readFileBytes(baseDir, path)
+			if err != nil {
+				return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+			}
+
+			h := hf()
+			h.Write(src)
+			return cty.StringVal(hex.EncodeToString(h.Sum(nil))), nil
+		},
+	})
+}
+
+// UUIDV5Func constructs a function that generates a name based UUID
+// (version 5) from a namespace and a name. The namespace can be one of
+// "dns", "oid", "url", "x500" or a UUID.
+var UUIDV5Func = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "namespace",
+			Type: cty.String,
+		},
+		{
+			Name: "name",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		var namespace uuid.UUID
+		switch ns := args[0].AsString(); ns {
+		case "dns":
+			namespace = uuid.NameSpaceDNS
+		case "oid":
+			namespace = uuid.NameSpaceOID
+		case "url":
+			namespace = uuid.NameSpaceURL
+		case "x500":
+			namespace = uuid.NameSpaceX500
+		default:
+			var err error
+			if namespace, err = uuid.Parse(ns); err != nil {
+				return cty.UnknownVal(cty.String), function.NewArgErrorf(0,
+					"uuidv5() doesn't support namespace %s (%v)", ns, err)
+			}
+		}
+
+		return cty.StringVal(uuid.NewSHA1(namespace, []byte(args[1].AsString())).String()), nil
+	},
+})
+
+// FileMd5 computes an MD5 hash of the contents of the given file.
+func FileMd5(baseDir string, path cty.Value) (cty.Value, error) {
+	fn := MakeFileMd5Func(baseDir)
+	return fn.Call([]cty.Value{path})
+}
+
+// FileSha256 computes a SHA256 hash of the contents of the given file.
+func FileSha256(baseDir string, path cty.Value) (cty.Value, error) {
+	fn := MakeFileSha256Func(baseDir)
+	return fn.Call([]cty.Value{path})
+}
+
+// UUIDV5 generates and returns a name based UUID for the namespace
+// and name.
+func UUIDV5(namespace, name cty.Value) (cty.Value, error) {
+	return UUIDV5Func.Call([]cty.Value{namespace, name})
+}
@@ -0,0 +1,53 @@
+package funcs
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+func Environment() map[string]function.Function {
+	return map[string]function.Function{
+		"env": EnvFunc,
+	}
+}
+
+// EnvFunc constructs a function that returns the value of an
+// environment variable. An optional default is returned if the
+// variable is not set, otherwise an unset variable is an empty string.
+var EnvFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "name",
+			Type: cty.String,
+		},
+	},
+	VarParam: &function.Parameter{
+		Name: "default",
+		Type: cty.String,
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if len(args) > 2 {
+			return cty.UnknownVal(cty.String), fmt.Errorf(
+				"env() takes at most one default value, got %d", len(args)-1)
+		}
+
+		if v, ok := os.LookupEnv(args[0].AsString()); ok {
+			return cty.StringVal(v), nil
+		}
+		if len(args) == 2 {
+			return args[1], nil
+		}
+
+		return cty.StringVal(""), nil
+	},
+})
+
+// Env returns the value of the named environment variable, or the
+// default if given and the variable is not set.
+func Env(name cty.Value, def ...cty.Value) (cty.Value, error) {
+	return EnvFunc.Call(append([]cty.Value{name}, def...))
+}
@@ -0,0 +1,80 @@
+package funcs
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestEnv(t *testing.T) {
+	os.Setenv("VAGRANT_FUNCS_TEST", "hello")
+	os.Setenv("VAGRANT_FUNCS_TEST_EMPTY", "")
+	os.Unsetenv("VAGRANT_FUNCS_TEST_UNSET")
+	defer os.Unsetenv("VAGRANT_FUNCS_TEST")
+	defer os.Unsetenv("VAGRANT_FUNCS_TEST_EMPTY")
+
+	tests := []struct {
+		Name    cty.Value
+		Default []cty.Value
+		Want    cty.Value
+		Err     bool
+	}{
+		{
+			cty.StringVal("VAGRANT_FUNCS_TEST"),
+			nil,
+			cty.StringVal("hello"),
+			false,
+		},
+		{
+			cty.StringVal("VAGRANT_FUNCS_TEST"),
+			[]cty.Value{cty.StringVal("default")},
+			cty.StringVal("hello"),
+			false,
+		},
+		{
+			cty.StringVal("VAGRANT_FUNCS_TEST_EMPTY"),
+			[]cty.Value{cty.StringVal("default")},
+			cty.StringVal(""),
+			false, // set but empty is not defaulted
+		},
+		{
+			cty.StringVal("VAGRANT_FUNCS_TEST_UNSET"),
+			nil,
+			cty.StringVal(""),
+			false,
+		},
+		{
+			cty.StringVal("VAGRANT_FUNCS_TEST_UNSET"),
+			[]cty.Value{cty.StringVal("default")},
+			cty.StringVal("default"),
+			false,
+		},
+		{
+			cty.StringVal("VAGRANT_FUNCS_TEST_UNSET"),
+			[]cty.Value{cty.StringVal("a"), cty.StringVal("b")},
+			cty.NilVal,
+			true, // too many defaults
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Env(%#v, %#v)", test.Name, test.Default), func(t *testing.T) {
+			got, err := Env(test.Name, test.Default...)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
@@ -2,11 +2,25 @@ package config
 
 // Hook is the configuration for a hook that runs at specified times.
 type Hook struct {
+	Task      string   `hcl:",label"`
 	When      string   `hcl:"when,attr"`
 	Command   []string `hcl:"command,attr"`
 	OnFailure string   `hcl:"on_failure,optional"`
 }
 
+// Valid values for the "when" attribute of a hook.
+const (
+	HookBefore = "before"
+	HookAfter  = "after"
+)
+
+// Valid values for the "on_failure" attribute of a hook. An empty value
+// is the same as HookOnFailureFail.
+const (
+	HookOnFailureFail     = "fail"
+	HookOnFailureContinue = "continue"
+)
+
 func (h *Hook) ContinueOnFailure() bool {
-	return h.OnFailure == "continue"
+	return h.OnFailure == HookOnFailureContinue
 }
@@ -10,6 +10,10 @@ import (
 // Filename is the default filename for the Vagrant configuration.
 const Filename = "Vagrantfile"
 
+// ConfigFilename is the filename for the HCL configuration of the
+// Vagrant process itself, such as runners and hooks.
+const ConfigFilename = "vagrant-config.hcl"
+
 func GetVagrantfileName() string {
 	if f := os.Getenv("VAGRANT_VAGRANTFILE"); f != "" {
 		return f
@@ -0,0 +1,49 @@
+package config
+
+// Provider is the configuration for choosing the default provider.
+type Provider struct {
+	// Priority lists providers in the order they are preferred when
+	// no provider is given. Providers are only chosen from this list if
+	// they are installed and usable. Providers listed in the
+	// VAGRANT_PREFERRED_PROVIDERS environment variable come first.
+	Priority []string `hcl:"priority,optional"`
+
+	// Overrides replace the priority for projects with matching labels.
+	Overrides []*ProviderOverride `hcl:"override,block"`
+}
+
+// ProviderOverride replaces the provider priority when all of its labels
+// match the labels of the project.
+type ProviderOverride struct {
+	Labels   map[string]string `hcl:"labels,attr"`
+	Priority []string          `hcl:"priority,attr"`
+}
+
+// PriorityFor returns the provider priority for a project with the given
+// labels. The first override whose labels all match is used, otherwise
+// the default priority is returned.
+func (p *Provider) PriorityFor(labels map[string]string) []string {
+	if p == nil {
+		return nil
+	}
+
+	for _, o := range p.Overrides {
+		if o.Matches(labels) {
+			return o.Priority
+		}
+	}
+
+	return p.Priority
+}
+
+// Matches returns true if all the labels of the override are set to the
+// same value in labels.
+func (o *ProviderOverride) Matches(labels map[string]string) bool {
+	for k, v := range o.Labels {
+		if lv, ok := labels[k]; !ok || lv != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderPriorityFor(t *testing.T) {
+	require := require.New(t)
+
+	cfg := TestConfig(t, `
+provider {
+  priority = ["vmware_desktop", "virtualbox"]
+
+  override {
+    labels   = { "env" = "ci", "os" = "linux" }
+    priority = ["docker"]
+  }
+
+  override {
+    labels   = { "env" = "ci" }
+    priority = ["libvirt"]
+  }
+}
+`)
+
+	require.Equal([]string{"vmware_desktop", "virtualbox"},
+		cfg.Provider.PriorityFor(nil))
+	require.Equal([]string{"docker"},
+		cfg.Provider.PriorityFor(map[string]string{"env": "ci", "os": "linux"}))
+	require.Equal([]string{"libvirt"},
+		cfg.Provider.PriorityFor(map[string]string{"env": "ci", "os": "darwin"}))
+
+	// Without a provider block there is no priority
+	var p *Provider
+	require.Nil(p.PriorityFor(map[string]string{"env": "ci"}))
+}
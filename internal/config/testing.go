@@ -7,6 +7,11 @@ import (
 
 	"github.com/mitchellh/go-testing-interface"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
 )
 
 // TestConfig returns a Config from a string source and fails the test if
@@ -39,4 +44,52 @@ func TestConfigFile(t testing.T, src string) {
 	require.NoError(t, ioutil.WriteFile(Filename, []byte(src), 0644))
 }
 
+// TestAny returns the message wrapped in an Any. Messages which already
+// are an Any are returned as is.
+func TestAny(t testing.T, m proto.Message) *anypb.Any {
+	t.Helper()
+
+	if a, ok := m.(*anypb.Any); ok {
+		return a
+	}
+
+	a, err := anypb.New(m)
+	require.NoError(t, err)
+
+	return a
+}
+
+// TestHash builds a hash from alternating keys and values, in the form
+// Ruby values are encoded in. String keys are encoded as strings, any
+// other key and the values must be messages.
+func TestHash(t testing.T, kv ...interface{}) *vagrant_plugin_sdk.Args_Hash {
+	t.Helper()
+
+	var h vagrant_plugin_sdk.Args_Hash
+	for i := 0; i < len(kv); i += 2 {
+		key, ok := kv[i].(proto.Message)
+		if !ok {
+			key = wrapperspb.String(kv[i].(string))
+		}
+
+		h.Entries = append(h.Entries, &vagrant_plugin_sdk.Args_HashEntry{
+			Key:   TestAny(t, key),
+			Value: TestAny(t, kv[i+1].(proto.Message)),
+		})
+	}
+
+	return &h
+}
+
+// TestConfigData builds the data of a configuration namespace of the
+// given Ruby class from alternating keys and values, as TestHash does.
+func TestConfigData(t testing.T, class string, kv ...interface{}) *vagrant_plugin_sdk.Args_ConfigData {
+	t.Helper()
+
+	return &vagrant_plugin_sdk.Args_ConfigData{
+		Source: &vagrant_plugin_sdk.Args_Class{Name: class},
+		Data:   TestHash(t, kv...),
+	}
+}
+
 const testSourceVal = ``
@@ -0,0 +1,611 @@
+package config
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+)
+
+// configIdentifierKey is added by the Ruby runtime to every configuration
+// namespace it encodes. It only identifies the Ruby instance so it is not
+// written out.
+const configIdentifierKey = "_vagrant_config_identifier"
+
+// ConvertVagrantfile converts the unfinalized configuration of a parsed
+// Ruby Vagrantfile into an HCL Vagrantfile. Each configuration namespace,
+// such as "vm" or "ssh", becomes a block and each option an attribute.
+//
+// Values which cannot be represented in HCL, such as procs and raw Ruby
+// values, are left out and a comment is written in their place. The
+// number of values left out is returned with the result.
+func ConvertVagrantfile(
+	config *vagrant_plugin_sdk.Args_Hash,
+	opts ...ConvertOption,
+) ([]byte, int, error) {
+	c := &vagrantfileConverter{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	f := hclwrite.NewFile()
+	if err := c.writeHash(f.Body(), "", config); err != nil {
+		return nil, 0, err
+	}
+
+	return f.Bytes(), c.skipped, nil
+}
+
+// ConvertOption is an option for ConvertVagrantfile.
+type ConvertOption func(*vagrantfileConverter)
+
+// WithAnnotation sets a function which is called with the name of each
+// block and attribute written, such as "vm" or "vm.box". If it returns a
+// non-empty string, that is written as a comment above the block or
+// attribute.
+func WithAnnotation(f func(name string) string) ConvertOption {
+	return func(c *vagrantfileConverter) {
+		c.annotate = f
+	}
+}
+
+type vagrantfileConverter struct {
+	skipped  int
+	annotate func(string) string
+}
+
+// writeHash writes the entries of the hash to the body. Configuration
+// namespaces are written as blocks, everything else as attributes.
+func (c *vagrantfileConverter) writeHash(
+	body *hclwrite.Body,
+	prefix string,
+	h *vagrant_plugin_sdk.Args_Hash,
+) error {
+	for _, entry := range h.GetEntries() {
+		key, reason, err := hashKey(entry.Key)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			name := "an entry"
+			if prefix != "" {
+				name = "an entry in " + prefix
+			}
+			c.skip(body, name, reason)
+			continue
+		}
+		if key == configIdentifierKey {
+			continue
+		}
+
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+
+		value, err := entry.Value.UnmarshalNew()
+		if err != nil {
+			return err
+		}
+		if unsetValue(value) {
+			continue
+		}
+
+		if !hclsyntax.ValidIdentifier(key) {
+			c.skip(body, name, "the name is not a valid HCL identifier")
+			continue
+		}
+
+		if cd, ok := value.(*vagrant_plugin_sdk.Args_ConfigData); ok {
+			if len(body.Attributes()) > 0 || len(body.Blocks()) > 0 {
+				body.AppendNewline()
+			}
+			c.comment(body, name)
+			block := body.AppendNewBlock(key, nil)
+			if err := c.writeHash(block.Body(), name, cd.Data); err != nil {
+				return err
+			}
+			continue
+		}
+
+		v, reason, err := ctyValue(entry.Value)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			c.skip(body, name, reason)
+			continue
+		}
+
+		c.comment(body, name)
+		body.SetAttributeValue(key, v)
+	}
+
+	return nil
+}
+
+// comment writes the annotation for the named value, if there is one.
+func (c *vagrantfileConverter) comment(body *hclwrite.Body, name string) {
+	if c.annotate == nil {
+		return
+	}
+
+	text := c.annotate(name)
+	if text == "" {
+		return
+	}
+
+	body.AppendUnstructuredTokens(hclwrite.Tokens{
+		{
+			Type:  hclsyntax.TokenComment,
+			Bytes: []byte("# " + text + "\n"),
+		},
+	})
+}
+
+// skip writes a comment noting that the named value was left out.
+func (c *vagrantfileConverter) skip(body *hclwrite.Body, name, reason string) {
+	c.skipped++
+	body.AppendUnstructuredTokens(hclwrite.Tokens{
+		{
+			Type:  hclsyntax.TokenComment,
+			Bytes: []byte(fmt.Sprintf("# vagrant: %s not converted: %s\n", name, reason)),
+		},
+	})
+}
+
+// ParseVagrantfile parses an HCL Vagrantfile, such as one written by
+// ConvertVagrantfile, into the unfinalized configuration form that Ruby
+// Vagrantfiles are parsed into. Each block becomes a configuration
+// namespace and each attribute an option. Attributes are evaluated
+// without any variables or functions.
+func ParseVagrantfile(src []byte, filename string) (*vagrant_plugin_sdk.Args_Hash, error) {
+	f, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return parseVagrantfileBody(f.Body.(*hclsyntax.Body))
+}
+
+func parseVagrantfileBody(body *hclsyntax.Body) (*vagrant_plugin_sdk.Args_Hash, error) {
+	type item struct {
+		start int
+		key   string
+		value proto.Message
+	}
+
+	var items []item
+	for name, attr := range body.Attributes {
+		v, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		value, err := protoValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", attr.SrcRange, err)
+		}
+
+		items = append(items, item{attr.SrcRange.Start.Byte, name, value})
+	}
+
+	seen := map[string]bool{}
+	for _, block := range body.Blocks {
+		if len(block.Labels) > 0 {
+			return nil, fmt.Errorf("%s: block %q must not have labels",
+				block.DefRange(), block.Type)
+		}
+		if seen[block.Type] || body.Attributes[block.Type] != nil {
+			return nil, fmt.Errorf("%s: %q is defined more than once",
+				block.DefRange(), block.Type)
+		}
+		seen[block.Type] = true
+
+		data, err := parseVagrantfileBody(block.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item{
+			block.Range().Start.Byte,
+			block.Type,
+			&vagrant_plugin_sdk.Args_ConfigData{Data: data},
+		})
+	}
+
+	// Attributes are not ordered, so everything is kept in the order it
+	// is written in the file.
+	sort.Slice(items, func(i, j int) bool { return items[i].start < items[j].start })
+
+	result := &vagrant_plugin_sdk.Args_Hash{}
+	for _, i := range items {
+		entry, err := hashEntry(i.key, i.value)
+		if err != nil {
+			return nil, err
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	return result, nil
+}
+
+// protoValue converts a cty value to the encoding used for Ruby values.
+func protoValue(v cty.Value) (proto.Message, error) {
+	if !v.IsWhollyKnown() {
+		return nil, fmt.Errorf("value is not known")
+	}
+	if v.IsNull() {
+		return &vagrant_plugin_sdk.Args_Null{}, nil
+	}
+
+	ty := v.Type()
+	switch {
+	case ty == cty.String:
+		return wrapperspb.String(v.AsString()), nil
+	case ty == cty.Bool:
+		return wrapperspb.Bool(v.True()), nil
+	case ty == cty.Number:
+		bf := v.AsBigFloat()
+		if i, acc := bf.Int64(); acc == big.Exact {
+			return wrapperspb.Int64(i), nil
+		}
+		f, _ := bf.Float64()
+		return wrapperspb.Double(f), nil
+	case ty.IsTupleType() || ty.IsListType() || ty.IsSetType():
+		list := &vagrant_plugin_sdk.Args_Array{}
+		for it := v.ElementIterator(); it.Next(); {
+			_, ev := it.Element()
+			value, err := protoValue(ev)
+			if err != nil {
+				return nil, err
+			}
+			a, err := anypb.New(value)
+			if err != nil {
+				return nil, err
+			}
+			list.List = append(list.List, a)
+		}
+		return list, nil
+	case ty.IsObjectType() || ty.IsMapType():
+		h := &vagrant_plugin_sdk.Args_Hash{}
+		for it := v.ElementIterator(); it.Next(); {
+			k, ev := it.Element()
+			value, err := protoValue(ev)
+			if err != nil {
+				return nil, err
+			}
+			entry, err := hashEntry(k.AsString(), value)
+			if err != nil {
+				return nil, err
+			}
+			h.Entries = append(h.Entries, entry)
+		}
+		return h, nil
+	default:
+		return nil, fmt.Errorf("values of type %s are not supported", ty.FriendlyName())
+	}
+}
+
+// hashEntry builds a hash entry with a string key.
+func hashEntry(key string, value proto.Message) (*vagrant_plugin_sdk.Args_HashEntry, error) {
+	k, err := anypb.New(wrapperspb.String(key))
+	if err != nil {
+		return nil, err
+	}
+	v, err := anypb.New(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vagrant_plugin_sdk.Args_HashEntry{Key: k, Value: v}, nil
+}
+
+// VagrantfileValue converts Vagrantfile configuration into an object with
+// an attribute for each configuration namespace. Values which cannot be
+// represented are left out, the reason for each is returned keyed by the
+// name of the value, such as "vm.provisioners".
+func VagrantfileValue(
+	config *vagrant_plugin_sdk.Args_Hash,
+) (cty.Value, map[string]string, error) {
+	skipped := map[string]string{}
+	v, err := vagrantfileObject("", config, skipped)
+	return v, skipped, err
+}
+
+func vagrantfileObject(
+	prefix string,
+	h *vagrant_plugin_sdk.Args_Hash,
+	skipped map[string]string,
+) (cty.Value, error) {
+	attrs := map[string]cty.Value{}
+	for _, entry := range h.GetEntries() {
+		key, reason, err := hashKey(entry.Key)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		if reason != "" {
+			skipped[prefix] = reason
+			continue
+		}
+		if key == configIdentifierKey {
+			continue
+		}
+
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+
+		value, err := entry.Value.UnmarshalNew()
+		if err != nil {
+			return cty.NilVal, err
+		}
+		if unsetValue(value) {
+			continue
+		}
+
+		if cd, ok := value.(*vagrant_plugin_sdk.Args_ConfigData); ok {
+			if attrs[key], err = vagrantfileObject(name, cd.Data, skipped); err != nil {
+				return cty.NilVal, err
+			}
+			continue
+		}
+
+		v, reason, err := ctyValue(entry.Value)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		if reason != "" {
+			skipped[name] = reason
+			continue
+		}
+
+		attrs[key] = v
+	}
+
+	return cty.ObjectVal(attrs), nil
+}
+
+// VagrantfileOptions returns the names of the options which are set in
+// the unfinalized configuration of a Vagrantfile, such as "vm.box".
+// Options which a Vagrantfile leaves unset are not included.
+func VagrantfileOptions(config *vagrant_plugin_sdk.Args_Hash) ([]string, error) {
+	var result []string
+	for _, entry := range config.GetEntries() {
+		namespace, reason, err := hashKey(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			continue
+		}
+
+		value, err := entry.Value.UnmarshalNew()
+		if err != nil {
+			return nil, err
+		}
+		cd, ok := value.(*vagrant_plugin_sdk.Args_ConfigData)
+		if !ok {
+			continue
+		}
+
+		for _, option := range cd.Data.GetEntries() {
+			key, reason, err := hashKey(option.Key)
+			if err != nil {
+				return nil, err
+			}
+			if reason != "" || key == configIdentifierKey {
+				continue
+			}
+
+			value, err := option.Value.UnmarshalNew()
+			if err != nil {
+				return nil, err
+			}
+			if unsetValue(value) {
+				continue
+			}
+
+			result = append(result, namespace+"."+key)
+		}
+	}
+
+	return result, nil
+}
+
+// VagrantfileTargetNames returns the names of the targets defined in the
+// unfinalized configuration of a Vagrantfile. A Vagrantfile which defines
+// no targets has a single target named "default". If the configuration
+// has no vm namespace the targets can't be determined, and false is
+// returned.
+func VagrantfileTargetNames(config *vagrant_plugin_sdk.Args_Hash) ([]string, bool, error) {
+	vm, err := hashValue(config, "vm")
+	if err != nil {
+		return nil, false, err
+	}
+	cd, ok := vm.(*vagrant_plugin_sdk.Args_ConfigData)
+	if !ok {
+		return nil, false, nil
+	}
+
+	defined, err := hashValue(cd.Data, "__defined_vm_keys")
+	if err != nil {
+		return nil, false, err
+	}
+
+	var names []string
+	if list, ok := defined.(*vagrant_plugin_sdk.Args_Array); ok {
+		for _, a := range list.List {
+			name, reason, err := hashKey(a)
+			if err != nil {
+				return nil, false, err
+			}
+			if reason == "" {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		names = []string{"default"}
+	}
+
+	return names, true, nil
+}
+
+// hashValue returns the value stored in the hash under the string or
+// symbol key, or nil if there is no such key.
+func hashValue(h *vagrant_plugin_sdk.Args_Hash, key string) (proto.Message, error) {
+	for _, entry := range h.GetEntries() {
+		k, reason, err := hashKey(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" || k != key {
+			continue
+		}
+
+		return entry.Value.UnmarshalNew()
+	}
+
+	return nil, nil
+}
+
+// ctyValue converts an encoded Ruby value to a cty value. If the value
+// cannot be represented, the reason is returned instead.
+func ctyValue(a *anypb.Any) (cty.Value, string, error) {
+	raw, err := a.UnmarshalNew()
+	if err != nil {
+		return cty.NilVal, "", err
+	}
+
+	switch v := raw.(type) {
+	case *wrapperspb.StringValue:
+		return cty.StringVal(v.Value), "", nil
+	case *wrapperspb.BoolValue:
+		return cty.BoolVal(v.Value), "", nil
+	case *wrapperspb.Int64Value:
+		return cty.NumberIntVal(v.Value), "", nil
+	case *wrapperspb.Int32Value:
+		return cty.NumberIntVal(int64(v.Value)), "", nil
+	case *wrapperspb.UInt64Value:
+		return cty.NumberUIntVal(v.Value), "", nil
+	case *wrapperspb.UInt32Value:
+		return cty.NumberUIntVal(uint64(v.Value)), "", nil
+	case *wrapperspb.FloatValue:
+		return cty.NumberFloatVal(float64(v.Value)), "", nil
+	case *wrapperspb.DoubleValue:
+		return cty.NumberFloatVal(v.Value), "", nil
+	case *vagrant_plugin_sdk.Args_Symbol:
+		return cty.StringVal(v.Str), "", nil
+	case *vagrant_plugin_sdk.Args_Path:
+		return cty.StringVal(v.Path), "", nil
+	case *vagrant_plugin_sdk.Args_Class:
+		return cty.StringVal(v.Name), "", nil
+	case *vagrant_plugin_sdk.Args_Null:
+		return cty.NullVal(cty.DynamicPseudoType), "", nil
+	case *vagrant_plugin_sdk.Args_Array:
+		return ctyTuple(v.List)
+	case *vagrant_plugin_sdk.Args_Set:
+		return ctyTuple(v.List.GetList())
+	case *vagrant_plugin_sdk.Args_Hash:
+		return ctyObject(v)
+	case *vagrant_plugin_sdk.Args_ConfigData:
+		return ctyObject(v.Data)
+	case *vagrant_plugin_sdk.Args_ProcRef:
+		return cty.NilVal, "it is a Ruby proc", nil
+	case *vagrant_plugin_sdk.Config_RawRubyValue:
+		return cty.NilVal, fmt.Sprintf("it is a raw Ruby value of class %s",
+			v.Source.GetName()), nil
+	case *vagrant_plugin_sdk.Args_Range:
+		return cty.NilVal, fmt.Sprintf("it is a Ruby range (%d..%d)",
+			v.Start, v.End), nil
+	default:
+		return cty.NilVal, fmt.Sprintf("values of type %s are not supported",
+			raw.ProtoReflect().Descriptor().FullName()), nil
+	}
+}
+
+func ctyTuple(list []*anypb.Any) (cty.Value, string, error) {
+	if len(list) == 0 {
+		return cty.EmptyTupleVal, "", nil
+	}
+
+	vals := make([]cty.Value, 0, len(list))
+	for _, a := range list {
+		v, reason, err := ctyValue(a)
+		if err != nil || reason != "" {
+			return cty.NilVal, reason, err
+		}
+
+		vals = append(vals, v)
+	}
+
+	return cty.TupleVal(vals), "", nil
+}
+
+func ctyObject(h *vagrant_plugin_sdk.Args_Hash) (cty.Value, string, error) {
+	attrs := map[string]cty.Value{}
+	for _, entry := range h.GetEntries() {
+		key, reason, err := hashKey(entry.Key)
+		if err != nil || reason != "" {
+			return cty.NilVal, reason, err
+		}
+		if key == configIdentifierKey {
+			continue
+		}
+
+		raw, err := entry.Value.UnmarshalNew()
+		if err != nil {
+			return cty.NilVal, "", err
+		}
+		if unsetValue(raw) {
+			continue
+		}
+
+		v, reason, err := ctyValue(entry.Value)
+		if err != nil || reason != "" {
+			return cty.NilVal, reason, err
+		}
+
+		attrs[key] = v
+	}
+
+	return cty.ObjectVal(attrs), "", nil
+}
+
+// hashKey returns the string form of a hash key. Only string and symbol
+// keys can be represented, for anything else the reason is returned.
+func hashKey(a *anypb.Any) (string, string, error) {
+	raw, err := a.UnmarshalNew()
+	if err != nil {
+		return "", "", err
+	}
+
+	switch v := raw.(type) {
+	case *wrapperspb.StringValue:
+		return v.Value, "", nil
+	case *vagrant_plugin_sdk.Args_Symbol:
+		return v.Str, "", nil
+	default:
+		return "", fmt.Sprintf("it has a hash key of type %s",
+			raw.ProtoReflect().Descriptor().FullName()), nil
+	}
+}
+
+// unsetValue returns true if the value is the UNSET_VALUE placeholder that
+// Ruby configuration classes use for options which have not been set. It
+// is a bare Object so it is encoded as a raw value with no data.
+func unsetValue(v interface{}) bool {
+	raw, ok := v.(*vagrant_plugin_sdk.Config_RawRubyValue)
+	return ok && raw.Source.GetName() == "Object" && len(raw.Data.GetEntries()) == 0
+}
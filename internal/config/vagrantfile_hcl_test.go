@@ -0,0 +1,223 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+)
+
+func TestConvertVagrantfile(t *testing.T) {
+	require := require.New(t)
+
+	unset := &vagrant_plugin_sdk.Config_RawRubyValue{
+		Source: &vagrant_plugin_sdk.Args_Class{Name: "Object"},
+		Data:   &vagrant_plugin_sdk.Args_Hash{},
+	}
+
+	vm := TestConfigData(t, "VagrantPlugins::Kernel_V2::VMConfig",
+		"_vagrant_config_identifier", wrapperspb.String("abc"),
+		"box", wrapperspb.String("hashicorp/bionic64"),
+		"box_version", unset,
+		"graceful_halt_timeout", wrapperspb.Int64(60),
+		"networks", &vagrant_plugin_sdk.Args_Array{List: []*anypb.Any{
+			TestAny(t, TestHash(t,
+				"type", &vagrant_plugin_sdk.Args_Symbol{Str: "private_network"},
+				"ip", wrapperspb.String("192.168.33.10"),
+			)),
+		}},
+		"provisioners", &vagrant_plugin_sdk.Args_Array{List: []*anypb.Any{
+			TestAny(t, &vagrant_plugin_sdk.Config_RawRubyValue{
+				Source: &vagrant_plugin_sdk.Args_Class{
+					Name: "VagrantPlugins::Kernel_V2::VagrantConfigProvisioner",
+				},
+				Data: TestHash(t, "name", wrapperspb.String("shell")),
+			}),
+		}},
+		"usable_port_range", &vagrant_plugin_sdk.Args_Range{Start: 2200, End: 2250},
+	)
+
+	ssh := TestConfigData(t, "VagrantPlugins::Kernel_V2::SSHConfig",
+		"forward_agent", wrapperspb.Bool(true),
+		"proxy_command", &vagrant_plugin_sdk.Args_Null{},
+	)
+
+	trigger := TestConfigData(t, "VagrantPlugins::Kernel_V2::TriggerConfig",
+		"ruby", &vagrant_plugin_sdk.Args_ProcRef{Id: "1"},
+	)
+
+	result, skipped, err := ConvertVagrantfile(TestHash(t,
+		"vm", vm,
+		"ssh", ssh,
+		"trigger", trigger,
+	))
+	require.NoError(err)
+	require.Equal(3, skipped)
+	require.Equal(`vm {
+  box                   = "hashicorp/bionic64"
+  graceful_halt_timeout = 60
+  networks = [{
+    ip   = "192.168.33.10"
+    type = "private_network"
+  }]
+  # vagrant: vm.provisioners not converted: it is a raw Ruby value of class VagrantPlugins::Kernel_V2::VagrantConfigProvisioner
+  # vagrant: vm.usable_port_range not converted: it is a Ruby range (2200..2250)
+}
+
+ssh {
+  forward_agent = true
+  proxy_command = null
+}
+
+trigger {
+  # vagrant: trigger.ruby not converted: it is a Ruby proc
+}
+`, string(result))
+
+	// The result must be valid HCL
+	_, diags := hclsyntax.ParseConfig(result, "Vagrantfile.hcl", hcl.InitialPos)
+	require.False(diags.HasErrors(), diags.Error())
+}
+
+func TestConvertVagrantfile_invalidKey(t *testing.T) {
+	require := require.New(t)
+
+	result, skipped, err := ConvertVagrantfile(TestHash(t,
+		"vm", TestConfigData(t, "VagrantPlugins::Kernel_V2::VMConfig",
+			"box", wrapperspb.String("hashicorp/bionic64"),
+			"not valid", wrapperspb.Bool(true),
+		),
+	))
+	require.NoError(err)
+	require.Equal(1, skipped)
+	require.Contains(string(result),
+		"# vagrant: vm.not valid not converted: the name is not a valid HCL identifier")
+}
+
+func TestConvertVagrantfile_annotation(t *testing.T) {
+	require := require.New(t)
+
+	result, _, err := ConvertVagrantfile(TestHash(t,
+		"vm", TestConfigData(t, "VagrantPlugins::Kernel_V2::VMConfig",
+			"box", wrapperspb.String("hashicorp/bionic64"),
+			"hostname", wrapperspb.String("web"),
+		),
+	), WithAnnotation(func(name string) string {
+		if name == "vm.box" {
+			return "set by the project Vagrantfile"
+		}
+		return ""
+	}))
+	require.NoError(err)
+	require.Equal(`vm {
+  # set by the project Vagrantfile
+  box      = "hashicorp/bionic64"
+  hostname = "web"
+}
+`, string(result))
+}
+
+func TestVagrantfileValue(t *testing.T) {
+	require := require.New(t)
+
+	unset := &vagrant_plugin_sdk.Config_RawRubyValue{
+		Source: &vagrant_plugin_sdk.Args_Class{Name: "Object"},
+		Data:   &vagrant_plugin_sdk.Args_Hash{},
+	}
+
+	value, skipped, err := VagrantfileValue(TestHash(t,
+		"vm", TestConfigData(t, "VagrantPlugins::Kernel_V2::VMConfig",
+			"_vagrant_config_identifier", wrapperspb.String("abc"),
+			"box", wrapperspb.String("hashicorp/bionic64"),
+			"box_version", unset,
+			"usable_port_range", &vagrant_plugin_sdk.Args_Range{Start: 2200, End: 2250},
+		),
+		"trigger", TestConfigData(t, "VagrantPlugins::Kernel_V2::TriggerConfig",
+			"ruby", &vagrant_plugin_sdk.Args_ProcRef{Id: "1"},
+		),
+	))
+	require.NoError(err)
+	require.Equal(map[string]string{
+		"vm.usable_port_range": "it is a Ruby range (2200..2250)",
+		"trigger.ruby":         "it is a Ruby proc",
+	}, skipped)
+	require.True(value.Equals(cty.ObjectVal(map[string]cty.Value{
+		"vm": cty.ObjectVal(map[string]cty.Value{
+			"box": cty.StringVal("hashicorp/bionic64"),
+		}),
+		"trigger": cty.EmptyObjectVal,
+	})).True())
+}
+
+func TestVagrantfileOptions(t *testing.T) {
+	require := require.New(t)
+
+	unset := &vagrant_plugin_sdk.Config_RawRubyValue{
+		Source: &vagrant_plugin_sdk.Args_Class{Name: "Object"},
+		Data:   &vagrant_plugin_sdk.Args_Hash{},
+	}
+
+	options, err := VagrantfileOptions(TestHash(t,
+		"vm", TestConfigData(t, "VagrantPlugins::Kernel_V2::VMConfig",
+			"_vagrant_config_identifier", wrapperspb.String("abc"),
+			"box", wrapperspb.String("hashicorp/bionic64"),
+			"box_version", unset,
+		),
+		"ssh", TestConfigData(t, "VagrantPlugins::Kernel_V2::SSHConfig",
+			"forward_agent", wrapperspb.Bool(false),
+		),
+	))
+	require.NoError(err)
+	require.Equal([]string{"vm.box", "ssh.forward_agent"}, options)
+}
+
+func TestParseVagrantfile(t *testing.T) {
+	require := require.New(t)
+
+	result, err := ParseVagrantfile([]byte(`
+vm {
+  box     = "hashicorp/bionic64"
+  timeout = 1.5
+  ports   = [2200, 2250]
+}
+
+ssh {
+  forward_agent = true
+  proxy_command = null
+}
+`), "Vagrantfile.hcl")
+	require.NoError(err)
+
+	expected := TestHash(t,
+		"vm", &vagrant_plugin_sdk.Args_ConfigData{Data: TestHash(t,
+			"box", wrapperspb.String("hashicorp/bionic64"),
+			"timeout", wrapperspb.Double(1.5),
+			"ports", &vagrant_plugin_sdk.Args_Array{List: []*anypb.Any{
+				TestAny(t, wrapperspb.Int64(2200)),
+				TestAny(t, wrapperspb.Int64(2250)),
+			}},
+		)},
+		"ssh", &vagrant_plugin_sdk.Args_ConfigData{Data: TestHash(t,
+			"forward_agent", wrapperspb.Bool(true),
+			"proxy_command", &vagrant_plugin_sdk.Args_Null{},
+		)},
+	)
+	require.True(proto.Equal(expected, result), "%v", result)
+
+	for name, src := range map[string]string{
+		"labels":    `vm "web" {}`,
+		"duplicate": "vm {}\nvm {}\n",
+		"variables": `vm { box = var.box }`,
+		"syntax":    `vm {`,
+	} {
+		_, err := ParseVagrantfile([]byte(src), "Vagrantfile.hcl")
+		require.Error(err, name)
+	}
+}
@@ -3,15 +3,347 @@ package config
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/vagrant/internal/datasource"
 )
 
-// TODO(spox): match back up with waypoint validation implementation
-// once we actually get proper configuration going
+// Validate the structure of the configuration.
+//
+// If there are any errors, the returned error is an hcl.Diagnostics
+// with the source range of each problem. This should be called after
+// Load and will validate everything that can be checked without
+// contacting the server: labels, runner data sources, hooks, and the
+// provider priority.
 func (c *Config) Validate() error {
+	var diags hcl.Diagnostics
+
+	// Without the parsed body we have no source ranges, so validate
+	// what we can against empty ranges.
+	body := c.body
+	if body == nil {
+		body = hcl.EmptyBody()
+	}
+
+	content, _, _ := body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "labels"},
+		},
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "runner"},
+			{Type: "hook", LabelNames: []string{"task"}},
+			{Type: "provider"},
+		},
+	})
+
+	diags = append(diags, c.validateLabels(content.Attributes["labels"])...)
+
+	var runnerBlock, providerBlock *hcl.Block
+	var hookBlocks []*hcl.Block
+	for _, b := range content.Blocks {
+		switch b.Type {
+		case "runner":
+			runnerBlock = b
+		case "hook":
+			hookBlocks = append(hookBlocks, b)
+		case "provider":
+			providerBlock = b
+		}
+	}
+
+	diags = append(diags, c.validateRunner(runnerBlock)...)
+
+	for i, h := range c.Hooks {
+		var b *hcl.Block
+		if i < len(hookBlocks) {
+			b = hookBlocks[i]
+		}
+
+		diags = append(diags, validateHook(h, b)...)
+	}
+
+	diags = append(diags, c.validateProvider(providerBlock)...)
+
+	if diags.HasErrors() {
+		return diags
+	}
+
 	return nil
 }
 
+// validateLabels validates the labels set on the configuration. If the
+// labels attribute is a literal map then each error is reported against
+// the offending item, otherwise against the whole attribute.
+func (c *Config) validateLabels(attr *hcl.Attribute) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	if len(c.Labels) == 0 {
+		return diags
+	}
+
+	// Determine the range to report for each label key
+	var rng *hcl.Range
+	ranges := map[string]*hcl.Range{}
+	if attr != nil {
+		rng = attr.Range.Ptr()
+		if pairs, d := hcl.ExprMap(attr.Expr); !d.HasErrors() {
+			for _, pair := range pairs {
+				v, d := pair.Key.Value(c.ctx)
+				if d.HasErrors() || !v.IsKnown() || v.IsNull() || !v.Type().Equals(cty.String) {
+					continue
+				}
+
+				ranges[v.AsString()] = hcl.RangeBetween(
+					pair.Key.Range(), pair.Value.Range()).Ptr()
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(c.Labels))
+	for k := range c.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		subject := rng
+		if r, ok := ranges[k]; ok {
+			subject = r
+		}
+
+		for _, err := range ValidateLabels(map[string]string{k: c.Labels[k]}) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid label",
+				Detail:   err.Error(),
+				Subject:  subject,
+			})
+		}
+	}
+
+	return diags
+}
+
+// validateRunner validates the runner configuration. The data source
+// type must be known and its body must decode for that type.
+func (c *Config) validateRunner(b *hcl.Block) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	if c.Runner == nil || c.Runner.DataSource == nil {
+		return diags
+	}
+	ds := c.Runner.DataSource
+
+	// Find the ranges for the data source block
+	var defRange, typeRange *hcl.Range
+	if b != nil {
+		defRange = b.DefRange.Ptr()
+		content, _, _ := b.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{Type: "data_source", LabelNames: []string{"type"}},
+			},
+		})
+		for _, dsBlock := range content.Blocks {
+			defRange = dsBlock.DefRange.Ptr()
+			typeRange = dsBlock.LabelRanges[0].Ptr()
+		}
+	}
+	if typeRange == nil {
+		typeRange = defRange
+	}
+
+	factory, ok := datasource.FromString[ds.Type]
+	if !ok {
+		types := make([]string, 0, len(datasource.FromString))
+		for k := range datasource.FromString {
+			types = append(types, fmt.Sprintf("%q", k))
+		}
+		sort.Strings(types)
+
+		return append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unknown data source type",
+			Detail: fmt.Sprintf(
+				"The data source type %q is not supported. Valid types are: %s.",
+				ds.Type, strings.Join(types, ", ")),
+			Subject: typeRange,
+		})
+	}
+
+	body := ds.Body
+	if body == nil {
+		body = hcl.EmptyBody()
+	}
+	if _, err := factory().ProjectSource(body, c.ctx); err != nil {
+		if d, ok := err.(hcl.Diagnostics); ok {
+			return append(diags, d...)
+		}
+
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid data source configuration",
+			Detail: fmt.Sprintf(
+				"The %q data source configuration is invalid: %s", ds.Type, err),
+			Subject: defRange,
+		})
+	}
+
+	return diags
+}
+
+// validateHook validates a single hook. The block is optional and is
+// only used to determine the source ranges of any errors.
+func validateHook(h *Hook, b *hcl.Block) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	var defRange, whenRange, commandRange, onFailureRange *hcl.Range
+	if b != nil {
+		defRange = b.DefRange.Ptr()
+		whenRange, commandRange, onFailureRange = defRange, defRange, defRange
+
+		attrs, _ := b.Body.JustAttributes()
+		if attr, ok := attrs["when"]; ok {
+			whenRange = attr.Expr.Range().Ptr()
+		}
+		if attr, ok := attrs["command"]; ok {
+			commandRange = attr.Expr.Range().Ptr()
+		}
+		if attr, ok := attrs["on_failure"]; ok {
+			onFailureRange = attr.Expr.Range().Ptr()
+		}
+	}
+
+	if h.Task == "" {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid hook task",
+			Detail:   "A hook must be labeled with the name of the task it runs for.",
+			Subject:  defRange,
+		})
+	}
+
+	switch h.When {
+	case HookBefore, HookAfter:
+	default:
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid hook \"when\" value",
+			Detail: fmt.Sprintf(
+				"The value %q is not valid. Hooks must run %q or %q the task.",
+				h.When, HookBefore, HookAfter),
+			Subject: whenRange,
+		})
+	}
+
+	if len(h.Command) == 0 || h.Command[0] == "" {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid hook command",
+			Detail:   "A hook command must contain at least the executable to run.",
+			Subject:  commandRange,
+		})
+	}
+
+	switch h.OnFailure {
+	case "", HookOnFailureFail, HookOnFailureContinue:
+	default:
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid hook \"on_failure\" value",
+			Detail: fmt.Sprintf(
+				"The value %q is not valid. Valid values are %q and %q.",
+				h.OnFailure, HookOnFailureFail, HookOnFailureContinue),
+			Subject: onFailureRange,
+		})
+	}
+
+	return diags
+}
+
+// validateProvider validates the provider priority and its overrides.
+// The block is optional and is only used to determine the source ranges
+// of any errors.
+func (c *Config) validateProvider(b *hcl.Block) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	if c.Provider == nil {
+		return diags
+	}
+
+	var priorityRange *hcl.Range
+	var overrideRanges []*hcl.Range
+	if b != nil {
+		priorityRange = b.DefRange.Ptr()
+		content, _, _ := b.Body.PartialContent(&hcl.BodySchema{
+			Attributes: []hcl.AttributeSchema{
+				{Name: "priority"},
+			},
+			Blocks: []hcl.BlockHeaderSchema{
+				{Type: "override"},
+			},
+		})
+		if attr, ok := content.Attributes["priority"]; ok {
+			priorityRange = attr.Expr.Range().Ptr()
+		}
+		for _, o := range content.Blocks {
+			overrideRanges = append(overrideRanges, o.DefRange.Ptr())
+		}
+	}
+
+	diags = append(diags, validateProviderPriority(c.Provider.Priority, priorityRange)...)
+
+	for i, o := range c.Provider.Overrides {
+		rng := priorityRange
+		if i < len(overrideRanges) {
+			rng = overrideRanges[i]
+		}
+
+		if len(o.Labels) == 0 {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid provider override",
+				Detail:   "A provider override must set the labels of the projects it applies to.",
+				Subject:  rng,
+			})
+		}
+
+		diags = append(diags, validateProviderPriority(o.Priority, rng)...)
+	}
+
+	return diags
+}
+
+// validateProviderPriority validates that a provider priority list only
+// names each provider once.
+func validateProviderPriority(priority []string, rng *hcl.Range) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	seen := map[string]struct{}{}
+	for _, name := range priority {
+		if name == "" {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid provider priority",
+				Detail:   "Provider names in a priority list can't be empty.",
+				Subject:  rng,
+			})
+			continue
+		}
+
+		if _, ok := seen[name]; ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid provider priority",
+				Detail:   fmt.Sprintf("The provider %q is listed more than once.", name),
+				Subject:  rng,
+			})
+		}
+		seen[name] = struct{}{}
+	}
+
+	return diags
+}
+
 // ValidateLabels validates a set of labels. This ensures that labels are
 // set according to our requirements:
 //
@@ -1,44 +1,187 @@
 package config
 
-// TODO: renable these tests when vagrantfile's can be parsed in hcl
-// import (
-// 	"path/filepath"
-// 	"testing"
-
-// 	"github.com/stretchr/testify/require"
-// )
-//
-// func TestConfigValidate(t *testing.T) {
-// 	cases := []struct {
-// 		File string
-// 		Err  string
-// 	}{
-// 		{
-// 			"valid.hcl",
-// 			"",
-// 		},
-
-// 		{
-// 			"no_build.hcl",
-// 			"'build' stanza",
-// 		},
-// 	}
-
-// 	for _, tt := range cases {
-// 		t.Run(tt.File, func(t *testing.T) {
-// 			require := require.New(t)
-
-// 			cfg, err := Load(filepath.Join("testdata", "validate", tt.File), "")
-// 			require.NoError(err)
-
-// 			err = cfg.Validate()
-// 			if tt.Err == "" {
-// 				require.NoError(err)
-// 				return
-// 			}
-
-// 			require.Error(err)
-// 			require.Contains(err.Error(), tt.Err)
-// 		})
-// 	}
-// }
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cases := []struct {
+		Name string
+		Src  string
+		Err  string
+		Line int
+	}{
+		{
+			"empty",
+			``,
+			"",
+			0,
+		},
+
+		{
+			"valid",
+			`
+labels = {
+  "env" = "test"
+}
+
+runner {
+  enabled = true
+
+  data_source "git" {
+    url = "https://example.com/repo.git"
+  }
+}
+
+hook "up" {
+  when    = "before"
+  command = ["echo", "hello"]
+}
+`,
+			"",
+			0,
+		},
+
+		{
+			"reserved label",
+			`
+labels = {
+  "env"          = "test"
+  "waypoint/foo" = "bar"
+}
+`,
+			"reserved for system use",
+			4,
+		},
+
+		{
+			"unknown data source",
+			`
+runner {
+  data_source "svn" {}
+}
+`,
+			"Unknown data source type",
+			3,
+		},
+
+		{
+			"invalid data source body",
+			`
+runner {
+  data_source "git" {
+    path = "foo"
+  }
+}
+`,
+			"url",
+			3,
+		},
+
+		{
+			"invalid hook when",
+			`
+hook "up" {
+  when    = "during"
+  command = ["echo"]
+}
+`,
+			"when",
+			3,
+		},
+
+		{
+			"invalid hook on_failure",
+			`
+hook "up" {
+  when       = "after"
+  command    = ["echo"]
+  on_failure = "retry"
+}
+`,
+			"on_failure",
+			5,
+		},
+
+		{
+			"empty hook command",
+			`
+hook "up" {
+  when    = "after"
+  command = []
+}
+`,
+			"command",
+			4,
+		},
+
+		{
+			"valid provider priority",
+			`
+provider {
+  priority = ["vmware_desktop", "virtualbox"]
+
+  override {
+    labels   = { "env" = "ci" }
+    priority = ["docker"]
+  }
+}
+`,
+			"",
+			0,
+		},
+
+		{
+			"duplicate provider priority",
+			`
+provider {
+  priority = [
+    "virtualbox",
+    "virtualbox",
+  ]
+}
+`,
+			"listed more than once",
+			3,
+		},
+
+		{
+			"provider override without labels",
+			`
+provider {
+  override {
+    labels   = {}
+    priority = ["docker"]
+  }
+}
+`,
+			"must set the labels",
+			3,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			cfg := TestConfig(t, tt.Src)
+			err := cfg.Validate()
+			if tt.Err == "" {
+				require.NoError(err)
+				return
+			}
+
+			require.Error(err)
+			require.Contains(err.Error(), tt.Err)
+
+			diags, ok := err.(hcl.Diagnostics)
+			require.True(ok)
+			require.Len(diags, 1)
+			require.NotNil(diags[0].Subject)
+			require.Equal(tt.Line, diags[0].Subject.Start.Line)
+		})
+	}
+}
@@ -691,7 +691,24 @@ func (b *Basis) Components(ctx context.Context) ([]*Component, error) {
 
 // Runs a specific task via component which matches the task's
 // component name. This is the entry point for running commands.
-func (b *Basis) Run(ctx context.Context, task *vagrant_server.Task) (err error) {
+func (b *Basis) Run(ctx context.Context, task *vagrant_server.Task) error {
+	hooks, err := loadTaskHooks(b.basis.Path, taskName(task))
+	if err != nil {
+		b.logger.Error("failed to load task hooks",
+			"task", taskName(task),
+			"error", err,
+		)
+
+		return err
+	}
+
+	return runTask(ctx, b, b.logger, hooks, func() error {
+		return b.run(ctx, task)
+	})
+}
+
+// run executes the command for the task without any hooks.
+func (b *Basis) run(ctx context.Context, task *vagrant_server.Task) (err error) {
 	b.logger.Debug("running new task",
 		"task", task)
 
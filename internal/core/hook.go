@@ -2,11 +2,19 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 
 	"github.com/hashicorp/go-hclog"
 
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/path"
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
 	"github.com/hashicorp/vagrant/internal/config"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
 )
 
 // execHook executes the given hook. This will return any errors. This ignores
@@ -29,6 +37,7 @@ func execHook(ctx context.Context, s scope, log hclog.Logger, h *config.Hook) (e
 	cmd := exec.CommandContext(ctx, h.Command[0], h.Command[1:]...)
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
+	cmd.Env = append(os.Environ(), hookEnv(s, h)...)
 
 	// Start
 	if err := cmd.Start(); err != nil {
@@ -51,3 +60,154 @@ func execHook(ctx context.Context, s scope, log hclog.Logger, h *config.Hook) (e
 
 	return nil
 }
+
+// hookEnv returns the environment variables describing the scope
+// and task for a hook.
+func hookEnv(s scope, h *config.Hook) []string {
+	env := map[string]string{
+		"VAGRANT_TASK":      h.Task,
+		"VAGRANT_HOOK_WHEN": h.When,
+	}
+
+	switch ref := s.Ref().(type) {
+	case *vagrant_plugin_sdk.Ref_Basis:
+		env["VAGRANT_BASIS_NAME"] = ref.GetName()
+	case *vagrant_plugin_sdk.Ref_Project:
+		env["VAGRANT_BASIS_NAME"] = ref.GetBasis().GetName()
+		env["VAGRANT_PROJECT_NAME"] = ref.GetName()
+	case *vagrant_plugin_sdk.Ref_Target:
+		env["VAGRANT_BASIS_NAME"] = ref.GetProject().GetBasis().GetName()
+		env["VAGRANT_PROJECT_NAME"] = ref.GetProject().GetName()
+		env["VAGRANT_TARGET_NAME"] = ref.GetName()
+		env["VAGRANT_TARGET_ID"] = ref.GetResourceId()
+	}
+
+	result := make([]string, 0, len(env))
+	for k, v := range env {
+		result = append(result, k+"="+v)
+	}
+
+	return result
+}
+
+// taskName returns the name hooks are matched against for a task.
+// This is the full command name, for example "box add".
+func taskName(task *vagrant_server.Task) string {
+	if task.CommandName != "" {
+		return task.CommandName
+	}
+
+	return task.GetComponent().GetName()
+}
+
+// loadTaskHooks loads the hooks for the named task from the Vagrant
+// configuration file found at or above dir. The hooks are returned
+// keyed by when they run. If no configuration file is found, no hooks
+// are returned.
+func loadTaskHooks(dir, task string) (map[string][]*config.Hook, error) {
+	hooks := map[string][]*config.Hook{}
+	if dir == "" || task == "" {
+		return hooks, nil
+	}
+
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return hooks, nil
+	}
+
+	for _, h := range cfg.Hooks {
+		if h.Task == task {
+			hooks[h.When] = append(hooks[h.When], h)
+		}
+	}
+
+	return hooks, nil
+}
+
+// loadConfig loads and validates the Vagrant configuration file found at
+// or above dir. If no configuration file is found, nil is returned.
+func loadConfig(dir string) (*config.Config, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	p, err := config.FindPath(path.NewPath(dir), config.ConfigFilename)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, nil
+	}
+
+	cfg, err := config.Load(p.String(), filepath.Dir(p.String()))
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// runTask runs the given function wrapped by the before and after hooks.
+// After hooks are only run if the function succeeds. A failed hook will
+// stop the task unless it is configured to continue on failure.
+func runTask(
+	ctx context.Context,
+	s scope,
+	log hclog.Logger,
+	hooks map[string][]*config.Hook,
+	f func() error,
+) error {
+	for i, h := range hooks[config.HookBefore] {
+		if err := runHook(ctx, s, log.Named(fmt.Sprintf("hook-before-%d", i)), h); err != nil {
+			return &runError{
+				err:      fmt.Errorf("Error running before hook index %d: %w", i, err),
+				exitCode: 1,
+			}
+		}
+	}
+
+	if err := f(); err != nil {
+		return err
+	}
+
+	for i, h := range hooks[config.HookAfter] {
+		if err := runHook(ctx, s, log.Named(fmt.Sprintf("hook-after-%d", i)), h); err != nil {
+			return &runError{
+				err:      fmt.Errorf("Error running after hook index %d: %w", i, err),
+				exitCode: 1,
+			}
+		}
+	}
+
+	return nil
+}
+
+// runHook executes a single hook, reporting progress to the scope UI.
+// Errors are ignored if the hook is configured to continue on failure.
+func runHook(ctx context.Context, s scope, log hclog.Logger, h *config.Hook) error {
+	ui, err := s.UI()
+	if err != nil {
+		return err
+	}
+
+	ui.Output("Running %s hook for %q: %s", h.When, h.Task,
+		strings.Join(h.Command, " "), terminal.WithHeaderStyle())
+
+	if err := s.execHook(ctx, log, h); err != nil {
+		if !h.ContinueOnFailure() {
+			ui.Output("Hook failed: %s", err, terminal.WithErrorStyle())
+			return err
+		}
+
+		log.Info("hook configured to continue on failure, ignoring error", "err", err)
+		ui.Output("Hook failed, continuing: %s", err, terminal.WithWarningStyle())
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/config"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// hookTestUI captures the output of hooks run through the UI.
+type hookTestUI struct {
+	terminal.UI
+
+	buf bytes.Buffer
+}
+
+func (u *hookTestUI) Output(msg string, raw ...interface{}) {
+	msg, _, _, _, _ = terminal.Interpret(msg, raw...)
+	u.buf.WriteString(msg + "\n")
+}
+
+func (u *hookTestUI) OutputWriters() (io.Writer, io.Writer, error) {
+	return &u.buf, &u.buf, nil
+}
+
+func TestTaskHooks(t *testing.T) {
+	const src = `
+hook "up" {
+  when    = "before"
+  command = ["sh", "-c", "echo before $VAGRANT_TASK $VAGRANT_HOOK_WHEN $VAGRANT_PROJECT_NAME"]
+}
+
+hook "up" {
+  when       = "before"
+  command    = ["sh", "-c", "exit 1"]
+  on_failure = "continue"
+}
+
+hook "up" {
+  when    = "after"
+  command = ["sh", "-c", "echo after $VAGRANT_TASK"]
+}
+
+hook "destroy" {
+  when    = "before"
+  command = ["sh", "-c", "exit 2"]
+}
+
+hook "destroy" {
+  when    = "after"
+  command = ["sh", "-c", "echo after destroy"]
+}
+
+hook "halt" {
+  when    = "after"
+  command = ["sh", "-c", "exit 3"]
+}
+`
+
+	td, err := ioutil.TempDir("", "vagrant-core")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(td) })
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(td, config.ConfigFilename), []byte(src), 0644))
+
+	newProject := func(t *testing.T) (*Project, *hookTestUI) {
+		p := TestMinimalProject(t)
+		p.project.Path = td
+
+		ui := &hookTestUI{UI: terminal.NonInteractiveUI(context.Background())}
+		p.ui = ui
+
+		return p, ui
+	}
+
+	run := func(t *testing.T, p *Project, task string, f func() error) error {
+		hooks, err := loadTaskHooks(p.project.Path, taskName(&vagrant_server.Task{
+			CommandName: task,
+		}))
+		require.NoError(t, err)
+
+		return runTask(context.Background(), p, hclog.L(), hooks, f)
+	}
+
+	t.Run("runs hooks around the task", func(t *testing.T) {
+		require := require.New(t)
+		p, ui := newProject(t)
+
+		called := false
+		err := run(t, p, "up", func() error {
+			called = true
+			ui.buf.WriteString("task\n")
+			return nil
+		})
+		require.NoError(err)
+		require.True(called)
+
+		out := ui.buf.String()
+		require.Contains(out, "before up before test-project\n")
+		require.Contains(out, "Hook failed, continuing")
+		require.Contains(out, "after up\n")
+
+		// Hooks run in order around the task
+		require.Less(bytes.Index(ui.buf.Bytes(), []byte("before up")),
+			bytes.Index(ui.buf.Bytes(), []byte("task\n")))
+		require.Less(bytes.Index(ui.buf.Bytes(), []byte("task\n")),
+			bytes.Index(ui.buf.Bytes(), []byte("after up")))
+	})
+
+	t.Run("failed before hook stops the task", func(t *testing.T) {
+		require := require.New(t)
+		p, ui := newProject(t)
+
+		called := false
+		err := run(t, p, "destroy", func() error {
+			called = true
+			return nil
+		})
+		require.Error(err)
+		require.False(called)
+		require.Contains(err.Error(), "before hook")
+		require.NotContains(ui.buf.String(), "after destroy")
+
+		var cmdErr CommandError
+		require.True(errors.As(err, &cmdErr))
+		require.Equal(int32(1), cmdErr.ExitCode())
+	})
+
+	t.Run("failed task skips after hooks", func(t *testing.T) {
+		require := require.New(t)
+		p, ui := newProject(t)
+
+		err := run(t, p, "up", func() error {
+			return errors.New("task failed")
+		})
+		require.Error(err)
+		require.Equal("task failed", err.Error())
+		require.NotContains(ui.buf.String(), "after up")
+	})
+
+	t.Run("failed after hook fails the task", func(t *testing.T) {
+		require := require.New(t)
+		p, _ := newProject(t)
+
+		err := run(t, p, "halt", func() error { return nil })
+		require.Error(err)
+		require.Contains(err.Error(), "after hook")
+	})
+
+	t.Run("no hooks for task", func(t *testing.T) {
+		require := require.New(t)
+		p, ui := newProject(t)
+
+		called := false
+		err := run(t, p, "status", func() error {
+			called = true
+			return nil
+		})
+		require.NoError(err)
+		require.True(called)
+		require.Empty(ui.buf.String())
+	})
+}
+
+func TestLoadTaskHooks_invalid(t *testing.T) {
+	require := require.New(t)
+
+	td, err := ioutil.TempDir("", "vagrant-core")
+	require.NoError(err)
+	defer os.RemoveAll(td)
+	require.NoError(ioutil.WriteFile(filepath.Join(td, config.ConfigFilename), []byte(`
+hook "up" {
+  when    = "sometimes"
+  command = ["true"]
+}
+`), 0644))
+
+	_, err = loadTaskHooks(td, "up")
+	require.Error(err)
+	require.Contains(err.Error(), "when")
+}
@@ -289,8 +289,43 @@ func (p *Project) VagrantfileName() (name string, err error) {
 	return fullPath.Base().String(), nil
 }
 
+// ProviderCandidate is a provider plugin considered when choosing the
+// default provider, and why it was or was not chosen.
+type ProviderCandidate struct {
+	Name        string
+	Priority    int
+	Defaultable bool
+	Installed   bool
+	Usable      bool
+	Chosen      bool
+	Reason      string
+}
+
+// ProviderSelection is the result of choosing the default provider.
+type ProviderSelection struct {
+	// Provider chosen by default, empty if no provider could be chosen
+	Provider string
+	// Candidates are the provider plugins in the order they were
+	// considered
+	Candidates []*ProviderCandidate
+}
+
 // DefaultProvider implements core.Project
 func (p *Project) DefaultProvider(opts *core.DefaultProviderOptions) (string, error) {
+	sel, err := p.SelectProvider(opts)
+	if err != nil {
+		return "", err
+	}
+	if sel.Provider == "" {
+		return "", errors.New("No default provider.")
+	}
+
+	return sel.Provider, nil
+}
+
+// SelectProvider chooses the default provider, recording why each
+// provider plugin was or was not chosen.
+func (p *Project) SelectProvider(opts *core.DefaultProviderOptions) (*ProviderSelection, error) {
 	logger := p.logger.Named("default-provider")
 	logger.Debug("Searching for default provider", "options", fmt.Sprintf("%#v", opts))
 	// Algorithm ported from Vagrant::Environment#default_provider; structure
@@ -310,23 +345,25 @@ func (p *Project) DefaultProvider(opts *core.DefaultProviderOptions) (string, er
 	//
 	// 2. If the VAGRANT_DEFAULT_PROVIDER environmental variable is set, it
 	//    takes next priority and will be the provider chosen.
+	sel := &ProviderSelection{}
 	defaultProvider := os.Getenv("VAGRANT_DEFAULT_PROVIDER")
 	if defaultProvider != "" && opts.ForceDefault {
 		logger.Debug("Using forced default provider", "provider", defaultProvider)
-		return defaultProvider, nil
+		sel.Provider = defaultProvider
+		return sel, nil
 	}
 
 	// Get the list of providers in our configuration, in order
 	configProviders := []string{}
 	targets, err := p.vagrantfile.TargetNames()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	for _, n := range targets {
 		target, err := p.Target(n, "")
 		if err != nil {
-			return "", nil
+			return nil, fmt.Errorf("failed to load target %s: %w", n, err)
 		}
 		if target.(*Target).target.Provider != "" {
 			configProviders = append(configProviders, target.(*Target).target.Provider)
@@ -339,114 +376,147 @@ func (p *Project) DefaultProvider(opts *core.DefaultProviderOptions) (string, er
 			}
 			providers, ok := pRaw.([]interface{})
 			if !ok {
-				return "", fmt.Errorf("unexpected type for target provider list (%T)", pRaw)
+				return nil, fmt.Errorf("unexpected type for target provider list (%T)", pRaw)
 			}
 			for _, pint := range providers {
 				pstring, err := optionToString(pint)
 				if err != nil {
-					return "", fmt.Errorf("unexpected type for target provider (%T)", pint)
+					return nil, fmt.Errorf("unexpected type for target provider (%T)", pint)
 				}
 				configProviders = append(configProviders, pstring)
 			}
 		}
 	}
+	inConfig := func(name string) bool {
+		for _, cp := range configProviders {
+			if cp == name {
+				return true
+			}
+		}
+		return false
+	}
 
-	usableProviders := []*core.NamedPlugin{}
+	usableProviders := []*ProviderCandidate{}
 	pluginProviders, err := p.basis.plugins.ListPlugins("provider")
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	for _, pp := range pluginProviders {
 		logger.Debug("considering plugin", "provider", pp.Name)
 
+		candidate := &ProviderCandidate{Name: pp.Name}
+		sel.Candidates = append(sel.Candidates, candidate)
+
 		// Skip excluded providers
 		if opts.IsExcluded(pp.Name) {
 			logger.Debug("skipping excluded provider", "provider", pp.Name)
+			candidate.Reason = "excluded"
 			continue
 		}
 
 		plug, err := p.basis.plugins.GetPlugin(pp.Name, pp.Type)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		plugOpts := plug.Options.(*component.ProviderOptions)
 		logger.Debug("got provider options", "options", fmt.Sprintf("%#v", plugOpts))
+		candidate.Priority = plugOpts.Priority
+		candidate.Defaultable = plugOpts.Defaultable
 
 		// Skip providers that can't be defaulted, unless they're in our
 		// config, in which case someone made our decision for us.
-		if !plugOpts.Defaultable {
-			inConfig := false
-			for _, cp := range configProviders {
-				if cp == pp.Name {
-					inConfig = true
-				}
-			}
-			if !inConfig {
-				logger.Debug("skipping non-defaultable provider", "provider", pp.Name)
-				continue
-			}
+		if !plugOpts.Defaultable && !inConfig(pp.Name) {
+			logger.Debug("skipping non-defaultable provider", "provider", pp.Name)
+			candidate.Reason = "can't be used by default and isn't configured in the Vagrantfile"
+			continue
 		}
 
-		// Skip the providers that aren't usable.
+		// Skip the providers that aren't installed or usable. A provider
+		// which fails the check is skipped rather than failing the
+		// search, since another provider may still be usable.
 		if opts.CheckUsable {
 			logger.Debug("Checking usable on provider", "provider", pp.Name)
 			pluginImpl := plug.Plugin.(core.Provider)
-			usable, err := pluginImpl.Usable()
-			if !usable {
-				logger.Debug("Skipping unusable provider", "provider", pp.Name)
+			candidate.Installed, err = pluginImpl.Installed()
+			if err != nil {
+				logger.Warn("failed to check if provider is installed",
+					"provider", pp.Name, "error", err)
+				candidate.Reason = fmt.Sprintf("failed to check if installed: %s", err)
+				continue
+			}
+			if !candidate.Installed {
+				logger.Debug("Skipping provider which is not installed", "provider", pp.Name)
+				candidate.Reason = "not installed"
 				continue
 			}
+
+			candidate.Usable, err = pluginImpl.Usable()
 			if err != nil {
-				return "", err
+				logger.Warn("failed to check if provider is usable",
+					"provider", pp.Name, "error", err)
+				candidate.Reason = fmt.Sprintf("not usable: %s", err)
+				continue
+			}
+			if !candidate.Usable {
+				logger.Debug("Skipping unusable provider", "provider", pp.Name)
+				candidate.Reason = "not usable on this host"
+				continue
 			}
 		}
 
 		// If we made it here we have a candidate usable provider
-		usableProviders = append(usableProviders, plug)
+		usableProviders = append(usableProviders, candidate)
 	}
 	logger.Debug("Initial usable provider list", "usableProviders", usableProviders)
 
 	// Sort by plugin priority, higher is first
 	sort.SliceStable(usableProviders, func(i, j int) bool {
-		iPriority := usableProviders[i].Options.(*component.ProviderOptions).Priority
-		jPriority := usableProviders[j].Options.(*component.ProviderOptions).Priority
-		return iPriority > jPriority
+		return usableProviders[i].Priority > usableProviders[j].Priority
 	})
 	logger.Debug("Priority sorted usable provider list", "usableProviders", usableProviders)
 
+	choose := func(c *ProviderCandidate, reason string) (*ProviderSelection, error) {
+		c.Chosen = true
+		c.Reason = reason
+		sel.Provider = c.Name
+		for _, u := range usableProviders {
+			if u != c {
+				u.Reason = fmt.Sprintf("usable, but %s was chosen first", c.Name)
+			}
+		}
+
+		return sel, nil
+	}
+
 	// If we're not forcing the default, but it's usable and hasn't been
 	// otherwise excluded, return it now.
 	for _, u := range usableProviders {
 		if u.Name == defaultProvider {
 			logger.Debug("Using default provider as it was found in usable list",
-				"provider", u)
-			return u.Name, nil
+				"provider", u.Name)
+			return choose(u, "set by VAGRANT_DEFAULT_PROVIDER")
 		}
 	}
 
 	// 2.5. Vagrant will go through all of the config.vm.provider calls in the
 	//      Vagrantfile and try each in order. It will choose the first
-	//      provider that is usable and listed in VAGRANT_PREFERRED_PROVIDERS.
-	preferredProviders := strings.Split(os.Getenv("VAGRANT_PREFERRED_PROVIDERS"), ",")
-	k := 0
-	for _, pp := range preferredProviders {
-		spp := strings.TrimSpace(pp) // .map { s.strip }
-		if spp != "" {               // .select { !s.empty? }
-			preferredProviders[k] = spp
-			k++
-		}
+	//      provider that is usable and listed in VAGRANT_PREFERRED_PROVIDERS,
+	//      or in the provider priority of the Vagrant configuration file.
+	preferredProviders, err := p.preferredProviders()
+	if err != nil {
+		return nil, err
 	}
-	preferredProviders = preferredProviders[:k]
 
 	for _, cp := range configProviders {
 		for _, up := range usableProviders {
 			if cp == up.Name {
 				for _, pp := range preferredProviders {
-					if cp == pp {
+					if cp == pp.name {
 						logger.Debug("Using preferred provider detected in configuration and usable",
-							"provider", pp)
-						return pp, nil
+							"provider", pp.name)
+						return choose(up, fmt.Sprintf(
+							"configured in the Vagrantfile and preferred by %s", pp.source))
 					}
 				}
 			}
@@ -462,19 +532,20 @@ func (p *Project) DefaultProvider(opts *core.DefaultProviderOptions) (string, er
 			if cp == up.Name {
 				logger.Debug("Using provider detected in configuration and usable",
 					"provider", cp)
-				return cp, nil
+				return choose(up, "configured in the Vagrantfile")
 			}
 		}
 	}
 
-	// 3.5. Vagrant will go through VAGRANT_PREFERRED_PROVIDERS and find the
-	//      first plugin that reports it is usable.
+	// 3.5. Vagrant will go through VAGRANT_PREFERRED_PROVIDERS, then the
+	//      provider priority of the Vagrant configuration file, and find
+	//      the first plugin that reports it is usable.
 	for _, pp := range preferredProviders {
 		for _, up := range usableProviders {
-			if pp == up.Name {
+			if pp.name == up.Name {
 				logger.Debug("Using preffered provider found in usable list",
-					"provider", pp)
-				return pp, nil
+					"provider", pp.name)
+				return choose(up, fmt.Sprintf("preferred by %s", pp.source))
 			}
 		}
 	}
@@ -487,11 +558,53 @@ func (p *Project) DefaultProvider(opts *core.DefaultProviderOptions) (string, er
 	//    take priority over VirtualBox.
 	if len(usableProviders) > 0 {
 		logger.Debug("Using the first provider from the usable list",
-			"provider", usableProviders[0])
-		return usableProviders[0].Name, nil
+			"provider", usableProviders[0].Name)
+		return choose(usableProviders[0], "highest priority usable provider")
+	}
+
+	return sel, nil
+}
+
+// preferredProvider is a provider named in a provider priority list, and
+// where the list came from.
+type preferredProvider struct {
+	name   string
+	source string
+}
+
+// preferredProviders returns the providers listed in the
+// VAGRANT_PREFERRED_PROVIDERS environment variable followed by those in
+// the provider priority of the Vagrant configuration file. The priority
+// used from the configuration file depends on the project labels.
+func (p *Project) preferredProviders() ([]*preferredProvider, error) {
+	var result []*preferredProvider
+	seen := map[string]struct{}{}
+	add := func(name, source string) {
+		if _, ok := seen[name]; ok {
+			return
+		}
+		seen[name] = struct{}{}
+		result = append(result, &preferredProvider{name: name, source: source})
+	}
+
+	for _, pp := range strings.Split(os.Getenv("VAGRANT_PREFERRED_PROVIDERS"), ",") {
+		spp := strings.TrimSpace(pp) // .map { s.strip }
+		if spp != "" {               // .select { !s.empty? }
+			add(spp, "VAGRANT_PREFERRED_PROVIDERS")
+		}
 	}
 
-	return "", errors.New("No default provider.")
+	cfg, err := loadConfig(p.project.Path)
+	if err != nil {
+		return nil, err
+	}
+	if cfg != nil {
+		for _, name := range cfg.Provider.PriorityFor(cfg.Labels) {
+			add(name, config.ConfigFilename)
+		}
+	}
+
+	return result, nil
 }
 
 // VagrantfilePath implements core.Project
@@ -614,7 +727,31 @@ func (p *Project) Ref() interface{} {
 	}
 }
 
-func (p *Project) Run(ctx context.Context, task *vagrant_server.Task) (err error) {
+func (p *Project) Run(ctx context.Context, task *vagrant_server.Task) error {
+	// Tasks for multiple targets are run against each target, which
+	// runs the hooks for the task itself
+	if len(task.Targets) > 0 {
+		_, err := p.RunTargets(ctx, task)
+		return err
+	}
+
+	hooks, err := loadTaskHooks(p.project.Path, taskName(task))
+	if err != nil {
+		p.logger.Error("failed to load task hooks",
+			"task", taskName(task),
+			"error", err,
+		)
+
+		return err
+	}
+
+	return runTask(ctx, p, p.logger, hooks, func() error {
+		return p.run(ctx, task)
+	})
+}
+
+// run executes the command for the task without any hooks.
+func (p *Project) run(ctx context.Context, task *vagrant_server.Task) (err error) {
 	p.logger.Debug("running new task",
 		"task", task)
 
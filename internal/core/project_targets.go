@@ -0,0 +1,254 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// TargetResult is the result of running a task against one of the
+// targets of a project.
+type TargetResult struct {
+	// Target the task was run against
+	Target *vagrant_plugin_sdk.Ref_Target
+	// Error returned when running the task, nil if it succeeded
+	Error error
+	// Skipped is true if the task was not run against the target
+	// because it failed on another target
+	Skipped bool
+}
+
+// RunTargets runs the task against each of the targets set on the task,
+// running against up to the parallelism of the task at once. The output
+// of each target is written to its own step of a step group.
+//
+// Once the task fails on a target it is not started on any remaining
+// targets unless the task is set to continue on error. Targets which
+// are already running are left to finish. The result for every target
+// is returned, along with an error naming the targets the task failed
+// on, if any. The exit code of the error is the highest exit code of
+// the failed targets.
+func (p *Project) RunTargets(
+	ctx context.Context,
+	task *vagrant_server.Task,
+) ([]*TargetResult, error) {
+	if len(task.Targets) == 0 {
+		return nil, errors.New("no targets given to run the task against")
+	}
+
+	// Load all the targets before running anything so an unknown
+	// target fails the task before it has run anywhere
+	targets, err := p.loadTaskTargets(task.Targets)
+	if err != nil {
+		return nil, err
+	}
+
+	parallelism := int(task.Parallelism)
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	p.logger.Info("running task against targets",
+		"task", taskName(task),
+		"targets", len(targets),
+		"parallelism", parallelism,
+		"continue-on-error", task.ContinueOnError,
+	)
+
+	results := make([]*TargetResult, len(targets))
+	for i, t := range targets {
+		results[i] = &TargetResult{Target: t.Ref().(*vagrant_plugin_sdk.Ref_Target)}
+	}
+
+	sg := p.ui.StepGroup()
+	skipped := runBounded(ctx, len(targets), parallelism, task.ContinueOnError,
+		func(i int) error {
+			err := p.runTarget(ctx, sg, targets[i], task)
+			results[i].Error = err
+			return err
+		},
+	)
+	sg.Wait()
+
+	for _, i := range skipped {
+		results[i].Skipped = true
+	}
+
+	if err := targetResultsError(task, results); err != nil {
+		return results, err
+	}
+
+	// Targets are also skipped if the context is cancelled
+	for _, r := range results {
+		if r.Skipped {
+			return results, ctx.Err()
+		}
+	}
+
+	return results, nil
+}
+
+// runBounded calls f with each index from 0 to n, with up to parallelism
+// calls running at once. Once a call fails no more calls are started,
+// unless continueOnError is set, and calls are not started once the
+// context is done. The indexes which were not called are returned.
+func runBounded(
+	ctx context.Context,
+	n, parallelism int,
+	continueOnError bool,
+	f func(int) error,
+) (skipped []int) {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed bool
+	)
+	sem := make(chan struct{}, parallelism)
+
+	for i := 0; i < n; i++ {
+		// Wait for a free slot before checking for failures so a
+		// failure on a running call is seen before the next starts
+		sem <- struct{}{}
+
+		mu.Lock()
+		stop := failed && !continueOnError
+		mu.Unlock()
+		if stop || ctx.Err() != nil {
+			skipped = append(skipped, i)
+			<-sem
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := f(i); err != nil {
+				mu.Lock()
+				failed = true
+				mu.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	return skipped
+}
+
+// loadTaskTargets loads the targets of a task. Each target must be
+// within this project, and is only included once.
+func (p *Project) loadTaskTargets(refs []*vagrant_plugin_sdk.Ref_Target) ([]*Target, error) {
+	seen := map[string]struct{}{}
+	targets := make([]*Target, 0, len(refs))
+	for _, ref := range refs {
+		if id := ref.Project.GetResourceId(); id != "" && id != p.project.ResourceId {
+			return nil, fmt.Errorf("target %s is not in project %s", ref.Name, p.project.Name)
+		}
+
+		t, err := p.basis.factory.NewTarget(
+			WithTargetRef(&vagrant_plugin_sdk.Ref_Target{
+				ResourceId: ref.ResourceId,
+				Name:       ref.Name,
+			}),
+			WithProject(p),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load target %s: %w", ref.Name, err)
+		}
+
+		if _, ok := seen[t.target.ResourceId]; ok {
+			continue
+		}
+		seen[t.target.ResourceId] = struct{}{}
+		targets = append(targets, t)
+	}
+
+	return targets, nil
+}
+
+// runTarget runs the task against a single target with the output of
+// the target written to a new step of the step group.
+func (p *Project) runTarget(
+	ctx context.Context,
+	sg terminal.StepGroup,
+	t *Target,
+	task *vagrant_server.Task,
+) (err error) {
+	name := t.target.Name
+	step := sg.Add("%s: running %s", name, taskName(task))
+	ui := newTargetUI(name, step)
+	defer func() {
+		ui.flush()
+		if err != nil {
+			step.Update("%s: %s failed: %s", name, taskName(task), err)
+			step.Abort()
+			return
+		}
+		step.Update("%s: %s complete", name, taskName(task))
+		step.Done()
+	}()
+
+	// The target is only run by this task while it holds the lease,
+	// so its UI can be replaced until the task is complete
+	prev := t.ui
+	t.ui = ui
+	defer func() { t.ui = prev }()
+
+	// Scope the task to the target it is being run against
+	run := proto.Clone(task).(*vagrant_server.Task)
+	run.Scope = &vagrant_server.Task_Target{
+		Target: t.Ref().(*vagrant_plugin_sdk.Ref_Target),
+	}
+	run.Targets = nil
+
+	return t.Run(ctx, run)
+}
+
+// targetResultsError returns an error naming the targets the task failed
+// on, or nil if it did not fail on any target.
+func targetResultsError(task *vagrant_server.Task, results []*TargetResult) error {
+	var failed []string
+	var exitCode int32
+	for _, r := range results {
+		if r.Error == nil {
+			continue
+		}
+		failed = append(failed, r.Target.Name)
+
+		code := int32(1)
+		if cmdErr, ok := r.Error.(CommandError); ok && cmdErr.ExitCode() != 0 {
+			code = cmdErr.ExitCode()
+		}
+		if code > exitCode {
+			exitCode = code
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	err := fmt.Errorf("%s failed on %d of %d targets: %s",
+		taskName(task), len(failed), len(results), strings.Join(failed, ", "))
+
+	return &runError{
+		err:      err,
+		exitCode: exitCode,
+		status: &status.Status{
+			Code:    int32(codes.Unknown),
+			Message: err.Error(),
+		},
+	}
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func TestRunBounded(t *testing.T) {
+	t.Run("limits calls running at once", func(t *testing.T) {
+		require := require.New(t)
+
+		var running, max int32
+		skipped := runBounded(context.Background(), 10, 3, false, func(int) error {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				m := atomic.LoadInt32(&max)
+				if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+		require.Empty(skipped)
+		require.Equal(int32(3), max)
+	})
+
+	t.Run("stops after a failure", func(t *testing.T) {
+		require := require.New(t)
+
+		var called []int
+		skipped := runBounded(context.Background(), 4, 1, false, func(i int) error {
+			called = append(called, i)
+			if i == 1 {
+				return errors.New("failed")
+			}
+			return nil
+		})
+		require.Equal([]int{0, 1}, called)
+		require.Equal([]int{2, 3}, skipped)
+	})
+
+	t.Run("continues on error", func(t *testing.T) {
+		require := require.New(t)
+
+		var mu sync.Mutex
+		called := map[int]bool{}
+		skipped := runBounded(context.Background(), 4, 2, true, func(i int) error {
+			mu.Lock()
+			called[i] = true
+			mu.Unlock()
+			return errors.New("failed")
+		})
+		require.Empty(skipped)
+		require.Len(called, 4)
+	})
+
+	t.Run("skips all once cancelled", func(t *testing.T) {
+		require := require.New(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		skipped := runBounded(ctx, 2, 2, true, func(int) error {
+			t.Fatal("should not be called")
+			return nil
+		})
+		require.Equal([]int{0, 1}, skipped)
+	})
+}
+
+func TestTargetResultsError(t *testing.T) {
+	require := require.New(t)
+	task := &vagrant_server.Task{CommandName: "up"}
+
+	results := []*TargetResult{
+		{Target: &vagrant_plugin_sdk.Ref_Target{Name: "web"}},
+		{Target: &vagrant_plugin_sdk.Ref_Target{Name: "db"}, Skipped: true},
+	}
+	require.NoError(targetResultsError(task, results))
+
+	results = append(results,
+		&TargetResult{
+			Target: &vagrant_plugin_sdk.Ref_Target{Name: "cache"},
+			Error:  &runError{exitCode: 3},
+		},
+		&TargetResult{
+			Target: &vagrant_plugin_sdk.Ref_Target{Name: "queue"},
+			Error:  errors.New("failed"),
+		},
+	)
+	err := targetResultsError(task, results)
+	require.Error(err)
+	require.Equal("up failed on 2 of 4 targets: cache, queue", err.Error())
+	require.Equal(int32(3), err.(CommandError).ExitCode())
+	require.Equal(err.Error(), err.(CommandError).Status().Message)
+}
+
+// targetTestStep captures the output and state of a step.
+type targetTestStep struct {
+	terminal.Step
+
+	buf    bytes.Buffer
+	msg    string
+	status string
+}
+
+func (s *targetTestStep) TermOutput() io.Writer { return &s.buf }
+
+func (s *targetTestStep) Update(msg string, args ...interface{}) {
+	s.msg, _, _, _, _ = terminal.Interpret(msg, args...)
+}
+
+func (s *targetTestStep) Status(status string) { s.status = status }
+
+func TestTargetUI(t *testing.T) {
+	require := require.New(t)
+
+	step := &targetTestStep{}
+	ui := newTargetUI("web", step)
+
+	ui.Output("first\nsecond")
+	ui.Output("failed", terminal.WithErrorStyle())
+	stdout, _, err := ui.OutputWriters()
+	require.NoError(err)
+	stdout.Write([]byte("partial"))
+	require.Equal("web: first\nweb: second\nweb: ! failed\n", step.buf.String())
+
+	require.NoError(ui.flush())
+	require.Equal("web: first\nweb: second\nweb: ! failed\nweb: partial\n", step.buf.String())
+
+	ui.Status().Update("booting")
+	require.Equal("web: booting", step.msg)
+
+	ui.Status().Step(terminal.StatusError, "boot failed")
+	require.Equal(terminal.StatusError, step.status)
+}
@@ -2,8 +2,14 @@ package core
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	sdkcore "github.com/hashicorp/vagrant-plugin-sdk/core"
+	"github.com/hashicorp/vagrant/internal/config"
+	"github.com/hashicorp/vagrant/internal/plugin"
 	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
 	"github.com/stretchr/testify/require"
 )
@@ -109,3 +115,111 @@ func TestProjectGetTargets(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, targets, 3)
 }
+
+func TestProjectSelectProvider(t *testing.T) {
+	providerPlugin := func(name string, priority int, installed, usable bool) *plugin.Plugin {
+		return plugin.TestPlugin(t,
+			BuildTestProviderPlugin(name, installed, usable),
+			plugin.WithPluginName(name),
+			plugin.WithPluginTypes(component.ProviderType),
+			plugin.WithPluginOptions(component.ProviderType, &component.ProviderOptions{
+				Priority:    priority,
+				Defaultable: true,
+			}),
+		)
+	}
+	testProject := func(t *testing.T) *Project {
+		pm := plugin.TestManager(t,
+			providerPlugin("low", 1, true, true),
+			providerPlugin("high", 10, true, true),
+			providerPlugin("missing", 20, false, false),
+			providerPlugin("unusable", 20, true, false),
+		)
+		return TestProject(t, WithPluginManager(pm))
+	}
+	candidate := func(sel *ProviderSelection, name string) *ProviderCandidate {
+		for _, c := range sel.Candidates {
+			if c.Name == name {
+				return c
+			}
+		}
+		t.Fatalf("no candidate for provider %s", name)
+		return nil
+	}
+	opts := &sdkcore.DefaultProviderOptions{CheckUsable: true}
+
+	t.Run("highest priority usable provider", func(t *testing.T) {
+		require := require.New(t)
+		tp := testProject(t)
+
+		sel, err := tp.SelectProvider(opts)
+		require.NoError(err)
+		require.Equal("high", sel.Provider)
+		require.Len(sel.Candidates, 4)
+		require.True(candidate(sel, "high").Chosen)
+		require.Equal("not installed", candidate(sel, "missing").Reason)
+		require.Equal("not usable on this host", candidate(sel, "unusable").Reason)
+		require.Equal("usable, but high was chosen first", candidate(sel, "low").Reason)
+
+		name, err := tp.DefaultProvider(opts)
+		require.NoError(err)
+		require.Equal("high", name)
+	})
+
+	t.Run("preferred providers", func(t *testing.T) {
+		require := require.New(t)
+		t.Setenv("VAGRANT_PREFERRED_PROVIDERS", "unusable, low")
+		tp := testProject(t)
+
+		sel, err := tp.SelectProvider(opts)
+		require.NoError(err)
+		require.Equal("low", sel.Provider)
+		require.Equal("preferred by VAGRANT_PREFERRED_PROVIDERS",
+			candidate(sel, "low").Reason)
+	})
+
+	t.Run("provider priority from config", func(t *testing.T) {
+		require := require.New(t)
+		t.Setenv("VAGRANT_PREFERRED_PROVIDERS", "")
+		tp := testProject(t)
+
+		dir := t.TempDir()
+		require.NoError(os.WriteFile(filepath.Join(dir, config.ConfigFilename), []byte(`
+labels = { "env" = "ci" }
+
+provider {
+  priority = ["high"]
+
+  override {
+    labels   = { "env" = "ci" }
+    priority = ["missing", "low"]
+  }
+}
+`), 0644))
+		tp.project.Path = dir
+
+		sel, err := tp.SelectProvider(opts)
+		require.NoError(err)
+		require.Equal("low", sel.Provider)
+		require.Equal("preferred by "+config.ConfigFilename,
+			candidate(sel, "low").Reason)
+	})
+
+	t.Run("excluded providers", func(t *testing.T) {
+		require := require.New(t)
+		tp := testProject(t)
+
+		sel, err := tp.SelectProvider(&sdkcore.DefaultProviderOptions{
+			CheckUsable: true,
+			Exclude:     []string{"high", "low"},
+		})
+		require.NoError(err)
+		require.Empty(sel.Provider)
+		require.Equal("excluded", candidate(sel, "high").Reason)
+
+		_, err = tp.DefaultProvider(&sdkcore.DefaultProviderOptions{
+			Exclude: []string{"high", "low", "missing", "unusable"},
+		})
+		require.Error(err)
+	})
+}
@@ -308,7 +308,12 @@ func (t *Target) Communicate() (c core.Communicator, err error) {
 
 // UpdatedAt implements core.Target
 func (t *Target) UpdatedAt() (tm *time.Time, err error) {
-	return
+	if t.target.UpdatedAt == nil {
+		return
+	}
+	updated := t.target.UpdatedAt.AsTime()
+
+	return &updated, nil
 }
 
 // Project implements core.Target
@@ -474,7 +479,35 @@ func (t *Target) Destroy() (err error) {
 	return
 }
 
-func (t *Target) Run(ctx context.Context, task *vagrant_server.Task) (err error) {
+func (t *Target) Run(ctx context.Context, task *vagrant_server.Task) error {
+	hooks, err := loadTaskHooks(t.project.project.Path, taskName(task))
+	if err != nil {
+		t.logger.Error("failed to load task hooks",
+			"task", taskName(task),
+			"error", err,
+		)
+
+		return err
+	}
+
+	release, err := t.lease(ctx)
+	if err != nil {
+		t.logger.Error("failed to lease target",
+			"task", taskName(task),
+			"error", err,
+		)
+
+		return err
+	}
+	defer release()
+
+	return runTask(ctx, t, t.logger, hooks, func() error {
+		return t.run(ctx, task)
+	})
+}
+
+// run executes the command for the task without any hooks.
+func (t *Target) run(ctx context.Context, task *vagrant_server.Task) (err error) {
 	t.logger.Debug("running new task",
 		"task", task)
 
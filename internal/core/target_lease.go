@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// targetLeaseTTL is how long the lease on a target is held without being
+// renewed. If the process holding the lease dies, the target is unlocked
+// once this has passed.
+const targetLeaseTTL = 30 * time.Second
+
+// targetLeaseRenewInterval is how often the lease on a target is renewed.
+// It is a fraction of the TTL so a slow renewal doesn't lose the lease.
+const targetLeaseRenewInterval = targetLeaseTTL / 3
+
+// Lease the target so no other operation can run against it. The lease
+// is renewed in the background until the returned function is called
+// to release it.
+func (t *Target) lease(ctx context.Context) (release func(), err error) {
+	var jobId string
+	if t.jobInfo != nil {
+		jobId = t.jobInfo.Id
+	}
+
+	resp, err := t.Client().AcquireTargetLease(ctx,
+		&vagrant_server.AcquireTargetLeaseRequest{
+			Target: t.Ref().(*vagrant_plugin_sdk.Ref_Target),
+			JobId:  jobId,
+			Holder: leaseHolder(),
+			Ttl:    targetLeaseTTL.String(),
+		},
+	)
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			// The message names the holder of the lease, so it is
+			// returned as is
+			return nil, errors.New(status.Convert(err).Message())
+		}
+
+		return nil, err
+	}
+
+	leaseId := resp.Lease.Id
+	t.logger.Trace("acquired target lease",
+		"lease", leaseId,
+		"expires", resp.Lease.ExpireTime.AsTime(),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(targetLeaseRenewInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			_, err := t.Client().RenewTargetLease(ctx,
+				&vagrant_server.RenewTargetLeaseRequest{
+					LeaseId: leaseId,
+					Ttl:     targetLeaseTTL.String(),
+				},
+			)
+			if err != nil {
+				// Keep trying since the lease is only lost once
+				// it has expired
+				t.logger.Warn("failed to renew target lease",
+					"lease", leaseId,
+					"error", err,
+				)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+
+		// The operation context may be cancelled by now, but the
+		// lease should still be released
+		_, err := t.Client().ReleaseTargetLease(context.Background(),
+			&vagrant_server.ReleaseTargetLeaseRequest{
+				LeaseId: leaseId,
+			},
+		)
+		if err != nil {
+			t.logger.Warn("failed to release target lease",
+				"lease", leaseId,
+				"error", err,
+			)
+			return
+		}
+
+		t.logger.Trace("released target lease", "lease", leaseId)
+	}, nil
+}
+
+// leaseHolder describes this process for the holder of a lease.
+func leaseHolder() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return fmt.Sprintf("pid %d on %s", os.Getpid(), host)
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/vagrant-plugin-sdk/component"
@@ -109,3 +110,33 @@ func TestTargetConfigedCommunicator(t *testing.T) {
 		}
 	}
 }
+
+func TestTargetUpdatedAt(t *testing.T) {
+	tt := TestMinimalTarget(t)
+
+	// Saving the target records the time it was updated
+	require.NoError(t, tt.Save())
+	updated, err := tt.UpdatedAt()
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	require.False(t, updated.IsZero())
+}
+
+func TestTargetLease(t *testing.T) {
+	tt := TestMinimalTarget(t)
+	ctx := context.Background()
+
+	release, err := tt.lease(ctx)
+	require.NoError(t, err)
+
+	// The target can't be leased again until it is released
+	_, err = tt.lease(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is locked by")
+
+	release()
+
+	release, err = tt.lease(ctx)
+	require.NoError(t, err)
+	release()
+}
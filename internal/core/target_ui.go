@@ -0,0 +1,240 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+)
+
+// targetUI is the UI for a target while a task is run against multiple
+// targets at once. Each target is given a step in a shared step group
+// and all output is written to the body of the step, with every line
+// prefixed by the name of the target.
+type targetUI struct {
+	mu   sync.Mutex
+	name string
+	step terminal.Step
+	out  *prefixWriter
+}
+
+func newTargetUI(name string, step terminal.Step) *targetUI {
+	return &targetUI{
+		name: name,
+		step: step,
+		out:  &prefixWriter{prefix: name + ": ", w: step.TermOutput()},
+	}
+}
+
+// Input implements terminal.UI. Targets run at once can't share the
+// terminal for input.
+func (u *targetUI) Input(*terminal.Input) (string, error) {
+	return "", terminal.ErrNonInteractive
+}
+
+// Interactive implements terminal.UI
+func (u *targetUI) Interactive() bool {
+	return false
+}
+
+// MachineReadable implements terminal.UI
+func (u *targetUI) MachineReadable() bool {
+	return false
+}
+
+// ClearLine implements terminal.UI
+func (u *targetUI) ClearLine() {}
+
+// Output implements terminal.UI
+func (u *targetUI) Output(msg string, raw ...interface{}) {
+	msg, style, disableNewline, _, _ := terminal.Interpret(msg, raw...)
+
+	switch style {
+	case terminal.HeaderStyle:
+		msg = "==> " + msg
+	case terminal.ErrorStyle, terminal.ErrorBoldStyle:
+		msg = "! " + msg
+	case terminal.WarningStyle, terminal.WarningBoldStyle:
+		msg = "WARNING: " + msg
+	}
+	if !disableNewline {
+		msg += "\n"
+	}
+
+	u.write([]byte(msg))
+}
+
+// NamedValues implements terminal.UI
+func (u *targetUI) NamedValues(rows []terminal.NamedValue, _ ...terminal.Option) {
+	var buf bytes.Buffer
+	tr := tabwriter.NewWriter(&buf, 1, 8, 0, ' ', tabwriter.AlignRight)
+	for _, row := range rows {
+		if v, ok := row.Value.(string); ok && v == "" {
+			continue
+		}
+		fmt.Fprintf(tr, "  %s: \t%v\n", row.Name, row.Value)
+	}
+	tr.Flush()
+
+	u.write(buf.Bytes())
+}
+
+// OutputWriters implements terminal.UI
+func (u *targetUI) OutputWriters() (io.Writer, io.Writer, error) {
+	w := writerFunc(u.write)
+	return w, w, nil
+}
+
+// Status implements terminal.UI. Status updates change the message of
+// the step for the target.
+func (u *targetUI) Status() terminal.Status {
+	return &targetUIStatus{ui: u}
+}
+
+// Table implements terminal.UI
+func (u *targetUI) Table(tbl *terminal.Table, _ ...terminal.Option) {
+	var buf bytes.Buffer
+	tr := tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tr, strings.Join(tbl.Headers, "\t"))
+	for _, row := range tbl.Rows {
+		values := make([]string, len(row))
+		for i, ent := range row {
+			values[i] = ent.Value
+		}
+		fmt.Fprintln(tr, strings.Join(values, "\t"))
+	}
+	tr.Flush()
+
+	u.write(buf.Bytes())
+}
+
+// StepGroup implements terminal.UI. Steps are added to the step group
+// shared by all the targets, named for this target.
+func (u *targetUI) StepGroup() terminal.StepGroup {
+	return &targetUIStepGroup{ui: u}
+}
+
+// flush writes any output which did not end with a new line.
+func (u *targetUI) flush() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.out.Flush()
+}
+
+func (u *targetUI) write(p []byte) (int, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.out.Write(p)
+}
+
+type targetUIStatus struct {
+	ui *targetUI
+}
+
+func (s *targetUIStatus) Update(msg string) {
+	s.ui.step.Update("%s: %s", s.ui.name, msg)
+}
+
+func (s *targetUIStatus) Step(status, msg string) {
+	s.ui.Output("%s", msg)
+	if status == terminal.StatusError || status == terminal.StatusWarn {
+		s.ui.step.Status(status)
+	}
+}
+
+func (s *targetUIStatus) Close() error {
+	return nil
+}
+
+// targetUIStepGroup writes the output of each step to the target UI,
+// since the step group of the target UI is already in use.
+type targetUIStepGroup struct {
+	ui *targetUI
+	wg sync.WaitGroup
+}
+
+func (g *targetUIStepGroup) Add(msg string, args ...interface{}) terminal.Step {
+	g.wg.Add(1)
+	g.ui.Output(msg, args...)
+
+	return &targetUIStep{group: g}
+}
+
+func (g *targetUIStepGroup) Wait() {
+	g.wg.Wait()
+}
+
+type targetUIStep struct {
+	group *targetUIStepGroup
+	once  sync.Once
+}
+
+func (s *targetUIStep) TermOutput() io.Writer {
+	return writerFunc(s.group.ui.write)
+}
+
+func (s *targetUIStep) Update(msg string, args ...interface{}) {
+	s.group.ui.Output(msg, args...)
+}
+
+func (s *targetUIStep) Status(status string) {
+	if status == terminal.StatusError || status == terminal.StatusWarn {
+		s.group.ui.step.Status(status)
+	}
+}
+
+func (s *targetUIStep) Done() {
+	s.once.Do(s.group.wg.Done)
+}
+
+func (s *targetUIStep) Abort() {
+	s.Status(terminal.StatusError)
+	s.Done()
+}
+
+// prefixWriter writes each line to w with a prefix. Partial lines are
+// buffered until the line is complete or the writer is flushed.
+type prefixWriter struct {
+	prefix string
+	w      io.Writer
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if _, err := fmt.Fprintf(w.w, "%s%s", w.prefix, w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// Flush writes any partial line which has been buffered.
+func (w *prefixWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := fmt.Fprintf(w.w, "%s%s\n", w.prefix, w.buf)
+	w.buf = nil
+
+	return err
+}
+
+type writerFunc func([]byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
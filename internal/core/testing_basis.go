@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
 	"github.com/hashicorp/vagrant-plugin-sdk/core"
 	coremocks "github.com/hashicorp/vagrant-plugin-sdk/core/mocks"
 	"github.com/hashicorp/vagrant-plugin-sdk/datadir"
@@ -35,6 +36,16 @@ type TestCommunicatorPlugin struct {
 	coremocks.Communicator
 }
 
+type TestProviderPlugin struct {
+	plugin.TestPluginWithFakeBroker
+	coremocks.Provider
+}
+
+type TestConfigPlugin struct {
+	plugin.TestPluginWithFakeBroker
+	coremocks.Config
+}
+
 type TestGuestPlugin struct {
 	PluginWithParent
 	plugin.TestPluginWithFakeBroker
@@ -61,6 +72,28 @@ func BuildTestCommunicatorPlugin(name string) *TestCommunicatorPlugin {
 	return c
 }
 
+func BuildTestProviderPlugin(name string, installed, usable bool) *TestProviderPlugin {
+	p := &TestProviderPlugin{}
+	p.On("Seed", mock.AnythingOfType("*core.Seeds")).Return(nil)
+	p.On("Seeds").Return(core.NewSeeds(), nil)
+	p.On("PluginName").Return(name, nil)
+	p.On("Installed").Return(installed, nil)
+	p.On("Usable").Return(usable, nil)
+	return p
+}
+
+// BuildTestConfigPlugin builds a config plugin for the namespace which
+// passes configuration through unchanged.
+func BuildTestConfigPlugin(namespace string) *TestConfigPlugin {
+	c := &TestConfigPlugin{}
+	c.On("Register").Return(&component.ConfigRegistration{Identifier: namespace}, nil)
+	c.On("Merge", mock.Anything, mock.Anything).Return(
+		func(_, toMerge *component.ConfigData) *component.ConfigData { return toMerge }, nil)
+	c.On("Finalize", mock.Anything).Return(
+		func(c *component.ConfigData) *component.ConfigData { return c }, nil)
+	return c
+}
+
 func BuildTestGuestPlugin(name string, parent string) *TestGuestPlugin {
 	p := &TestGuestPlugin{}
 	p.On("SetPluginName", mock.AnythingOfType("string")).Return(nil)
@@ -21,6 +21,7 @@ import (
 	"github.com/hashicorp/vagrant-plugin-sdk/internal-shared/protomappers"
 	"github.com/hashicorp/vagrant-plugin-sdk/localizer"
 	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant/internal/config"
 	"github.com/hashicorp/vagrant/internal/plugin"
 	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
 	"github.com/hashicorp/vagrant/internal/serverclient"
@@ -56,6 +57,7 @@ type registration struct {
 	plugin           *plugin.Plugin              // Plugin that provides this config component
 	set              bool                        // Flag to identify this configuration is in use
 	subregistrations map[string]*subregistration // Configuration plugins used within this configuration
+	validators       []ConfigValidator           // Validators run on the finalized configuration
 }
 
 func (r *registration) String() string {
@@ -111,8 +113,12 @@ func (r registrations) init(n string) *registration {
 
 // Register a config component
 func (r registrations) register(n string, p *plugin.Plugin) error {
-	if _, ok := r[n]; ok {
-		return fmt.Errorf("namespace %s is already registered by plugin %s", n, p.Name)
+	if reg, ok := r[n]; ok {
+		if reg.plugin != nil {
+			return fmt.Errorf("namespace %s is already registered by plugin %s", n, p.Name)
+		}
+		reg.plugin = p
+		return nil
 	}
 	r[n] = &registration{
 		identifier:       n,
@@ -193,6 +199,10 @@ func NewVagrantfile(
 	)
 	v.internal = int
 
+	for n, validators := range builtinValidators {
+		v.registrations.init(n).validators = validators
+	}
+
 	return v
 }
 
@@ -211,6 +221,59 @@ func (v *Vagrantfile) GetSource(
 	return s.base, nil
 }
 
+// Get the finalized configuration encoded as a hash keyed
+// by namespace
+func (v *Vagrantfile) FinalizedConfig() (*vagrant_plugin_sdk.Args_Hash, error) {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	if v.root == nil {
+		return nil, fmt.Errorf("vagrantfile has not been initialized")
+	}
+
+	raw, err := dynamic.Map(
+		v.root.Data,
+		(**vagrant_plugin_sdk.Args_Hash)(nil),
+		argmapper.ConverterFunc(v.mappers...),
+		argmapper.Typed(
+			context.Background(),
+			v.logger,
+			plugin.Internal(v.logger, v.mappers),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return raw.(*vagrant_plugin_sdk.Args_Hash), nil
+}
+
+// Get the location of the last source to set each option. Options
+// are named by their namespace and key, for example "vm.box". Options
+// which no source sets are not included.
+func (v *Vagrantfile) OptionLocations() (map[string]LoadLocation, error) {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	result := map[string]LoadLocation{}
+	for l := VAGRANTFILE_BOX; l <= VAGRANTFILE_PROVIDER; l++ {
+		s, ok := v.sources[l]
+		if !ok {
+			continue
+		}
+
+		options, err := config.VagrantfileOptions(s.base.Unfinalized)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range options {
+			result[o] = l
+		}
+	}
+
+	return result, nil
+}
+
 // Register a task to be performed on close
 func (v *Vagrantfile) Closer(
 	fn cleanup.CleanupFn, // cleanup task to perform
@@ -269,10 +332,18 @@ func (v *Vagrantfile) Register(
 		if v.registrations == nil {
 			return fmt.Errorf("registrations are nil")
 		}
-		return v.registrations.register(info.Identifier, p)
+		if err = v.registrations.register(info.Identifier, p); err != nil {
+			return err
+		}
+
+		return v.registrations[info.Identifier].addValidators(info, p)
 	}
 
 	r := v.registrations.init(info.Identifier)
+	if err = r.addValidators(info, p); err != nil {
+		return err
+	}
+
 	return r.sub(info.Scope, p)
 }
 
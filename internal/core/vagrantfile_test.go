@@ -0,0 +1,149 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/helper/types"
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant/internal/config"
+	"github.com/hashicorp/vagrant/internal/plugin"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestVagrantfileOptionLocations(t *testing.T) {
+	v := &Vagrantfile{
+		sources: map[LoadLocation]*source{
+			VAGRANTFILE_BOX: {base: &vagrant_server.Vagrantfile{
+				Unfinalized: testVagrantfileConfig(t,
+					"box", wrapperspb.String("hashicorp/bionic64"),
+					"hostname", wrapperspb.String("box"),
+				),
+			}},
+			VAGRANTFILE_PROJECT: {base: &vagrant_server.Vagrantfile{
+				Unfinalized: testVagrantfileConfig(t,
+					"hostname", wrapperspb.String("project"),
+				),
+			}},
+			VAGRANTFILE_TARGET: {base: &vagrant_server.Vagrantfile{
+				Unfinalized: testVagrantfileConfig(t,
+					"hostname", wrapperspb.String("target"),
+					"box_version", wrapperspb.String("1.0.0"),
+				),
+			}},
+		},
+	}
+
+	locations, err := v.OptionLocations()
+	require.NoError(t, err)
+	require.Equal(t, map[string]LoadLocation{
+		"vm.box":         VAGRANTFILE_BOX,
+		"vm.hostname":    VAGRANTFILE_TARGET,
+		"vm.box_version": VAGRANTFILE_TARGET,
+	}, locations)
+}
+
+func TestVagrantfileValidate(t *testing.T) {
+	v := &Vagrantfile{
+		logger:        hclog.NewNullLogger(),
+		registrations: make(registrations),
+		root: &component.ConfigData{
+			Data: map[string]interface{}{
+				"ssh": &component.ConfigData{
+					Data: map[string]interface{}{
+						"__invalid_methods": []interface{}{
+							types.Symbol("usernme"),
+							types.Symbol("forward_agnet"),
+						},
+					},
+				},
+				"vm": &component.ConfigData{
+					Data: map[string]interface{}{
+						"box": "hashicorp/bionic64",
+					},
+				},
+			},
+		},
+	}
+
+	// Validators are supplied by the options of config plugins
+	configPlugin := func(name string, fn ConfigValidator) *plugin.Plugin {
+		return plugin.TestPlugin(t,
+			BuildTestConfigPlugin("vm"),
+			plugin.WithPluginName(name),
+			plugin.WithPluginTypes(component.ConfigType),
+			plugin.WithPluginOptions(component.ConfigType, &ConfigOptions{
+				Validators: []ConfigValidator{fn},
+			}),
+		)
+	}
+
+	err := v.Register(
+		&component.ConfigRegistration{Identifier: "vm"},
+		configPlugin("vm", func(_ *Vagrantfile, c *component.ConfigData, r *Validation) error {
+			r.Warn("vm", "box is %s", c.Data["box"])
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+
+	err = v.Register(
+		&component.ConfigRegistration{Identifier: "vm", Scope: "provider"},
+		configPlugin("vm-provider", func(*Vagrantfile, *component.ConfigData, *Validation) error { return nil }),
+	)
+	require.Error(t, err)
+
+	result, err := v.Validate()
+	require.NoError(t, err)
+	require.Equal(t, []*ValidationProblem{
+		{Namespace: "ssh", Message: `unknown configuration option "forward_agnet"`},
+		{Namespace: "ssh", Message: `unknown configuration option "usernme"`},
+	}, result.Errors)
+	require.Equal(t, []*ValidationProblem{
+		{Namespace: "vm", Message: "box is hashicorp/bionic64"},
+	}, result.Warnings)
+}
+
+func TestVagrantfileValidateForwardedPorts(t *testing.T) {
+	port := func(options ...interface{}) []interface{} {
+		o := map[interface{}]interface{}{}
+		for i := 0; i < len(options); i += 2 {
+			o[types.Symbol(options[i].(string))] = options[i+1]
+		}
+		return []interface{}{types.Symbol("forwarded_port"), o}
+	}
+
+	config := &component.ConfigData{
+		Data: map[string]interface{}{
+			"__networks": map[interface{}]interface{}{
+				"forwarded_port-ssh": port("guest", int64(22), "host", int64(2222), "host_ip", "127.0.0.1"),
+				"forwarded_port-web": port("guest", int64(80), "host", int64(8080)),
+				"forwarded_port-app": port("guest", int64(3000), "host", int64(8080)),
+				"forwarded_port-dns": port("guest", int64(53), "host", int64(8080), "protocol", "udp"),
+				"forwarded_port-db":  port("guest", int64(5432)),
+				"forwarded_port-bad": port("guest", int64(70000), "host", int64(7000)),
+				"private_network-1":  []interface{}{types.Symbol("private_network"), map[interface{}]interface{}{}},
+			},
+		},
+	}
+
+	result := &Validation{}
+	require.NoError(t, validateVMForwardedPorts(nil, config, result))
+	require.Empty(t, result.Warnings)
+	require.Equal(t, []*ValidationProblem{
+		{Namespace: "vm", Message: `forwarded port "forwarded_port-bad" must use ports between 1 and 65535`},
+		{Namespace: "vm", Message: `forwarded port "forwarded_port-db" must set both the guest and host ports`},
+		{Namespace: "vm", Message: "host port 8080 (tcp) is forwarded more than once"},
+	}, result.Errors)
+}
+
+// testVagrantfileConfig builds unfinalized configuration with the
+// alternating keys and values set in the vm namespace.
+func testVagrantfileConfig(t *testing.T, kv ...interface{}) *vagrant_plugin_sdk.Args_Hash {
+	return config.TestHash(t,
+		"vm", config.TestConfigData(t, "VagrantPlugins::Kernel_V2::VMConfig", kv...),
+	)
+}
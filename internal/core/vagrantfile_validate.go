@@ -0,0 +1,314 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant/internal/plugin"
+)
+
+// ConfigValidator validates the finalized configuration of a
+// namespace. Problems found with the configuration are added to
+// the validation. A returned error means the validator could not
+// be run.
+type ConfigValidator func(
+	v *Vagrantfile, // vagrantfile being validated
+	config *component.ConfigData, // finalized configuration of the namespace
+	result *Validation, // validation to add problems to
+) error
+
+// Problem found with the configuration of a namespace
+type ValidationProblem struct {
+	Namespace string
+	Message   string
+}
+
+// Result of validating a Vagrantfile
+type Validation struct {
+	Errors   []*ValidationProblem
+	Warnings []*ValidationProblem
+}
+
+// Add an error for the namespace
+func (r *Validation) Error(namespace, format string, args ...interface{}) {
+	r.Errors = append(r.Errors, &ValidationProblem{
+		Namespace: namespace,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// Add a warning for the namespace
+func (r *Validation) Warn(namespace, format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, &ValidationProblem{
+		Namespace: namespace,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// Validators for the builtin configuration namespaces
+var builtinValidators = map[string][]ConfigValidator{
+	"vm": {
+		validateVMBox,
+		validateVMForwardedPorts,
+		validateVMProviders,
+	},
+}
+
+// ConfigOptions are the options of a config component. They are
+// set as the component options of the plugin providing the config
+// component.
+type ConfigOptions struct {
+	// Validators run on the finalized configuration of the
+	// namespace registered by the component
+	Validators []ConfigValidator
+}
+
+// Add the validators supplied by the options of a config plugin
+// to the registration of its namespace
+func (r *registration) addValidators(
+	info *component.ConfigRegistration, // registration information of the plugin
+	p *plugin.Plugin, // plugin providing the config component
+) error {
+	opts, ok := p.Options[component.ConfigType].(*ConfigOptions)
+	if !ok || len(opts.Validators) == 0 {
+		return nil
+	}
+
+	if info.Scope != "" {
+		return fmt.Errorf("validators cannot be registered for scoped configuration (%s.%s)",
+			info.Identifier, info.Scope)
+	}
+
+	r.validators = append(r.validators, opts.Validators...)
+
+	return nil
+}
+
+// Validate the finalized configuration. Every namespace is
+// checked for options which do not exist, and then any
+// validators registered for the namespace are run.
+func (v *Vagrantfile) Validate() (*Validation, error) {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	if v.root == nil {
+		return nil, fmt.Errorf("vagrantfile has not been initialized")
+	}
+
+	namespaces := make([]string, 0, len(v.root.Data))
+	for n := range v.root.Data {
+		namespaces = append(namespaces, n)
+	}
+	sort.Strings(namespaces)
+
+	result := &Validation{}
+	for _, n := range namespaces {
+		c, ok := v.root.Data[n].(*component.ConfigData)
+		if !ok {
+			continue
+		}
+
+		validateUnknownOptions(n, c, result)
+
+		var validators []ConfigValidator
+		if reg, ok := v.registrations[n]; ok {
+			validators = reg.validators
+		}
+		for _, fn := range validators {
+			if err := fn(v, c, result); err != nil {
+				v.logger.Error("failed to run configuration validator",
+					"namespace", n,
+					"error", err,
+				)
+
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Ruby configuration records any options which are set but do
+// not exist so they can be reported during validation
+func validateUnknownOptions(
+	namespace string,
+	config *component.ConfigData,
+	result *Validation,
+) {
+	raw, ok := config.Data["__invalid_methods"]
+	if !ok || raw == nil {
+		return
+	}
+
+	var names []string
+	switch m := raw.(type) {
+	case []interface{}:
+		for _, n := range m {
+			if s, err := optionToString(n); err == nil {
+				names = append(names, s)
+			}
+		}
+	case map[interface{}]interface{}:
+		for n := range m {
+			if s, err := optionToString(n); err == nil {
+				names = append(names, s)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		result.Error(namespace, "unknown configuration option %q", n)
+	}
+}
+
+// Check the box used is in the box collection. It is only a
+// warning since the box will be downloaded when it is missing.
+func validateVMBox(
+	v *Vagrantfile,
+	config *component.ConfigData,
+	result *Validation,
+) error {
+	name, err := optionToString(config.Data["box"])
+	if err != nil || name == "" || v.boxes == nil {
+		return nil
+	}
+
+	version, _ := optionToString(config.Data["box_version"])
+	// Only exact versions can be looked up in the collection
+	if strings.ContainsAny(version, "<>=~,") {
+		version = ""
+	}
+
+	box, err := v.boxes.Find(name, version)
+	if err != nil {
+		return err
+	}
+	if box == nil {
+		result.Warn("vm", "box %q is not installed and will be downloaded", name)
+	}
+
+	return nil
+}
+
+// Check forwarded ports have both ports set, are in range, and
+// do not share a host port
+func validateVMForwardedPorts(
+	_ *Vagrantfile,
+	config *component.ConfigData,
+	result *Validation,
+) error {
+	networks, ok := config.Data["__networks"].(map[interface{}]interface{})
+	if !ok {
+		return nil
+	}
+
+	// Sort by ID so problems are reported in a consistent order
+	ids := make([]string, 0, len(networks))
+	byID := map[string]interface{}{}
+	for k, n := range networks {
+		id, err := optionToString(k)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+		byID[id] = n
+	}
+	sort.Strings(ids)
+
+	used := map[string]struct{}{}
+	for _, id := range ids {
+		network, ok := byID[id].([]interface{})
+		if !ok || len(network) != 2 {
+			continue
+		}
+		if t, _ := optionToString(network[0]); t != "forwarded_port" {
+			continue
+		}
+		options, ok := network[1].(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+
+		rawHost, _ := getOptionValue("host", options)
+		rawGuest, _ := getOptionValue("guest", options)
+		host, hostOk := optionToInt(rawHost)
+		guest, guestOk := optionToInt(rawGuest)
+		if !hostOk || !guestOk {
+			result.Error("vm", "forwarded port %q must set both the guest and host ports", id)
+			continue
+		}
+		if host < 1 || host > 65535 || guest < 1 || guest > 65535 {
+			result.Error("vm", "forwarded port %q must use ports between 1 and 65535", id)
+		}
+
+		rawIP, _ := getOptionValue("host_ip", options)
+		rawProtocol, _ := getOptionValue("protocol", options)
+		hostIP, _ := optionToString(rawIP)
+		protocol, _ := optionToString(rawProtocol)
+		if protocol == "" {
+			protocol = "tcp"
+		}
+
+		key := fmt.Sprintf("%s/%s/%d", hostIP, protocol, host)
+		if _, ok := used[key]; ok {
+			result.Error("vm", "host port %d (%s) is forwarded more than once", host, protocol)
+		}
+		used[key] = struct{}{}
+	}
+
+	return nil
+}
+
+// Check the providers configured are installed
+func validateVMProviders(
+	v *Vagrantfile,
+	config *component.ConfigData,
+	result *Validation,
+) error {
+	if v.factory == nil {
+		return nil
+	}
+
+	providers, ok := config.Data["__provider_order"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	for _, raw := range providers {
+		name, err := optionToString(raw)
+		if err != nil {
+			continue
+		}
+		if _, err := v.factory.plugins.Find(name, component.ProviderType); err != nil {
+			result.Error("vm", "provider %q is not installed", name)
+		}
+	}
+
+	return nil
+}
+
+// Option values from the config which are expected to be integer
+// values may be any of the integer types depending on how they
+// were decoded
+func optionToInt(
+	opt interface{}, // value to convert
+) (int64, bool) {
+	switch i := opt.(type) {
+	case int:
+		return int64(i), true
+	case int32:
+		return int64(i), true
+	case int64:
+		return i, true
+	case uint32:
+		return int64(i), true
+	case uint64:
+		return int64(i), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,577 @@
+package datasource
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/h2non/filetype"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/mitchellh/mapstructure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// checksumTypes are the supported checksum types for archives.
+var checksumTypes = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+type ArchiveSource struct{}
+
+func newArchiveSource() Sourcer { return &ArchiveSource{} }
+
+func (s *ArchiveSource) ProjectSource(body hcl.Body, ctx *hcl.EvalContext) (*vagrant_server.Job_DataSource, error) {
+	// Decode
+	var cfg archiveConfig
+	if diag := gohcl.DecodeBody(body, ctx, &cfg); len(diag) > 0 {
+		return nil, diag
+	}
+
+	// Validate the checksum early so it fails before a job is queued
+	if cfg.Checksum != "" {
+		if _, _, err := parseChecksum(cfg.Checksum); err != nil {
+			return nil, err
+		}
+	}
+
+	// Return the data source
+	return &vagrant_server.Job_DataSource{
+		Source: &vagrant_server.Job_DataSource_Archive{
+			Archive: &vagrant_server.Job_Archive{
+				Url:      cfg.Url,
+				Checksum: cfg.Checksum,
+				Path:     cfg.Path,
+			},
+		},
+	}, nil
+}
+
+func (s *ArchiveSource) Override(raw *vagrant_server.Job_DataSource, m map[string]string) error {
+	src := raw.Source.(*vagrant_server.Job_DataSource_Archive).Archive
+
+	var md mapstructure.Metadata
+	if err := mapstructure.DecodeMetadata(m, src, &md); err != nil {
+		return err
+	}
+
+	if len(md.Unused) > 0 {
+		return fmt.Errorf("invalid override keys: %v", md.Unused)
+	}
+
+	return nil
+}
+
+func (s *ArchiveSource) Get(
+	ctx context.Context,
+	log hclog.Logger,
+	ui terminal.UI,
+	raw *vagrant_server.Job_DataSource,
+	baseDir string,
+) (string, func() error, error) {
+	source := raw.Source.(*vagrant_server.Job_DataSource_Archive)
+
+	// Some quick validation
+	if p := source.Archive.Path; p != "" {
+		if filepath.IsAbs(p) {
+			return "", nil, status.Errorf(codes.FailedPrecondition,
+				"archive path must be relative")
+		}
+
+		for _, part := range strings.Split(filepath.ToSlash(p), "/") {
+			if part == ".." {
+				return "", nil, status.Errorf(codes.FailedPrecondition,
+					"archive path may not contain '..'")
+			}
+		}
+	}
+
+	var (
+		checksumType string
+		checksum     []byte
+		err          error
+	)
+	if source.Archive.Checksum != "" {
+		checksumType, checksum, err = parseChecksum(source.Archive.Checksum)
+		if err != nil {
+			return "", nil, status.Errorf(codes.FailedPrecondition,
+				"invalid archive checksum: %s", err)
+		}
+	}
+
+	// Create a temporary directory where we will store the extracted data.
+	td, err := ioutil.TempDir(baseDir, "vagrant")
+	if err != nil {
+		return "", nil, err
+	}
+	closer := func() error {
+		return os.RemoveAll(td)
+	}
+
+	// Output
+	ui.Output("Fetching data from archive", terminal.WithHeaderStyle())
+	ui.Output("URL: %s", source.Archive.Url, terminal.WithInfoStyle())
+
+	// Fetch the archive into a temporary file outside of the extraction
+	// directory so it doesn't end up in the sourced data.
+	archive, err := ioutil.TempFile(baseDir, "vagrant-archive")
+	if err != nil {
+		closer()
+		return "", nil, err
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	var h hash.Hash
+	var w io.Writer = archive
+	if checksumType != "" {
+		h = checksumTypes[checksumType]()
+		w = io.MultiWriter(archive, h)
+	}
+
+	log.Debug("fetching archive", "url", source.Archive.Url, "path", archive.Name())
+	if err := fetchArchive(ctx, source.Archive.Url, w); err != nil {
+		closer()
+		return "", nil, status.Errorf(codes.Aborted,
+			"Archive download failed: %s", err)
+	}
+
+	// Verify the checksum if we were given one
+	if h != nil {
+		if actual := h.Sum(nil); !bytes.Equal(actual, checksum) {
+			closer()
+			return "", nil, status.Errorf(codes.FailedPrecondition,
+				"Archive checksum mismatch: expected %s, got %s",
+				hex.EncodeToString(checksum), hex.EncodeToString(actual))
+		}
+
+		ui.Output("Checksum verified (%s)", checksumType, terminal.WithInfoStyle())
+	}
+
+	// Extract
+	if err := extractArchive(archive, td); err != nil {
+		closer()
+		return "", nil, status.Errorf(codes.Aborted,
+			"Archive extraction failed: %s", err)
+	}
+
+	// If we have a path, set it.
+	result := td
+	if p := source.Archive.Path; p != "" {
+		result = filepath.Join(result, p)
+	}
+
+	return result, closer, nil
+}
+
+// fetchArchive writes the contents of the archive at the given location
+// to w. The location may be an HTTP(S) URL, a file URL, or a path that
+// is local to the runner.
+func fetchArchive(ctx context.Context, location string, w io.Writer) error {
+	u, err := url.Parse(location)
+
+	// Anything that doesn't look like a URL with a scheme is treated as a
+	// local path. Single letter schemes are Windows drive letters.
+	if err != nil || len(u.Scheme) <= 1 {
+		return copyFile(location, w)
+	}
+
+	switch u.Scheme {
+	case "file":
+		return copyFile(u.Path, w)
+
+	case "http", "https":
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected response status: %s", resp.Status)
+		}
+
+		_, err = io.Copy(w, resp.Body)
+		return err
+
+	default:
+		return fmt.Errorf("unsupported archive URL scheme: %s", u.Scheme)
+	}
+}
+
+func copyFile(path string, w io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// extractArchive detects the format of the given archive and extracts
+// it into dst.
+func extractArchive(f *os.File, dst string) error {
+	header := make([]byte, 262)
+	if _, err := f.ReadAt(header, 0); err != nil && err != io.EOF {
+		return err
+	}
+
+	typ, err := filetype.Match(header)
+	if err != nil {
+		return err
+	}
+
+	switch typ.Extension {
+	case "gz":
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+
+		gzr, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gzr.Close()
+
+		return extractTar(tar.NewReader(gzr), dst)
+
+	case "zip":
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+
+		zr, err := zip.NewReader(f, info.Size())
+		if err != nil {
+			return err
+		}
+
+		return extractZip(zr, dst)
+
+	default:
+		return fmt.Errorf("unsupported archive format, must be tar.gz or zip")
+	}
+}
+
+func extractTar(tr *tar.Reader, dst string) error {
+	// Entries are checked against the real path of the destination,
+	// since symlinks they contain are resolved as they are extracted
+	root, err := filepath.EvalSymlinks(dst)
+	if err != nil {
+		return err
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		path, err := archiveEntryPath(root, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if _, err := archiveDir(root, path); err != nil {
+				return err
+			}
+
+		case tar.TypeReg:
+			if err := writeArchiveFile(root, path, os.FileMode(hdr.Mode), tr); err != nil {
+				return err
+			}
+
+		case tar.TypeSymlink:
+			if err := writeArchiveSymlink(root, path, hdr.Linkname); err != nil {
+				return err
+			}
+
+		default:
+			// Skip anything else, such as hard links and devices, since
+			// project data has no need for them.
+		}
+	}
+}
+
+func extractZip(zr *zip.Reader, dst string) error {
+	// Entries are checked against the real path of the destination,
+	// since symlinks they contain are resolved as they are extracted
+	root, err := filepath.EvalSymlinks(dst)
+	if err != nil {
+		return err
+	}
+
+	for _, zf := range zr.File {
+		path, err := archiveEntryPath(root, zf.Name)
+		if err != nil {
+			return err
+		}
+
+		mode := zf.Mode()
+		switch {
+		case mode.IsDir():
+			if _, err := archiveDir(root, path); err != nil {
+				return err
+			}
+
+		case mode&os.ModeSymlink != 0:
+			rc, err := zf.Open()
+			if err != nil {
+				return err
+			}
+			target, err := ioutil.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+
+			if err := writeArchiveSymlink(root, path, string(target)); err != nil {
+				return err
+			}
+
+		case mode.IsRegular():
+			rc, err := zf.Open()
+			if err != nil {
+				return err
+			}
+			err = writeArchiveFile(root, path, mode, rc)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// archiveEntryPath returns the path within root for the given archive
+// entry name. An error is returned if the entry would be extracted
+// outside of root.
+func archiveEntryPath(root, name string) (string, error) {
+	name = filepath.FromSlash(name)
+	if filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("archive entry has an absolute path: %s", name)
+	}
+
+	path := filepath.Join(root, name)
+	if !withinDir(root, path) {
+		return "", fmt.Errorf("archive entry is outside of the destination: %s", name)
+	}
+
+	return path, nil
+}
+
+// archiveDir creates dir, and any missing parents, within root and
+// returns its real path. Symlinks extracted from earlier entries are
+// only followed if they resolve to a directory within root, so an entry
+// can't be written outside of root through them.
+func archiveDir(root, dir string) (string, error) {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." {
+		return root, nil
+	}
+
+	cur := root
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		next := filepath.Join(cur, part)
+		info, err := os.Lstat(next)
+		switch {
+		case os.IsNotExist(err):
+			if err := os.Mkdir(next, 0755); err != nil {
+				return "", err
+			}
+
+		case err != nil:
+			return "", err
+
+		case info.Mode()&os.ModeSymlink != 0:
+			if next, err = filepath.EvalSymlinks(next); err != nil {
+				return "", err
+			}
+			if !withinDir(root, next) {
+				return "", fmt.Errorf("archive entry is outside of the destination: %s", dir)
+			}
+			if info, err = os.Stat(next); err != nil {
+				return "", err
+			}
+			if !info.IsDir() {
+				return "", fmt.Errorf("archive entry parent is not a directory: %s", dir)
+			}
+
+		case !info.IsDir():
+			return "", fmt.Errorf("archive entry parent is not a directory: %s", dir)
+		}
+
+		cur = next
+	}
+
+	return cur, nil
+}
+
+func writeArchiveFile(root, path string, mode os.FileMode, r io.Reader) error {
+	dir, err := archiveDir(root, filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	// Refuse to write through anything that already exists at the path,
+	// such as a symlink extracted from an earlier entry.
+	f, err := os.OpenFile(filepath.Join(dir, filepath.Base(path)),
+		os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+	return err
+}
+
+func writeArchiveSymlink(root, path, target string) error {
+	dir, err := archiveDir(root, filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	if !archiveLinkWithin(root, dir, target) {
+		return fmt.Errorf("archive symlink points outside of the destination: %s -> %s",
+			path, target)
+	}
+
+	return os.Symlink(target, filepath.Join(dir, filepath.Base(path)))
+}
+
+// archiveLinkWithin returns true if the symlink target, relative to the
+// real directory dir, stays within root. The target is resolved against
+// what has been extracted so far, following existing symlinks the way
+// the filesystem would. Once the target passes through a path which
+// doesn't exist yet it can't be resolved, so it must not go up a
+// directory from there, since a later entry could make that path a
+// symlink which changes where ".." leads.
+func archiveLinkWithin(root, dir, target string) bool {
+	target = filepath.FromSlash(target)
+	if filepath.IsAbs(target) || filepath.VolumeName(target) != "" {
+		return false
+	}
+
+	cur := dir
+	resolved := true
+	for _, part := range strings.Split(target, string(filepath.Separator)) {
+		switch {
+		case part == "" || part == ".":
+			continue
+
+		case part == "..":
+			if !resolved {
+				return false
+			}
+			cur = filepath.Dir(cur)
+
+		case resolved:
+			cur = filepath.Join(cur, part)
+			info, err := os.Lstat(cur)
+			if err != nil {
+				resolved = false
+				continue
+			}
+			if info.Mode()&os.ModeSymlink != 0 {
+				// Symlinks are checked when extracted, so one which
+				// can't be resolved yet still stays within root
+				if real, err := filepath.EvalSymlinks(cur); err == nil {
+					cur = real
+				} else {
+					resolved = false
+				}
+			}
+		}
+
+		if resolved && !withinDir(root, cur) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// withinDir returns true if path is dir or is contained within dir.
+func withinDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// parseChecksum parses a checksum in the format "type:value".
+func parseChecksum(v string) (string, []byte, error) {
+	idx := strings.Index(v, ":")
+	if idx == -1 {
+		return "", nil, fmt.Errorf("checksum must be in the format 'type:value'")
+	}
+
+	typ := strings.ToLower(v[:idx])
+	newHash, ok := checksumTypes[typ]
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported checksum type: %s", typ)
+	}
+
+	sum, err := hex.DecodeString(v[idx+1:])
+	if err != nil {
+		return "", nil, fmt.Errorf("checksum value must be hex encoded: %s", err)
+	}
+
+	if len(sum) != newHash().Size() {
+		return "", nil, fmt.Errorf("checksum value has invalid length for %s", typ)
+	}
+
+	return typ, sum, nil
+}
+
+type archiveConfig struct {
+	Url      string `hcl:"url,attr"`
+	Checksum string `hcl:"checksum,optional"`
+	Path     string `hcl:"path,optional"`
+}
+
+var _ Sourcer = (*ArchiveSource)(nil)
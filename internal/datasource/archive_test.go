@@ -0,0 +1,397 @@
+package datasource
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func TestArchiveSourceOverride(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Input    *vagrant_server.Job_DataSource
+		M        map[string]string
+		Expected *vagrant_server.Job_DataSource
+		Error    string
+	}{
+		{
+			"nothing",
+			testArchiveDataSource(&vagrant_server.Job_Archive{Url: "foo"}),
+			nil,
+			testArchiveDataSource(&vagrant_server.Job_Archive{Url: "foo"}),
+			"",
+		},
+
+		{
+			"url and checksum",
+			testArchiveDataSource(&vagrant_server.Job_Archive{Url: "foo"}),
+			map[string]string{"url": "bar", "checksum": "sha256:abcd"},
+			testArchiveDataSource(&vagrant_server.Job_Archive{
+				Url:      "bar",
+				Checksum: "sha256:abcd",
+			}),
+			"",
+		},
+
+		{
+			"invalid",
+			testArchiveDataSource(&vagrant_server.Job_Archive{Url: "foo"}),
+			map[string]string{"other": "bar"},
+			nil,
+			"other",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			var s ArchiveSource
+			err := s.Override(tt.Input, tt.M)
+			if tt.Error != "" {
+				require.Error(err)
+				require.Contains(err.Error(), tt.Error)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal(tt.Expected, tt.Input)
+		})
+	}
+}
+
+func TestArchiveSourceGet(t *testing.T) {
+	files := map[string]string{
+		"vagrant.hcl":          "project = \"test\"",
+		"sub/Vagrantfile":      "# empty",
+		"sub/nested/README.md": "hello",
+	}
+
+	t.Run("tar.gz", func(t *testing.T) {
+		require := require.New(t)
+
+		path := testArchiveTarGz(t, files)
+		dir, closer := testArchiveGet(t, &vagrant_server.Job_Archive{Url: path})
+		defer closer()
+
+		_, err := os.Stat(filepath.Join(dir, "vagrant.hcl"))
+		require.NoError(err)
+		_, err = os.Stat(filepath.Join(dir, "sub", "nested", "README.md"))
+		require.NoError(err)
+	})
+
+	t.Run("zip", func(t *testing.T) {
+		require := require.New(t)
+
+		path := testArchiveZip(t, files)
+		dir, closer := testArchiveGet(t, &vagrant_server.Job_Archive{Url: path})
+		defer closer()
+
+		_, err := os.Stat(filepath.Join(dir, "vagrant.hcl"))
+		require.NoError(err)
+	})
+
+	t.Run("file url", func(t *testing.T) {
+		require := require.New(t)
+
+		path := testArchiveTarGz(t, files)
+		dir, closer := testArchiveGet(t, &vagrant_server.Job_Archive{Url: "file://" + path})
+		defer closer()
+
+		_, err := os.Stat(filepath.Join(dir, "vagrant.hcl"))
+		require.NoError(err)
+	})
+
+	t.Run("path", func(t *testing.T) {
+		require := require.New(t)
+
+		path := testArchiveTarGz(t, files)
+		dir, closer := testArchiveGet(t, &vagrant_server.Job_Archive{
+			Url:  path,
+			Path: "sub",
+		})
+		defer closer()
+
+		_, err := os.Stat(filepath.Join(dir, "Vagrantfile"))
+		require.NoError(err)
+	})
+
+	t.Run("valid checksum", func(t *testing.T) {
+		require := require.New(t)
+
+		path := testArchiveTarGz(t, files)
+		dir, closer := testArchiveGet(t, &vagrant_server.Job_Archive{
+			Url:      path,
+			Checksum: "sha256:" + testArchiveSHA256(t, path),
+		})
+		defer closer()
+
+		_, err := os.Stat(filepath.Join(dir, "vagrant.hcl"))
+		require.NoError(err)
+	})
+
+	t.Run("invalid checksum", func(t *testing.T) {
+		require := require.New(t)
+
+		path := testArchiveTarGz(t, files)
+		_, _, err := testArchiveGetErr(t, &vagrant_server.Job_Archive{
+			Url:      path,
+			Checksum: "sha256:" + hex.EncodeToString(make([]byte, sha256.Size)),
+		})
+		require.Error(err)
+		require.Contains(err.Error(), "checksum mismatch")
+	})
+
+	t.Run("path with parent directory", func(t *testing.T) {
+		require := require.New(t)
+
+		path := testArchiveTarGz(t, files)
+		_, _, err := testArchiveGetErr(t, &vagrant_server.Job_Archive{
+			Url:  path,
+			Path: "sub/../..",
+		})
+		require.Error(err)
+		require.Contains(err.Error(), "..")
+	})
+
+	t.Run("entry with path traversal", func(t *testing.T) {
+		require := require.New(t)
+
+		path := testArchiveTarGz(t, map[string]string{
+			"../escape": "bad",
+		})
+		_, _, err := testArchiveGetErr(t, &vagrant_server.Job_Archive{Url: path})
+		require.Error(err)
+		require.Contains(err.Error(), "outside of the destination")
+	})
+
+	t.Run("symlink within the destination", func(t *testing.T) {
+		require := require.New(t)
+
+		path := testArchiveTarGzEntries(t, []*tar.Header{
+			{Name: "sub/", Typeflag: tar.TypeDir},
+			{Name: "sub/link", Linkname: "../real", Typeflag: tar.TypeSymlink},
+			{Name: "real/file", Typeflag: tar.TypeReg},
+		})
+		dir, closer := testArchiveGet(t, &vagrant_server.Job_Archive{Url: path})
+		defer closer()
+
+		_, err := os.Stat(filepath.Join(dir, "sub", "link", "file"))
+		require.NoError(err)
+	})
+
+	t.Run("symlink chain leaving the destination", func(t *testing.T) {
+		require := require.New(t)
+
+		dst := filepath.Join(t.TempDir(), "a", "b")
+		require.NoError(os.MkdirAll(dst, 0755))
+
+		path := testArchiveTarGzEntries(t, []*tar.Header{
+			{Name: "x/", Typeflag: tar.TypeDir},
+			{Name: "x/a", Linkname: "..", Typeflag: tar.TypeSymlink},
+			{Name: "x/a/b", Linkname: "../..", Typeflag: tar.TypeSymlink},
+			{Name: "x/a/b/pwned", Typeflag: tar.TypeReg},
+		})
+		f, err := os.Open(path)
+		require.NoError(err)
+		defer f.Close()
+
+		err = extractArchive(f, dst)
+		require.Error(err)
+		require.Contains(err.Error(), "outside of the destination")
+
+		_, err = os.Lstat(filepath.Join(dst, "..", "..", "pwned"))
+		require.True(os.IsNotExist(err))
+	})
+
+	t.Run("symlink made to leave the destination by a later entry", func(t *testing.T) {
+		require := require.New(t)
+
+		path := testArchiveTarGzEntries(t, []*tar.Header{
+			{Name: "sub/link", Linkname: "d/../..", Typeflag: tar.TypeSymlink},
+			{Name: "sub/d", Linkname: ".", Typeflag: tar.TypeSymlink},
+		})
+		_, _, err := testArchiveGetErr(t, &vagrant_server.Job_Archive{Url: path})
+		require.Error(err)
+		require.Contains(err.Error(), "outside of the destination")
+	})
+
+	t.Run("entry written through a symlink leaving the destination", func(t *testing.T) {
+		require := require.New(t)
+
+		dst := t.TempDir()
+		outside := t.TempDir()
+		require.NoError(os.Symlink(outside, filepath.Join(dst, "link")))
+
+		path := testArchiveTarGz(t, map[string]string{
+			"link/pwned": "bad",
+		})
+		f, err := os.Open(path)
+		require.NoError(err)
+		defer f.Close()
+
+		err = extractArchive(f, dst)
+		require.Error(err)
+		require.Contains(err.Error(), "outside of the destination")
+
+		_, err = os.Lstat(filepath.Join(outside, "pwned"))
+		require.True(os.IsNotExist(err))
+	})
+
+	t.Run("zip entry with path traversal", func(t *testing.T) {
+		require := require.New(t)
+
+		path := testArchiveZip(t, map[string]string{
+			"sub/../../escape": "bad",
+		})
+		_, _, err := testArchiveGetErr(t, &vagrant_server.Job_Archive{Url: path})
+		require.Error(err)
+		require.Contains(err.Error(), "outside of the destination")
+	})
+}
+
+func TestParseChecksum(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Input string
+		Type  string
+		Error string
+	}{
+		{"sha256", "sha256:" + hex.EncodeToString(make([]byte, 32)), "sha256", ""},
+		{"uppercase type", "SHA1:" + hex.EncodeToString(make([]byte, 20)), "sha1", ""},
+		{"no type", "abcd", "", "format"},
+		{"unknown type", "crc32:abcd", "", "unsupported"},
+		{"bad hex", "md5:zz", "", "hex"},
+		{"bad length", "md5:abcd", "", "length"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			typ, _, err := parseChecksum(tt.Input)
+			if tt.Error != "" {
+				require.Error(err)
+				require.Contains(err.Error(), tt.Error)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal(tt.Type, typ)
+		})
+	}
+}
+
+func testArchiveDataSource(a *vagrant_server.Job_Archive) *vagrant_server.Job_DataSource {
+	return &vagrant_server.Job_DataSource{
+		Source: &vagrant_server.Job_DataSource_Archive{Archive: a},
+	}
+}
+
+func testArchiveGet(t *testing.T, a *vagrant_server.Job_Archive) (string, func() error) {
+	t.Helper()
+
+	dir, closer, err := testArchiveGetErr(t, a)
+	require.NoError(t, err)
+	return dir, closer
+}
+
+func testArchiveGetErr(t *testing.T, a *vagrant_server.Job_Archive) (string, func() error, error) {
+	t.Helper()
+
+	var s ArchiveSource
+	return s.Get(
+		context.Background(),
+		hclog.L(),
+		terminal.ConsoleUI(context.Background()),
+		testArchiveDataSource(a),
+		t.TempDir(),
+	)
+}
+
+func testArchiveTarGz(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	f, err := ioutil.TempFile(t.TempDir(), "archive*.tar.gz")
+	require.NoError(t, err)
+	defer f.Close()
+
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+
+	return f.Name()
+}
+
+// testArchiveTarGzEntries writes a tar.gz archive with the given
+// entries, in order. Regular files are empty.
+func testArchiveTarGzEntries(t *testing.T, entries []*tar.Header) string {
+	t.Helper()
+
+	f, err := ioutil.TempFile(t.TempDir(), "archive*.tar.gz")
+	require.NoError(t, err)
+	defer f.Close()
+
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	for _, hdr := range entries {
+		hdr.Mode = 0755
+		require.NoError(t, tw.WriteHeader(hdr))
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+
+	return f.Name()
+}
+
+func testArchiveZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	f, err := ioutil.TempFile(t.TempDir(), "archive*.zip")
+	require.NoError(t, err)
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	return f.Name()
+}
+
+func testArchiveSHA256(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -40,13 +40,16 @@ type Sourcer interface {
 var (
 	// FromString maps a string key to a source implementation.
 	FromString = map[string]func() Sourcer{
-		"git":   newGitSource,
-		"local": newLocalSource,
+		"archive": newArchiveSource,
+		"git":     newGitSource,
+		"local":   newLocalSource,
 	}
 
 	// FromType maps a server DataSource type to a source implementation.
 	FromType = map[reflect.Type]func() Sourcer{
-		reflect.TypeOf((*vagrant_server.Job_DataSource_Git)(nil)):   newGitSource,
-		reflect.TypeOf((*vagrant_server.Job_DataSource_Local)(nil)): newLocalSource,
+		reflect.TypeOf((*vagrant_server.Job_DataSource_Archive)(nil)): newArchiveSource,
+		reflect.TypeOf((*vagrant_server.Job_DataSource_Git)(nil)):     newGitSource,
+		reflect.TypeOf((*vagrant_server.Job_DataSource_Local)(nil)):   newLocalSource,
+		reflect.TypeOf((*vagrant_server.Job_DataSource_Upload)(nil)):  newUploadSource,
 	}
 )
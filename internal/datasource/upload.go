@@ -0,0 +1,268 @@
+package datasource
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// IgnoreFile is the name of the file within a workspace that lists
+// the paths to exclude when the workspace is uploaded. It uses the
+// same syntax as a .gitignore file.
+const IgnoreFile = ".vagrantignore"
+
+// defaultIgnore are the patterns that are always excluded from an
+// uploaded workspace.
+var defaultIgnore = []string{
+	".vagrant/",
+}
+
+// ClientSourcer is implemented by sourcers that need access to the
+// Vagrant server to source their data.
+type ClientSourcer interface {
+	Sourcer
+
+	// SetClient sets the client used to communicate with the server.
+	SetClient(vagrant_server.VagrantClient)
+}
+
+type UploadSource struct {
+	client vagrant_server.VagrantClient
+}
+
+func newUploadSource() Sourcer { return &UploadSource{} }
+
+func (s *UploadSource) SetClient(c vagrant_server.VagrantClient) {
+	s.client = c
+}
+
+func (s *UploadSource) ProjectSource(body hcl.Body, ctx *hcl.EvalContext) (*vagrant_server.Job_DataSource, error) {
+	return nil, fmt.Errorf("upload data source is set per job and cannot be a project default")
+}
+
+func (s *UploadSource) Override(raw *vagrant_server.Job_DataSource, m map[string]string) error {
+	if len(m) > 0 {
+		return fmt.Errorf("overrides not allowed for upload data source")
+	}
+
+	return nil
+}
+
+func (s *UploadSource) Get(
+	ctx context.Context,
+	log hclog.Logger,
+	ui terminal.UI,
+	raw *vagrant_server.Job_DataSource,
+	baseDir string,
+) (string, func() error, error) {
+	source := raw.Source.(*vagrant_server.Job_DataSource_Upload)
+	if s.client == nil {
+		return "", nil, status.Errorf(codes.Internal,
+			"upload data source requires a server client")
+	}
+
+	// Create a temporary directory where we will store the extracted data.
+	td, err := ioutil.TempDir(baseDir, "vagrant")
+	if err != nil {
+		return "", nil, err
+	}
+	closer := func() error {
+		return os.RemoveAll(td)
+	}
+
+	// Output
+	ui.Output("Fetching uploaded workspace", terminal.WithHeaderStyle())
+	ui.Output("Digest: %s", source.Upload.Digest, terminal.WithInfoStyle())
+
+	archive, err := ioutil.TempFile(baseDir, "vagrant-upload")
+	if err != nil {
+		closer()
+		return "", nil, err
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	stream, err := s.client.GetBlob(ctx, &vagrant_server.GetBlobRequest{
+		Digest: source.Upload.Digest,
+	})
+	if err != nil {
+		closer()
+		return "", nil, err
+	}
+
+	h := sha256.New()
+	w := io.MultiWriter(archive, h)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			closer()
+			return "", nil, err
+		}
+
+		if _, err := w.Write(resp.Chunk); err != nil {
+			closer()
+			return "", nil, err
+		}
+	}
+
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != source.Upload.Digest {
+		closer()
+		return "", nil, status.Errorf(codes.DataLoss,
+			"uploaded workspace digest mismatch: expected %s, got %s",
+			source.Upload.Digest, actual)
+	}
+
+	log.Debug("extracting uploaded workspace", "digest", source.Upload.Digest, "dir", td)
+	if err := extractArchive(archive, td); err != nil {
+		closer()
+		return "", nil, status.Errorf(codes.Aborted,
+			"Workspace extraction failed: %s", err)
+	}
+
+	return td, closer, nil
+}
+
+// PackWorkspace writes the contents of dir to w as a tar.gz archive.
+// Paths matching patterns in the IgnoreFile at the root of dir are
+// excluded, as is the local .vagrant data directory. The hex encoded
+// SHA-256 digest of the written archive is returned.
+func PackWorkspace(dir string, w io.Writer) (string, error) {
+	patterns, err := readIgnorePatterns(dir)
+	if err != nil {
+		return "", err
+	}
+	matcher := gitignore.NewMatcher(patterns)
+
+	h := sha256.New()
+	bw := bufio.NewWriter(io.MultiWriter(w, h))
+	gzw := gzip.NewWriter(bw)
+	tw := tar.NewWriter(gzw)
+
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		if matcher.Match(strings.Split(filepath.ToSlash(rel), "/"), info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Only directories, regular files, and symlinks are packed
+		var link string
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		case info.IsDir(), info.Mode().IsRegular():
+		default:
+			return nil
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := tw.Close(); err != nil {
+		return "", err
+	}
+	if err := gzw.Close(); err != nil {
+		return "", err
+	}
+	if err := bw.Flush(); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readIgnorePatterns returns the default ignore patterns along with
+// any patterns in the IgnoreFile within dir.
+func readIgnorePatterns(dir string) ([]gitignore.Pattern, error) {
+	var patterns []gitignore.Pattern
+	for _, p := range defaultIgnore {
+		patterns = append(patterns, gitignore.ParsePattern(p, nil))
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, IgnoreFile))
+	if os.IsNotExist(err) {
+		return patterns, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		patterns = append(patterns, gitignore.ParsePattern(line, nil))
+	}
+
+	return patterns, nil
+}
+
+var (
+	_ Sourcer       = (*UploadSource)(nil)
+	_ ClientSourcer = (*UploadSource)(nil)
+)
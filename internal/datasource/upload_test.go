@@ -0,0 +1,85 @@
+package datasource
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func TestPackWorkspace(t *testing.T) {
+	require := require.New(t)
+
+	src, err := ioutil.TempDir("", "vagrant-test")
+	require.NoError(err)
+	defer os.RemoveAll(src)
+
+	files := map[string]string{
+		"Vagrantfile":        "Vagrant.configure('2') {}",
+		"scripts/setup.sh":   "echo hello",
+		"build/output.bin":   "ignored",
+		"notes.log":          "ignored",
+		".vagrant/machine":   "ignored",
+		".vagrantignore":     "# comment\nbuild/\n*.log\n",
+		"nested/keep/me.txt": "kept",
+	}
+	for name, content := range files {
+		path := filepath.Join(src, filepath.FromSlash(name))
+		require.NoError(os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	var buf bytes.Buffer
+	digest, err := PackWorkspace(src, &buf)
+	require.NoError(err)
+
+	sum := sha256.Sum256(buf.Bytes())
+	require.Equal(hex.EncodeToString(sum[:]), digest)
+
+	// Write the archive to disk and extract it
+	archive, err := ioutil.TempFile("", "vagrant-test")
+	require.NoError(err)
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+	_, err = archive.Write(buf.Bytes())
+	require.NoError(err)
+	_, err = archive.Seek(0, 0)
+	require.NoError(err)
+
+	dst, err := ioutil.TempDir("", "vagrant-test")
+	require.NoError(err)
+	defer os.RemoveAll(dst)
+	require.NoError(extractArchive(archive, dst))
+
+	for _, name := range []string{"Vagrantfile", "scripts/setup.sh", ".vagrantignore", "nested/keep/me.txt"} {
+		data, err := ioutil.ReadFile(filepath.Join(dst, filepath.FromSlash(name)))
+		require.NoError(err, name)
+		require.Equal(files[name], string(data))
+	}
+
+	for _, name := range []string{"build", "notes.log", ".vagrant"} {
+		_, err := os.Stat(filepath.Join(dst, name))
+		require.True(os.IsNotExist(err), name)
+	}
+}
+
+func TestUploadSourceOverride(t *testing.T) {
+	require := require.New(t)
+
+	source := &vagrant_server.Job_DataSource{
+		Source: &vagrant_server.Job_DataSource_Upload{
+			Upload: &vagrant_server.Job_Upload{Digest: "abcd"},
+		},
+	}
+
+	var s UploadSource
+	require.NoError(s.Override(source, nil))
+	require.Error(s.Override(source, map[string]string{"digest": "efgh"}))
+}
@@ -11,20 +11,27 @@ import (
 // WithInterrupt returns a Context that is done when an interrupt signal is received.
 // It also returns a closer function that should be deferred for proper cleanup.
 func WithInterrupt(ctx context.Context, log hclog.Logger) (context.Context, func()) {
-	log.Trace("starting interrupt listener for context cancellation")
+	return WithSignals(ctx, log, os.Interrupt)
+}
+
+// WithSignals returns a Context that is done when any of the given signals
+// is received. It also returns a closer function that should be deferred
+// for proper cleanup.
+func WithSignals(ctx context.Context, log hclog.Logger, sigs ...os.Signal) (context.Context, func()) {
+	log.Trace("starting signal listener for context cancellation", "signals", sigs)
 
 	// Create the cancellable context that we'll use when we receive an interrupt
 	ctx, cancel := context.WithCancel(ctx)
 
 	// Create the signal channel and cancel the context when we get a signal
 	ch := make(chan os.Signal, 1)
-	signal.Notify(ch, os.Interrupt)
+	signal.Notify(ch, sigs...)
 	go func() {
 		log.Trace("interrupt listener goroutine started")
 
 		select {
-		case <-ch:
-			log.Warn("interrupt received, cancelling context")
+		case sig := <-ch:
+			log.Warn("signal received, cancelling context", "signal", sig)
 			cancel()
 		case <-ctx.Done():
 			log.Warn("context cancelled, stopping interrupt listener loop")
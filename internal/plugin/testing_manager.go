@@ -16,5 +16,8 @@ func TestManager(t testing.T, plugins ...*Plugin) *Manager {
 		hclog.New(&hclog.LoggerOptions{}),
 	)
 	pluginManager.Plugins = plugins
+	for _, p := range plugins {
+		p.manager = pluginManager
+	}
 	return pluginManager
 }
@@ -72,3 +72,13 @@ func WithPluginTypes(types ...component.Type) PluginProperty {
 		return
 	}
 }
+
+func WithPluginOptions(typ component.Type, opts interface{}) PluginProperty {
+	return func(p *Plugin) (err error) {
+		if p.Options == nil {
+			p.Options = map[component.Type]interface{}{}
+		}
+		p.Options[typ] = opts
+		return
+	}
+}
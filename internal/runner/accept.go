@@ -220,7 +220,9 @@ func (r *Runner) accept(ctx context.Context, id string) error {
 			// Execute the job. We have to close the UI right afterwards to
 			// ensure that no more output is writting to the client.
 			log.Info("starting job execution")
+			done := r.metrics.jobStarted(assignment.Assignment.Job)
 			result, err = r.executeJob(ctx, log, ui, assignment.Assignment.Job, wd)
+			done(err)
 			if ui, ok := ui.(io.Closer); ok {
 				ui.Close()
 			}
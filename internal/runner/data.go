@@ -41,6 +41,11 @@ func (r *Runner) downloadJobData(
 	}
 	sourcer := factory()
 
+	// Some sourcers need to fetch their data from the server
+	if cs, ok := sourcer.(datasource.ClientSourcer); ok {
+		cs.SetClient(r.client)
+	}
+
 	// Apply any overrides
 	if len(overrides) > 0 {
 		if err := sourcer.Override(source, overrides); err != nil {
@@ -0,0 +1,113 @@
+package runner
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	serverptypes "github.com/hashicorp/vagrant/internal/server/ptypes"
+)
+
+// EnvMetricsAddr is the environment variable that sets the address the
+// runner serves its metrics on. If it is empty no metrics are served.
+const EnvMetricsAddr = "VAGRANT_RUNNER_METRICS_ADDR"
+
+// runnerMetrics are the job execution metrics of a runner.
+type runnerMetrics struct {
+	registry *prometheus.Registry
+	running  prometheus.Gauge
+	duration *prometheus.HistogramVec
+}
+
+func newRunnerMetrics() *runnerMetrics {
+	m := &runnerMetrics{
+		registry: prometheus.NewRegistry(),
+
+		running: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "vagrant_runner",
+			Name:      "jobs_running",
+			Help:      "Number of jobs the runner is currently executing.",
+		}),
+
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "vagrant_runner",
+			Name:      "job_duration_seconds",
+			Help:      "Duration of job executions by the runner.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}, []string{"operation", "result"}),
+	}
+
+	m.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		m.running,
+		m.duration,
+	)
+
+	return m
+}
+
+// jobStarted records that the job has started executing. The returned
+// function must be called with the result of the execution when the job
+// is done.
+func (m *runnerMetrics) jobStarted(job *vagrant_server.Job) func(error) {
+	start := time.Now()
+	m.running.Inc()
+
+	return func(err error) {
+		m.running.Dec()
+
+		result := "success"
+		if err != nil {
+			result = "error"
+		}
+
+		m.duration.WithLabelValues(serverptypes.JobOperation(job), result).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// MetricsHandler returns a handler which serves the job execution metrics
+// of the runner in the Prometheus text format.
+func (r *Runner) MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(r.metrics.registry, promhttp.HandlerOpts{
+		ErrorLog: r.logger.Named("metrics").StandardLogger(nil),
+	})
+}
+
+// serveMetrics starts serving the metrics of the runner on "/metrics" of
+// the given address. The server is shut down when the runner is closed.
+func (r *Runner) serveMetrics(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", r.MetricsHandler())
+	srv := &http.Server{
+		ReadHeaderTimeout: 5 * time.Second,
+		Handler:           mux,
+	}
+
+	log := r.logger.Named("metrics")
+	go func() {
+		log.Info("serving runner metrics", "addr", ln.Addr().String())
+		if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+			log.Warn("error serving runner metrics", "error", err)
+		}
+	}()
+
+	r.Closer(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		return srv.Shutdown(ctx)
+	})
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package runner
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	serverptypes "github.com/hashicorp/vagrant/internal/server/ptypes"
+)
+
+func TestRunnerMetrics(t *testing.T) {
+	require := require.New(t)
+
+	r := &Runner{
+		logger:  hclog.L(),
+		metrics: newRunnerMetrics(),
+	}
+
+	job := serverptypes.TestJobNew(t, nil)
+	r.metrics.jobStarted(job)(nil)
+	r.metrics.jobStarted(job)(errors.New("failed"))
+	r.metrics.jobStarted(job)
+
+	rec := httptest.NewRecorder()
+	r.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, err := ioutil.ReadAll(rec.Body)
+	require.NoError(err)
+
+	out := string(body)
+	require.Contains(out, "vagrant_runner_jobs_running 1")
+	require.Contains(out, `vagrant_runner_job_duration_seconds_count{operation="noop",result="success"} 1`)
+	require.Contains(out, `vagrant_runner_job_duration_seconds_count{operation="noop",result="error"} 1`)
+}
@@ -115,12 +115,24 @@ func (r *Runner) executeJob(
 		log.Warn("running a run operation", "scope", scope, "job", job)
 		return r.executeRunOp(ctx, job, scope)
 
+	case *vagrant_server.Job_Validate:
+		return r.executeValidateOp(ctx, log, job, p)
+
 	case *vagrant_server.Job_Auth:
 		return r.executeAuthOp(ctx, log, job, p)
 
 	case *vagrant_server.Job_Docs:
 		return r.executeDocsOp(ctx, log, job, p)
 
+	case *vagrant_server.Job_Config:
+		return r.executeConfigOp(ctx, log, job, p)
+
+	case *vagrant_server.Job_GlobalStatus:
+		return r.executeGlobalStatusOp(ctx, log, job, b)
+
+	case *vagrant_server.Job_ProviderList:
+		return r.executeProviderListOp(ctx, log, job, p)
+
 	default:
 		return nil, status.Errorf(codes.Aborted, "unknown operation %T", job.Operation)
 	}
@@ -0,0 +1,81 @@
+package runner
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant/internal/core"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func (r *Runner) executeConfigOp(
+	ctx context.Context,
+	log hclog.Logger,
+	job *vagrant_server.Job,
+	project *core.Project,
+) (*vagrant_server.Job_Result, error) {
+	op, ok := job.Operation.(*vagrant_server.Job_Config)
+	if !ok {
+		// this shouldn't happen since the call to this function is gated
+		// on the above type match.
+		panic("operation not expected type")
+	}
+
+	if project == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"configuration can only be shown within a project")
+	}
+
+	name := op.Config.Target
+	if name == "" {
+		var err error
+		if name, err = project.PrimaryTargetName(); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Debug("loading target configuration", "target", name,
+		"provider", op.Config.Provider)
+
+	pv, err := project.Vagrantfile()
+	if err != nil {
+		return nil, err
+	}
+
+	tv, err := pv.TargetConfig(name, op.Config.Provider, false)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := tv.(*core.Vagrantfile)
+	if !ok {
+		return nil, status.Errorf(codes.Internal,
+			"unexpected vagrantfile type %T", tv)
+	}
+
+	finalized, err := v.FinalizedConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := v.OptionLocations()
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make(map[string]string, len(locations))
+	for option, l := range locations {
+		sources[option] = strings.ToLower(l.String())
+	}
+
+	return &vagrant_server.Job_Result{
+		Config: &vagrant_server.Job_ConfigResult{
+			Target:    name,
+			Finalized: finalized,
+			Sources:   sources,
+		},
+	}, nil
+}
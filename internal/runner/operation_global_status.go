@@ -0,0 +1,206 @@
+package runner
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	sdkcore "github.com/hashicorp/vagrant-plugin-sdk/core"
+	"github.com/hashicorp/vagrant/internal/core"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	serverptypes "github.com/hashicorp/vagrant/internal/server/ptypes"
+)
+
+func (r *Runner) executeGlobalStatusOp(
+	ctx context.Context,
+	log hclog.Logger,
+	job *vagrant_server.Job,
+	basis *core.Basis,
+) (*vagrant_server.Job_Result, error) {
+	op, ok := job.Operation.(*vagrant_server.Job_GlobalStatus)
+	if !ok {
+		// this shouldn't happen since the call to this function is gated
+		// on the above type match.
+		panic("operation not expected type")
+	}
+
+	index, err := basis.TargetIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := index.All()
+	if err != nil {
+		return nil, err
+	}
+
+	providers := map[string]struct{}{}
+	for _, p := range op.GlobalStatus.Providers {
+		providers[p] = struct{}{}
+	}
+	states := map[vagrant_server.Operation_PhysicalState]struct{}{}
+	for _, s := range op.GlobalStatus.States {
+		states[s] = struct{}{}
+	}
+
+	result := &vagrant_server.Job_GlobalStatusResult{}
+	for _, t := range targets {
+		target, ok := t.(*core.Target)
+		if !ok {
+			return nil, status.Errorf(codes.Internal,
+				"unexpected target type %T", t)
+		}
+
+		entry, err := globalStatusEntry(target)
+		if err != nil {
+			return nil, err
+		}
+
+		L := log.With("target", entry.Name, "project", entry.ProjectPath)
+
+		if op.GlobalStatus.Prune {
+			valid, err := globalStatusValid(L, target)
+			if err != nil {
+				return nil, err
+			}
+
+			// Only targets which don't exist are removed, the same as
+			// when garbage collecting, so others can still be managed
+			switch {
+			case valid:
+			case serverptypes.TargetRemovable(entry.State):
+				L.Debug("removing invalid target from index")
+				if err := index.Delete(entry.Uuid); err != nil {
+					return nil, err
+				}
+				result.Pruned = append(result.Pruned, entry)
+				continue
+			default:
+				L.Debug("keeping invalid target which may still exist",
+					"state", entry.State)
+				result.Orphaned = append(result.Orphaned, entry)
+			}
+		}
+
+		if _, ok := providers[entry.Provider]; len(providers) > 0 && !ok {
+			continue
+		}
+		if _, ok := states[entry.State]; len(states) > 0 && !ok {
+			continue
+		}
+
+		result.Targets = append(result.Targets, entry)
+	}
+
+	return &vagrant_server.Job_Result{
+		GlobalStatus: result,
+	}, nil
+}
+
+// globalStatusEntry builds the entry describing a target.
+func globalStatusEntry(t *core.Target) (*vagrant_server.Job_GlobalStatusResult_Entry, error) {
+	uuid, err := t.GetUUID()
+	if err != nil {
+		return nil, err
+	}
+	name, err := t.Name()
+	if err != nil {
+		return nil, err
+	}
+	p, err := t.Project()
+	if err != nil {
+		return nil, err
+	}
+	home, err := p.Home()
+	if err != nil {
+		return nil, err
+	}
+	state, err := t.State()
+	if err != nil {
+		return nil, err
+	}
+	updated, err := t.UpdatedAt()
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &vagrant_server.Job_GlobalStatusResult_Entry{
+		Uuid:        uuid,
+		Name:        name,
+		State:       physicalState(state),
+		ProjectPath: home.String(),
+	}
+	// Targets which have not been up are given the default provider,
+	// so an error finding it just leaves the provider unset
+	if provider, err := t.ProviderName(); err == nil {
+		entry.Provider = provider
+	}
+	if updated != nil {
+		entry.UpdatedAt = timestamppb.New(*updated)
+	}
+
+	return entry, nil
+}
+
+// globalStatusValid returns false if the project of the target no
+// longer exists, or if its Vagrantfile no longer defines the target.
+// If the Vagrantfile can't be loaded the target is assumed to be valid.
+func globalStatusValid(log hclog.Logger, t *core.Target) (bool, error) {
+	p, err := t.Project()
+	if err != nil {
+		return false, err
+	}
+
+	home, err := p.Home()
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(home.String()); os.IsNotExist(err) {
+		return false, nil
+	}
+
+	v, err := p.Vagrantfile()
+	if err == nil {
+		var names []string
+		if names, err = v.TargetNames(); err == nil {
+			name, err := t.Name()
+			if err != nil {
+				return false, err
+			}
+			for _, n := range names {
+				if n == name {
+					return true, nil
+				}
+			}
+
+			return false, nil
+		}
+	}
+
+	log.Warn("failed to load the Vagrantfile of the project, not pruning target",
+		"error", err)
+
+	return true, nil
+}
+
+// physicalState converts the state of a target to its protobuf value.
+func physicalState(s sdkcore.State) vagrant_server.Operation_PhysicalState {
+	switch s {
+	case sdkcore.PENDING:
+		return vagrant_server.Operation_PENDING
+	case sdkcore.CREATED:
+		return vagrant_server.Operation_CREATED
+	case sdkcore.DESTROYED:
+		return vagrant_server.Operation_DESTROYED
+	case sdkcore.HALTED:
+		return vagrant_server.Operation_HALTED
+	case sdkcore.NOT_CREATED:
+		return vagrant_server.Operation_NOT_CREATED
+	default:
+		return vagrant_server.Operation_UNKNOWN
+	}
+}
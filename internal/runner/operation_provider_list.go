@@ -0,0 +1,61 @@
+package runner
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sdkcore "github.com/hashicorp/vagrant-plugin-sdk/core"
+	"github.com/hashicorp/vagrant/internal/core"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func (r *Runner) executeProviderListOp(
+	ctx context.Context,
+	log hclog.Logger,
+	job *vagrant_server.Job,
+	project *core.Project,
+) (*vagrant_server.Job_Result, error) {
+	if _, ok := job.Operation.(*vagrant_server.Job_ProviderList); !ok {
+		// this shouldn't happen since the call to this function is gated
+		// on the above type match.
+		panic("operation not expected type")
+	}
+
+	if project == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"providers can only be listed within a project")
+	}
+
+	sel, err := project.SelectProvider(&sdkcore.DefaultProviderOptions{
+		CheckUsable: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("selected default provider", "provider", sel.Provider)
+
+	result := &vagrant_server.Job_ProviderListResult{
+		DefaultProvider: sel.Provider,
+	}
+	for _, c := range sel.Candidates {
+		result.Providers = append(result.Providers,
+			&vagrant_server.Job_ProviderListResult_Provider{
+				Name:        c.Name,
+				Priority:    int32(c.Priority),
+				Defaultable: c.Defaultable,
+				Installed:   c.Installed,
+				Usable:      c.Usable,
+				Chosen:      c.Chosen,
+				Reason:      c.Reason,
+			},
+		)
+	}
+
+	return &vagrant_server.Job_Result{
+		ProviderList: result,
+	}, nil
+}
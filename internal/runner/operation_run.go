@@ -8,12 +8,20 @@ import (
 	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
 	"google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Runs interface {
 	Run(context.Context, *vagrant_server.Task) error
 }
 
+// RunsTargets is implemented by scopes which can run a task against
+// multiple targets, returning the result for each target.
+type RunsTargets interface {
+	RunTargets(context.Context, *vagrant_server.Task) ([]*core.TargetResult, error)
+}
+
 // Keeping this around as an example
 func (r *Runner) executeRunOp(
 	ctx context.Context,
@@ -32,29 +40,105 @@ func (r *Runner) executeRunOp(
 	var jrr vagrant_server.Job_RunResult
 	jrr.Task = op.Run.Task
 
-	err = scope.Run(ctx, op.Run.Task)
+	task := r.recordTaskStart(ctx, job, op.Run.Task)
+	if rt, ok := scope.(RunsTargets); ok && len(op.Run.Task.Targets) > 0 {
+		var results []*core.TargetResult
+		results, err = rt.RunTargets(ctx, op.Run.Task)
+		for _, result := range results {
+			tr := &vagrant_server.Job_RunResult_TargetResult{
+				Target:    result.Target,
+				RunResult: result.Error == nil && !result.Skipped,
+				Skipped:   result.Skipped,
+			}
+			if result.Error != nil {
+				tr.RunError, tr.ExitCode = runErrorStatus(result.Error)
+			}
+			jrr.TargetResults = append(jrr.TargetResults, tr)
+		}
+	} else {
+		err = scope.Run(ctx, op.Run.Task)
+	}
 
 	r.logger.Debug("execution of run operation complete", "job", job, "error", err)
 
 	jrr.RunResult = err == nil
 	if err != nil {
-		if cmdErr, ok := err.(core.CommandError); ok {
-			jrr.RunError = err.(core.CommandError).Status()
-			jrr.ExitCode = int32(cmdErr.ExitCode())
-		} else {
-			// If we have an error without a status we'll make one here
-			jrr.RunError = &status.Status{
-				Code:    int32(codes.Unknown),
-				Message: fmt.Sprintf("Unexpected error from run operation: %s", err),
-			}
-			jrr.ExitCode = 1
-		}
-
+		jrr.RunError, jrr.ExitCode = runErrorStatus(err)
 	}
 
+	r.recordTaskComplete(task, &jrr)
 	r.logger.Info("run operation is complete!")
 
 	return &vagrant_server.Job_Result{
 		Run: &jrr,
 	}, nil
 }
+
+// runErrorStatus returns the status and exit code for an error from
+// running a task.
+func runErrorStatus(err error) (*status.Status, int32) {
+	if cmdErr, ok := err.(core.CommandError); ok {
+		return cmdErr.Status(), cmdErr.ExitCode()
+	}
+
+	// If we have an error without a status we'll make one here
+	return &status.Status{
+		Code:    int32(codes.Unknown),
+		Message: fmt.Sprintf("Unexpected error from run operation: %s", err),
+	}, 1
+}
+
+// recordTaskStart stores the task as running so that it is included in
+// the history of its scope. The stored task is returned so it can be
+// updated once complete, or nil if it could not be stored. Failing to
+// store the task does not stop it from running.
+func (r *Runner) recordTaskStart(
+	ctx context.Context,
+	job *vagrant_server.Job,
+	task *vagrant_server.Task,
+) *vagrant_server.Task {
+	record := proto.Clone(task).(*vagrant_server.Task)
+	record.Id = ""
+	record.JobId = job.Id
+	record.Status = &vagrant_server.Status{
+		State:     vagrant_server.Status_RUNNING,
+		StartTime: timestamppb.Now(),
+	}
+
+	resp, err := r.client.UpsertTask(ctx, &vagrant_server.UpsertTaskRequest{
+		Task: record,
+	})
+	if err != nil {
+		r.logger.Warn("failed to record task", "task", task.Task, "error", err)
+		return nil
+	}
+
+	return resp.Task
+}
+
+// recordTaskComplete updates the stored task with the result of running it.
+func (r *Runner) recordTaskComplete(
+	task *vagrant_server.Task,
+	result *vagrant_server.Job_RunResult,
+) {
+	if task == nil {
+		return
+	}
+
+	task.Status.CompleteTime = timestamppb.Now()
+	task.Status.State = vagrant_server.Status_SUCCESS
+	if !result.RunResult {
+		task.Status.State = vagrant_server.Status_ERROR
+		task.Status.Error = result.RunError
+	}
+	task.ExitCode = result.ExitCode
+
+	// The job may have been cancelled, but the task should still be
+	// recorded as complete
+	_, err := r.client.UpsertTask(context.Background(), &vagrant_server.UpsertTaskRequest{
+		Task: task,
+	})
+	if err != nil {
+		r.logger.Warn("failed to record task completion", "task", task.Task, "error", err)
+	}
+}
@@ -0,0 +1,101 @@
+package runner
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant/internal/core"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func (r *Runner) executeValidateOp(
+	ctx context.Context,
+	log hclog.Logger,
+	job *vagrant_server.Job,
+	project *core.Project,
+) (*vagrant_server.Job_Result, error) {
+	op, ok := job.Operation.(*vagrant_server.Job_Validate)
+	if !ok {
+		// this shouldn't happen since the call to this function is gated
+		// on the above type match.
+		panic("operation not expected type")
+	}
+
+	if project == nil {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"configuration can only be validated within a project")
+	}
+
+	pv, err := project.Vagrantfile()
+	if err != nil {
+		return nil, err
+	}
+
+	names := op.Validate.Targets
+	if len(names) == 0 {
+		if names, err = pv.TargetNames(); err != nil {
+			return nil, err
+		}
+	}
+
+	var results []*vagrant_server.Job_ValidateResult_Target
+	for _, name := range names {
+		L := log.With("target", name)
+		L.Debug("validating target configuration")
+
+		result := &vagrant_server.Job_ValidateResult_Target{Name: name}
+		results = append(results, result)
+
+		// A target which can't be loaded is reported as a problem
+		// with its configuration so the remaining targets are
+		// still validated
+		tv, err := pv.TargetConfig(name, "", false)
+		if err != nil {
+			L.Debug("failed to load target configuration", "error", err)
+			result.Errors = append(result.Errors, &vagrant_server.Job_ValidateResult_Problem{
+				Message: err.Error(),
+			})
+			continue
+		}
+
+		v, ok := tv.(*core.Vagrantfile)
+		if !ok {
+			return nil, status.Errorf(codes.Internal,
+				"unexpected vagrantfile type %T", tv)
+		}
+
+		validation, err := v.Validate()
+		if err != nil {
+			return nil, err
+		}
+
+		result.Errors = append(result.Errors, validationProblems(validation.Errors)...)
+		result.Warnings = validationProblems(validation.Warnings)
+
+		L.Debug("validated target configuration",
+			"errors", len(result.Errors),
+			"warnings", len(result.Warnings),
+		)
+	}
+
+	return &vagrant_server.Job_Result{
+		Validate: &vagrant_server.Job_ValidateResult{
+			Targets: results,
+		},
+	}, nil
+}
+
+func validationProblems(problems []*core.ValidationProblem) []*vagrant_server.Job_ValidateResult_Problem {
+	result := make([]*vagrant_server.Job_ValidateResult_Problem, len(problems))
+	for i, p := range problems {
+		result[i] = &vagrant_server.Job_ValidateResult_Problem{
+			Namespace: p.Namespace,
+			Message:   p.Message,
+		}
+	}
+
+	return result
+}
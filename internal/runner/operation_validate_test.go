@@ -0,0 +1,64 @@
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/component"
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	configpkg "github.com/hashicorp/vagrant/internal/config"
+	"github.com/hashicorp/vagrant/internal/core"
+	"github.com/hashicorp/vagrant/internal/plugin"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func TestExecuteValidateOp(t *testing.T) {
+	require := require.New(t)
+
+	// The validator is supplied by the config plugin of the namespace
+	validator := func(_ *core.Vagrantfile, c *component.ConfigData, r *core.Validation) error {
+		r.Warn("vm", "box is %s", c.Data["box"])
+		return nil
+	}
+	pm := plugin.TestManager(t,
+		plugin.TestPlugin(t,
+			core.BuildTestConfigPlugin("vm"),
+			plugin.WithPluginName("vm"),
+			plugin.WithPluginTypes(component.ConfigType),
+			plugin.WithPluginOptions(component.ConfigType, &core.ConfigOptions{
+				Validators: []core.ConfigValidator{validator},
+			}),
+		),
+	)
+	project := core.TestProject(t, core.WithPluginManager(pm))
+	target := core.TestTarget(t, project, &vagrant_server.Target{Name: "web"})
+
+	tv, err := target.Vagrantfile()
+	require.NoError(err)
+	v := tv.(*core.Vagrantfile)
+	require.NoError(v.Source(&vagrant_server.Vagrantfile{
+		Unfinalized: configpkg.TestHash(t,
+			"vm", &vagrant_plugin_sdk.Args_ConfigData{Data: configpkg.TestHash(t,
+				"box", &vagrant_plugin_sdk.Args_Symbol{Str: "hashicorp/bionic64"},
+			)},
+		),
+	}, core.VAGRANTFILE_TARGET))
+	require.NoError(v.Init())
+
+	result, err := (&Runner{}).executeValidateOp(context.Background(), hclog.L(),
+		&vagrant_server.Job{
+			Operation: &vagrant_server.Job_Validate{
+				Validate: &vagrant_server.Job_ValidateOp{Targets: []string{"web"}},
+			},
+		}, project)
+	require.NoError(err)
+	require.Len(result.Validate.Targets, 1)
+	require.Equal("web", result.Validate.Targets[0].Name)
+	require.Empty(result.Validate.Targets[0].Errors)
+	require.Equal([]*vagrant_server.Job_ValidateResult_Problem{
+		{Namespace: "vm", Message: "box is hashicorp/bionic64"},
+	}, result.Validate.Targets[0].Warnings)
+}
@@ -72,6 +72,11 @@ type Runner struct {
 	// sequence for every operation
 	opConfig *intcfg.Config
 
+	// metrics are the job execution metrics of the runner. If metricsAddr
+	// is set they are served on that address once the runner starts.
+	metrics     *runnerMetrics
+	metricsAddr string
+
 	// noopCh is used in tests only. This will cause any noop operations
 	// to block until this channel is closed.
 	noopCh <-chan struct{}
@@ -101,6 +106,7 @@ func New(opts ...Option) (*Runner, error) {
 		ctx:      context.Background(),
 		runner:   &vagrant_server.Runner{Id: id},
 		opConfig: &intcfg.Config{},
+		metrics:  newRunnerMetrics(),
 	}
 
 	// Build our config
@@ -171,6 +177,12 @@ func (r *Runner) Start() error {
 
 	log := r.logger
 
+	if r.metricsAddr != "" {
+		if err := r.serveMetrics(r.metricsAddr); err != nil {
+			return err
+		}
+	}
+
 	// Register
 	log.Debug("registering runner")
 	client, err := r.client.RunnerConfig(r.ctx)
@@ -301,6 +313,16 @@ func ByIdOnly() Option {
 	}
 }
 
+// WithMetricsAddr serves the job execution metrics of the runner on
+// "/metrics" of the given address once the runner is started. If addr is
+// empty no metrics are served.
+func WithMetricsAddr(addr string) Option {
+	return func(r *Runner, cfg *config) error {
+		r.metricsAddr = addr
+		return nil
+	}
+}
+
 func WithContext(ctx context.Context) Option {
 	return func(r *Runner, cfg *config) error {
 		r.ctx = ctx
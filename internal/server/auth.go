@@ -14,20 +14,91 @@ import (
 // An interface implemented by something that wishes to authenticate the server
 // actions.
 type AuthChecker interface {
-	// Called before each RPC to authenticate it.
-	Authenticate(ctx context.Context, token, endpoint string, effects []string) error
+	// Called before each RPC to authenticate it. The returned context is
+	// passed to the handler, this can be used to attach information about
+	// the authenticated user.
+	Authenticate(ctx context.Context, token, endpoint string, effects []string) (context.Context, error)
+}
+
+// An interface implemented by an AuthChecker that wishes to record the
+// mutating actions taken against the server.
+type AuditLogger interface {
+	// Called after each authenticated RPC that mutates data. The context is
+	// the one returned by Authenticate and err is the result of the RPC.
+	Audit(ctx context.Context, endpoint string, err error)
 }
 
 var readonly = []string{"readonly"}
 
+// Runner endpoints are called on behalf of queued jobs, which are
+// audited when they are queued.
+var runner = []string{"runner"}
+
 // Information about the effects of endpoints that are authenticated. If a endpoint
 // is not listed, the DefaultEffect value is used.
 var Effects = map[string][]string{
-	"ListBuilds": readonly,
+	"ListBuilds":          readonly,
+	"ListTokens":          readonly,
+	"ListUsers":           readonly,
+	"ListAuditEvents":     readonly,
+	"GetVersionInfo":      readonly,
+	"GetBasis":            readonly,
+	"FindBasis":           readonly,
+	"ListBasis":           readonly,
+	"ListTasks":           readonly,
+	"GetTask":             readonly,
+	"GetLatestTask":       readonly,
+	"GetProject":          readonly,
+	"FindProject":         readonly,
+	"ListProjects":        readonly,
+	"GetTarget":           readonly,
+	"FindTarget":          readonly,
+	"ListTargets":         readonly,
+	"GetBox":              readonly,
+	"ListBoxes":           readonly,
+	"FindBox":             readonly,
+	"GetVagrantfileCache": readonly,
+	"GetLogStream":        readonly,
+	"GetConfig":           readonly,
+	"GetJob":              readonly,
+	"ValidateJob":         readonly,
+	"GetJobStream":        readonly,
+	"GetRunner":           readonly,
+	"GetServerConfig":     readonly,
+	"CreateSnapshot":      readonly,
+	"GetBlob":             readonly,
+	"RunnerConfig":        runner,
+	"RunnerJobStream":     runner,
+	"AcquireTargetLease":  runner,
+	"RenewTargetLease":    runner,
+	"ReleaseTargetLease":  runner,
 }
 
 var DefaultEffects = []string{"mutable"}
 
+// unauthenticated returns true if the method is part of a service that
+// does not require authentication.
+func unauthenticated(method string) bool {
+	return strings.HasPrefix(method, "/grpc.reflection.v1alpha.ServerReflection/") ||
+		strings.HasPrefix(method, "/grpc.health.v1.Health/")
+}
+
+// audit records the result of an RPC with the checker if it is an
+// AuditLogger and the endpoint mutates data.
+func audit(ctx context.Context, checker AuthChecker, name string, effects []string, err error) {
+	logger, ok := checker.(AuditLogger)
+	if !ok {
+		return
+	}
+
+	for _, effect := range effects {
+		if effect == "mutable" {
+			logger.Audit(ctx, name, err)
+			return
+		}
+	}
+}
+
 // authUnaryInterceptor returns a gRPC unary interceptor that inspects the metadata
 // attached to the context. A token is extract from that metadata and the given
 // AuthChecker is invoked to guard calling the target handler. Effectively
@@ -38,8 +109,8 @@ func authUnaryInterceptor(checker AuthChecker) grpc.UnaryServerInterceptor {
 		req interface{},
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler) (interface{}, error) {
-		// Allow reflection API to be unauthenticated
-		if strings.HasPrefix(info.FullMethod, "/grpc.reflection.v1alpha.ServerReflection/") {
+		// Allow reflection and health APIs to be unauthenticated
+		if unauthenticated(info.FullMethod) {
 			return handler(ctx, req)
 		}
 
@@ -60,11 +131,14 @@ func authUnaryInterceptor(checker AuthChecker) grpc.UnaryServerInterceptor {
 			token = authHeader[0]
 		}
 
-		err := checker.Authenticate(ctx, token, name, effects)
+		ctx, err := checker.Authenticate(ctx, token, name, effects)
 		if err != nil {
 			return nil, err
 		}
-		return handler(ctx, req)
+
+		resp, err := handler(ctx, req)
+		audit(ctx, checker, name, effects, err)
+		return resp, err
 	}
 }
 
@@ -78,8 +152,8 @@ func authStreamInterceptor(checker AuthChecker) grpc.StreamServerInterceptor {
 		ss grpc.ServerStream,
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler) error {
-		// Allow reflection API to be unauthenticated
-		if strings.HasPrefix(info.FullMethod, "/grpc.reflection.v1alpha.ServerReflection/") {
+		// Allow reflection and health APIs to be unauthenticated
+		if unauthenticated(info.FullMethod) {
 			return handler(srv, ss)
 		}
 
@@ -95,19 +169,24 @@ func authStreamInterceptor(checker AuthChecker) grpc.StreamServerInterceptor {
 			return status.Errorf(codes.InvalidArgument, "Retrieving metadata is failed")
 		}
 
-		authHeader, ok := md["authorization"]
-		if !ok {
-			return status.Errorf(codes.Unauthenticated, "Authorization token is not supplied")
+		// A missing token is left to the checker since the client may
+		// have been identified by its certificate.
+		var token string
+		if authHeader, ok := md["authorization"]; ok {
+			token = authHeader[0]
 		}
 
-		token := authHeader[0]
-
-		err := checker.Authenticate(ss.Context(), token, name, effects)
+		ctx, err := checker.Authenticate(ss.Context(), token, name, effects)
 		if err != nil {
 			return err
 		}
+		if ctx != ss.Context() {
+			ss = &contextServerStream{ServerStream: ss, ctx: ctx}
+		}
 
 		// Invoke the handler.
-		return handler(srv, ss)
+		err = handler(srv, ss)
+		audit(ctx, checker, name, effects, err)
+		return err
 	}
 }
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// identityKey is the context key for the identity of a client that
+// authenticated with a certificate.
+type identityKey struct{}
+
+// IdentityFromContext returns the identity of a client that presented a
+// verified client certificate. This is only set if the server is
+// configured with WithClientCertAuth. If the client was not identified
+// by a certificate, an empty string is returned.
+func IdentityFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(identityKey{}).(string)
+	return id
+}
+
+// certIdentity returns the identity from the verified client certificate
+// of the peer. This is the common name of the certificate subject, or the
+// full subject if there is no common name.
+func certIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ""
+	}
+
+	chains := info.State.VerifiedChains
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return ""
+	}
+
+	subject := chains[0][0].Subject
+	if subject.CommonName != "" {
+		return subject.CommonName
+	}
+
+	return subject.String()
+}
+
+// certIdentityUnaryInterceptor returns a gRPC unary interceptor that
+// adds the identity of a verified client certificate to the context
+// so it can be used by the AuthChecker.
+func certIdentityUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if id := certIdentity(ctx); id != "" {
+			ctx = context.WithValue(ctx, identityKey{}, id)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// certIdentityStreamInterceptor returns a gRPC stream interceptor that
+// adds the identity of a verified client certificate to the context
+// so it can be used by the AuthChecker.
+func certIdentityStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		if id := certIdentity(ss.Context()); id != "" {
+			ss = &contextServerStream{
+				ServerStream: ss,
+				ctx:          context.WithValue(ss.Context(), identityKey{}, id),
+			}
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// contextServerStream overrides the context of a server stream.
+type contextServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
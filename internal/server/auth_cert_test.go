@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/vagrant/internal/clicontext"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	"github.com/hashicorp/vagrant/internal/serverclient"
+	"github.com/hashicorp/vagrant/internal/serverconfig"
+)
+
+// identityAuth records the identity of authenticated calls and
+// requires either a token or a certificate identity.
+type identityAuth struct {
+	sync.Mutex
+
+	identity string
+}
+
+func (a *identityAuth) Authenticate(ctx context.Context, token, endpoint string, effects []string) (context.Context, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	a.identity = IdentityFromContext(ctx)
+	if a.identity == "" && token == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "Authorization token is not supplied")
+	}
+
+	return ctx, nil
+}
+
+type versionServer struct {
+	vagrant_server.UnimplementedVagrantServer
+}
+
+func (versionServer) GetVersionInfo(context.Context, *emptypb.Empty) (*vagrant_server.GetVersionInfoResponse, error) {
+	return testVersionInfoResponse(), nil
+}
+
+func TestClientCertAuth(t *testing.T) {
+	ca := NewTestCA(t)
+	serverCert, serverKey := ca.Issue(t, "server")
+	clientCert, clientKey := ca.Issue(t, "runner-1")
+
+	start := func(t *testing.T, certAuth bool) (string, *identityAuth) {
+		tlsConfig, err := TLSConfig(serverCert, serverKey, ca.CertFile)
+		require.NoError(t, err)
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { ln.Close() })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		auth := &identityAuth{}
+		go Run(
+			WithContext(ctx),
+			WithGRPC(ln),
+			WithImpl(versionServer{}),
+			WithAuthentication(auth),
+			WithGRPCTLS(tlsConfig),
+			WithClientCertAuth(certAuth),
+		)
+
+		return ln.Addr().String(), auth
+	}
+
+	call := func(addr string, client serverconfig.Client) error {
+		client.Address = addr
+		client.Tls = true
+		client.TlsCAFile = ca.CertFile
+
+		conn, err := serverclient.Connect(context.Background(),
+			serverclient.FromContextConfig(&clicontext.Config{Server: client}),
+			serverclient.Timeout(2*time.Second),
+		)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		_, err = vagrant_server.NewVagrantClient(conn).GetVersionInfo(
+			context.Background(), &emptypb.Empty{})
+		return err
+	}
+
+	t.Run("identifies clients by certificate", func(t *testing.T) {
+		require := require.New(t)
+		addr, auth := start(t, true)
+
+		require.NoError(call(addr, serverconfig.Client{
+			TlsCertFile: clientCert,
+			TlsKeyFile:  clientKey,
+		}))
+
+		auth.Lock()
+		defer auth.Unlock()
+		require.Equal("runner-1", auth.identity)
+	})
+
+	t.Run("requires a token without certificate auth", func(t *testing.T) {
+		require := require.New(t)
+		addr, auth := start(t, false)
+
+		err := call(addr, serverconfig.Client{
+			TlsCertFile: clientCert,
+			TlsKeyFile:  clientKey,
+		})
+		require.Error(err)
+		require.Equal(codes.Unauthenticated, status.Code(err))
+
+		require.NoError(call(addr, serverconfig.Client{
+			TlsCertFile: clientCert,
+			TlsKeyFile:  clientKey,
+			RequireAuth: true,
+			AuthToken:   "token",
+		}))
+
+		auth.Lock()
+		defer auth.Unlock()
+		require.Empty(auth.identity)
+	})
+
+	t.Run("rejects clients without a certificate", func(t *testing.T) {
+		addr, _ := start(t, true)
+
+		require.Error(t, call(addr, serverconfig.Client{}))
+	})
+
+	t.Run("rejects certificates from other CAs", func(t *testing.T) {
+		addr, _ := start(t, true)
+
+		otherCert, otherKey := NewTestCA(t).Issue(t, "intruder")
+		require.Error(t, call(addr, serverconfig.Client{
+			TlsCertFile: otherCert,
+			TlsKeyFile:  otherKey,
+		}))
+	})
+}
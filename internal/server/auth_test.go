@@ -16,11 +16,11 @@ type trivialAuth struct {
 }
 
 // Called before each RPC to authenticate it.
-func (t *trivialAuth) Authenticate(ctx context.Context, token string, endpoint string, effects []string) error {
+func (t *trivialAuth) Authenticate(ctx context.Context, token string, endpoint string, effects []string) (context.Context, error) {
 	t.method = endpoint
 	t.token = token
 	t.effects = effects
-	return nil
+	return ctx, nil
 }
 
 func TestAuthUnaryInterceptor(t *testing.T) {
@@ -55,3 +55,47 @@ func TestAuthUnaryInterceptor(t *testing.T) {
 	require.Equal("bar", chk.method)
 	require.Equal(DefaultEffects, chk.effects)
 }
+
+type userKey struct{}
+
+// auditingAuth attaches a user to the context and records audited calls.
+type auditingAuth struct {
+	audited []string
+	users   []string
+}
+
+func (a *auditingAuth) Authenticate(ctx context.Context, token string, endpoint string, effects []string) (context.Context, error) {
+	return context.WithValue(ctx, userKey{}, "alice"), nil
+}
+
+func (a *auditingAuth) Audit(ctx context.Context, endpoint string, err error) {
+	a.audited = append(a.audited, endpoint)
+	a.users = append(a.users, ctx.Value(userKey{}).(string))
+}
+
+func TestAuthUnaryInterceptor_audit(t *testing.T) {
+	require := require.New(t)
+
+	var chk auditingAuth
+
+	f := authUnaryInterceptor(&chk)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+		"authorization": []string{"this-is-a-token"},
+	})
+
+	for _, method := range []string{"/foo/QueueJob", "/foo/GetJob"} {
+		_, err := f(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				// The context from Authenticate is passed to the handler
+				require.Equal("alice", ctx.Value(userKey{}))
+				return nil, nil
+			},
+		)
+		require.NoError(err)
+	}
+
+	// Only the mutating endpoint is audited
+	require.Equal([]string{"QueueJob"}, chk.audited)
+	require.Equal([]string{"alice"}, chk.users)
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/oklog/run"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/hashicorp/vagrant/internal/protocolversion"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// gatewayBufferSize is the buffer size of the in-memory connection between
+// the gateway and its gRPC server.
+const gatewayBufferSize = 1024 * 1024
+
+// gatewayInit sets up the JSON/HTTP gateway and adds its gRPC server to the
+// run group. The gateway calls the service over an in-memory connection so
+// requests go through the same interceptors, including authentication, as
+// calls to the gRPC listener. The returned handler serves the RPCs bound in
+// gateway.yaml under "/v1/" along with the OpenAPI document.
+func gatewayInit(group *run.Group, opts *options) (http.Handler, error) {
+	log := opts.Logger.Named("gateway")
+
+	s := grpc.NewServer(opts.grpcOptions...)
+	vagrant_server.RegisterVagrantServer(s, opts.Service)
+
+	ln := bufconn.Listen(gatewayBufferSize)
+	conn, err := grpc.DialContext(opts.Context, "bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return ln.DialContext(ctx)
+		}),
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(protocolversion.UnaryClientInterceptor(protocolversion.Current())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := runtime.NewServeMux()
+	if err := vagrant_server.RegisterVagrantHandler(opts.Context, mux, conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	group.Add(func() error {
+		log.Debug("starting gateway gRPC server")
+		return s.Serve(ln)
+	}, func(error) {
+		conn.Close()
+		s.Stop()
+	})
+
+	root := http.NewServeMux()
+	root.HandleFunc("/v1/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(vagrant_server.OpenAPI)
+	})
+	root.Handle("/v1/", gatewayAuthHandler(mux))
+
+	return root, nil
+}
+
+// gatewayAuthHandler strips the "Bearer" scheme from the Authorization
+// header, since the server expects the token alone.
+func gatewayAuthHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := r.Header.Get("Authorization"); v != "" {
+			if len(v) > 7 && strings.EqualFold(v[:7], "bearer ") {
+				r.Header.Set("Authorization", strings.TrimSpace(v[7:]))
+			}
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// gatewayServer is a service implementation for the gateway RPCs.
+type gatewayServer struct {
+	versionServer
+}
+
+func (gatewayServer) ListProjects(context.Context, *emptypb.Empty) (*vagrant_server.ListProjectsResponse, error) {
+	return &vagrant_server.ListProjectsResponse{
+		Projects: []*vagrant_plugin_sdk.Ref_Project{{Name: "web"}},
+	}, nil
+}
+
+func (gatewayServer) GetJob(ctx context.Context, req *vagrant_server.GetJobRequest) (*vagrant_server.Job, error) {
+	return &vagrant_server.Job{Id: req.JobId}, nil
+}
+
+// tokenAuth accepts a single token.
+type tokenAuth string
+
+func (a tokenAuth) Authenticate(ctx context.Context, token, endpoint string, effects []string) (context.Context, error) {
+	if token != string(a) {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
+	}
+
+	return ctx, nil
+}
+
+func TestGateway(t *testing.T) {
+	require := require.New(t)
+
+	grpcLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer grpcLn.Close()
+
+	httpLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer httpLn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go Run(
+		WithContext(ctx),
+		WithGRPC(grpcLn),
+		WithHTTP(httpLn),
+		WithImpl(gatewayServer{}),
+		WithAuthentication(tokenAuth("secret")),
+	)
+
+	get := func(path, token string, v interface{}) int {
+		req, err := http.NewRequest("GET", "http://"+httpLn.Addr().String()+path, nil)
+		require.NoError(err)
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(err)
+		defer resp.Body.Close()
+
+		if v != nil && resp.StatusCode == http.StatusOK {
+			require.NoError(json.NewDecoder(resp.Body).Decode(v))
+		}
+
+		return resp.StatusCode
+	}
+
+	// Calls require a token
+	require.Equal(http.StatusUnauthorized, get("/v1/projects", "", nil))
+	require.Equal(http.StatusUnauthorized, get("/v1/projects", "wrong", nil))
+
+	// The token can be given with or without the Bearer scheme
+	var projects struct {
+		Projects []struct{ Name string }
+	}
+	require.Equal(http.StatusOK, get("/v1/projects", "secret", &projects))
+	require.Len(projects.Projects, 1)
+	require.Equal("web", projects.Projects[0].Name)
+
+	var job struct{ Id string }
+	require.Equal(http.StatusOK, get("/v1/jobs/abc", "Bearer secret", &job))
+	require.Equal("abc", job.Id)
+
+	// The OpenAPI document is served without authentication
+	var doc struct {
+		Paths map[string]interface{}
+	}
+	require.Equal(http.StatusOK, get("/v1/openapi.json", "", &doc))
+	require.Contains(doc.Paths, "/v1/jobs/{jobId}")
+	require.Contains(doc.Paths, "/v1/jobs/{jobId}/cancel")
+}
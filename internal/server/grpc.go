@@ -5,6 +5,8 @@ import (
 
 	"github.com/oklog/run"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/types/known/emptypb"
 
@@ -21,12 +23,17 @@ func grpcInit(group *run.Group, opts *options) error {
 		return err
 	}
 
+	metrics := newGRPCMetrics(opts.MetricsRegistry)
+
 	var so []grpc.ServerOption
 	so = append(so,
 		grpc.ChainUnaryInterceptor(
 			// Insert our logger and also log req/resp
 			logUnaryInterceptor(log, false),
 
+			// Record request latencies
+			metrics.unaryInterceptor(),
+
 			// Protocol version negotiation
 			versionUnaryInterceptor(resp.Info),
 		),
@@ -34,11 +41,23 @@ func grpcInit(group *run.Group, opts *options) error {
 			// Insert our logger and log
 			logStreamInterceptor(log, false),
 
+			// Record stream durations
+			metrics.streamInterceptor(),
+
 			// Protocol version negotiation
 			versionStreamInterceptor(resp.Info),
 		),
 	)
 
+	// Identify clients by their certificates before authentication so
+	// the AuthChecker can use the identity.
+	if opts.ClientCertAuth {
+		so = append(so,
+			grpc.ChainUnaryInterceptor(certIdentityUnaryInterceptor()),
+			grpc.ChainStreamInterceptor(certIdentityStreamInterceptor()),
+		)
+	}
+
 	if opts.AuthChecker != nil {
 		so = append(so,
 			grpc.ChainUnaryInterceptor(authUnaryInterceptor(opts.AuthChecker)),
@@ -46,6 +65,25 @@ func grpcInit(group *run.Group, opts *options) error {
 		)
 	}
 
+	// If we have an idle timeout, track calls so we can shut down
+	// once the server is no longer being used.
+	if opts.IdleTimeout > 0 {
+		t := newIdleTracker()
+		so = append(so,
+			grpc.ChainUnaryInterceptor(t.unaryInterceptor()),
+			grpc.ChainStreamInterceptor(t.streamInterceptor()),
+		)
+		idleInit(group, opts, t)
+	}
+
+	// The HTTP gateway serves the service through the same interceptors
+	// over an in-memory connection, so it doesn't use the credentials.
+	opts.grpcOptions = so
+
+	if opts.GRPCTLSConfig != nil {
+		so = append(so, grpc.Creds(credentials.NewTLS(opts.GRPCTLSConfig)))
+	}
+
 	s := grpc.NewServer(so...)
 	opts.grpcServer = s
 
@@ -55,6 +93,10 @@ func grpcInit(group *run.Group, opts *options) error {
 	// easy enough.
 	reflection.Register(s)
 
+	// Register the standard health service so orchestrators can check
+	// whether the server is ready.
+	healthpb.RegisterHealthServer(s, opts.health.grpc)
+
 	// Register our server
 	vagrant_server.RegisterVagrantServer(s, opts.Service)
 
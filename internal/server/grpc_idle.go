@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/oklog/run"
+	"google.golang.org/grpc"
+)
+
+// idleTracker tracks in-flight gRPC calls so that the server can be shut
+// down after it has been idle for some period of time.
+type idleTracker struct {
+	mu     sync.Mutex
+	active int
+	last   time.Time
+}
+
+func newIdleTracker() *idleTracker {
+	return &idleTracker{last: time.Now()}
+}
+
+func (t *idleTracker) begin() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.active++
+}
+
+func (t *idleTracker) end() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.active--
+	t.last = time.Now()
+}
+
+// idle returns how long the server has had no calls in flight. This is
+// zero if there are currently calls in flight.
+func (t *idleTracker) idle() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.active > 0 {
+		return 0
+	}
+
+	return time.Since(t.last)
+}
+
+func (t *idleTracker) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		t.begin()
+		defer t.end()
+		return handler(ctx, req)
+	}
+}
+
+func (t *idleTracker) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		t.begin()
+		defer t.end()
+		return handler(srv, ss)
+	}
+}
+
+// idleInit adds an actor to the run group that exits once the server
+// has been idle for the configured timeout, stopping the server.
+func idleInit(group *run.Group, opts *options, t *idleTracker) {
+	log := opts.Logger.Named("idle")
+	timeout := opts.IdleTimeout
+
+	interval := timeout / 10
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	if interval > time.Second {
+		interval = time.Second
+	}
+
+	stopCh := make(chan struct{})
+	group.Add(func() error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stopCh:
+				return nil
+			case <-ticker.C:
+			}
+
+			if t.idle() >= timeout {
+				log.Info("server idle timeout reached, shutting down", "timeout", timeout)
+				return nil
+			}
+		}
+	}, func(error) {
+		close(stopCh)
+	})
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/oklog/run"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdleTracker(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newIdleTracker()
+	tracker.begin()
+	time.Sleep(10 * time.Millisecond)
+	require.Zero(tracker.idle())
+
+	tracker.end()
+	time.Sleep(10 * time.Millisecond)
+	require.True(tracker.idle() > 0)
+}
+
+func TestIdleInit(t *testing.T) {
+	t.Run("exits when idle", func(t *testing.T) {
+		require := require.New(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var group run.Group
+		group.Add(func() error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, func(error) { cancel() })
+
+		idleInit(&group, &options{
+			Logger:      hclog.L(),
+			IdleTimeout: 50 * time.Millisecond,
+		}, newIdleTracker())
+
+		doneCh := make(chan error, 1)
+		go func() { doneCh <- group.Run() }()
+
+		select {
+		case err := <-doneCh:
+			require.NoError(err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("server did not shut down when idle")
+		}
+	})
+
+	t.Run("stays up while calls are in flight", func(t *testing.T) {
+		require := require.New(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var group run.Group
+		group.Add(func() error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, func(error) { cancel() })
+
+		tracker := newIdleTracker()
+		tracker.begin()
+		idleInit(&group, &options{
+			Logger:      hclog.L(),
+			IdleTimeout: 50 * time.Millisecond,
+		}, tracker)
+
+		doneCh := make(chan error, 1)
+		go func() { doneCh <- group.Run() }()
+
+		select {
+		case <-doneCh:
+			t.Fatal("server shut down with calls in flight")
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		tracker.end()
+		select {
+		case err := <-doneCh:
+			require.NoError(err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("server did not shut down when idle")
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// grpcMetrics tracks the latency of gRPC requests by method and
+// response code.
+type grpcMetrics struct {
+	latency *prometheus.HistogramVec
+}
+
+func newGRPCMetrics(reg prometheus.Registerer) *grpcMetrics {
+	m := &grpcMetrics{
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "vagrant_server",
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of gRPC requests handled by the server.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+	}
+	reg.MustRegister(m.latency)
+
+	return m
+}
+
+func (m *grpcMetrics) observe(method string, start time.Time, err error) {
+	m.latency.WithLabelValues(method, status.Code(err).String()).
+		Observe(time.Since(start).Seconds())
+}
+
+// unaryInterceptor returns a gRPC unary interceptor that records the
+// latency of each request.
+func (m *grpcMetrics) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observe(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// streamInterceptor returns a gRPC stream interceptor that records the
+// duration of each stream.
+func (m *grpcMetrics) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.observe(info.FullMethod, start, err)
+		return err
+	}
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/oklog/run"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// An interface implemented by a service that can report whether it is
+// able to serve requests.
+type HealthChecker interface {
+	// Called to check the health of the service. A non-nil error means
+	// the service is unable to serve requests.
+	CheckHealth(ctx context.Context) error
+}
+
+// An interface implemented by a service that can stop taking on new work
+// before the server shuts down.
+type Drainer interface {
+	// Called once when the server starts draining. The service should stop
+	// starting new work and return once the work in progress is complete
+	// or ctx is done.
+	Drain(ctx context.Context) error
+}
+
+// healthCheckInterval is how often the gRPC health status is updated.
+const healthCheckInterval = 10 * time.Second
+
+// errDraining is the readiness error of a server that is draining.
+var errDraining = errors.New("server is draining")
+
+// healthState tracks the health of the server. It backs both the gRPC
+// health service and the HTTP health endpoints.
+type healthState struct {
+	checker  HealthChecker
+	grpc     *health.Server
+	draining int32
+}
+
+func newHealthState(impl vagrant_server.VagrantServer) *healthState {
+	h := &healthState{grpc: health.NewServer()}
+	h.checker, _ = impl.(HealthChecker)
+	return h
+}
+
+// live returns an error if the service is unhealthy.
+func (h *healthState) live(ctx context.Context) error {
+	if h.checker == nil {
+		return nil
+	}
+
+	return h.checker.CheckHealth(ctx)
+}
+
+// ready returns an error if the server should not be sent requests. This
+// is the case if the service is unhealthy or the server is draining.
+func (h *healthState) ready(ctx context.Context) error {
+	if atomic.LoadInt32(&h.draining) != 0 {
+		return errDraining
+	}
+
+	return h.live(ctx)
+}
+
+// update sets the gRPC health status of the server from its readiness.
+func (h *healthState) update(ctx context.Context) {
+	st := healthpb.HealthCheckResponse_SERVING
+	if h.ready(ctx) != nil {
+		st = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	h.grpc.SetServingStatus("", st)
+	h.grpc.SetServingStatus(vagrant_server.Vagrant_ServiceDesc.ServiceName, st)
+}
+
+// drain marks the server as draining so it reports as not ready.
+func (h *healthState) drain(ctx context.Context) {
+	atomic.StoreInt32(&h.draining, 1)
+	h.update(ctx)
+}
+
+// handler returns an HTTP handler that responds with 200 if check
+// succeeds and 503 otherwise.
+func (h *healthState) handler(check func(context.Context) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+
+		if err := check(r.Context()); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(err.Error() + "\n"))
+			return
+		}
+
+		w.Write([]byte("ok\n"))
+	})
+}
+
+// healthInit adds an actor to the run group which keeps the gRPC health
+// status up to date, and one which drains the server when requested.
+func healthInit(group *run.Group, opts *options) {
+	h := opts.health
+	log := opts.Logger.Named("health")
+
+	ctx, cancel := context.WithCancel(opts.Context)
+	group.Add(func() error {
+		tk := time.NewTicker(healthCheckInterval)
+		defer tk.Stop()
+
+		for {
+			h.update(ctx)
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-tk.C:
+			}
+		}
+	}, func(error) {
+		cancel()
+		h.grpc.Shutdown()
+	})
+
+	if opts.DrainCh == nil {
+		return
+	}
+
+	drainCtx, drainCancel := context.WithCancel(opts.Context)
+	group.Add(func() error {
+		select {
+		case <-drainCtx.Done():
+			return drainCtx.Err()
+		case <-opts.DrainCh:
+		}
+
+		log.Info("draining server", "timeout", opts.DrainTimeout.String())
+		h.drain(drainCtx)
+
+		if d, ok := opts.Service.(Drainer); ok {
+			ctx := drainCtx
+			if opts.DrainTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(drainCtx, opts.DrainTimeout)
+				defer cancel()
+			}
+
+			if err := d.Drain(ctx); err != nil {
+				log.Warn("server did not finish draining", "error", err)
+			}
+		}
+
+		// Returning ends the run group and so shuts down the server.
+		log.Info("server drained")
+		return nil
+	}, func(error) { drainCancel() })
+}
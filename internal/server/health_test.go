@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// drainServer is a service implementation which reports its health and
+// blocks draining until released.
+type drainServer struct {
+	versionServer
+
+	unhealthy int32
+	draining  chan struct{}
+	release   chan struct{}
+}
+
+func (s *drainServer) CheckHealth(ctx context.Context) error {
+	if atomic.LoadInt32(&s.unhealthy) != 0 {
+		return errors.New("unhealthy")
+	}
+
+	return nil
+}
+
+func (s *drainServer) Drain(ctx context.Context) error {
+	close(s.draining)
+	<-s.release
+	return nil
+}
+
+func TestHealth(t *testing.T) {
+	require := require.New(t)
+
+	grpcLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer grpcLn.Close()
+
+	httpLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer httpLn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	impl := &drainServer{
+		draining: make(chan struct{}),
+		release:  make(chan struct{}),
+	}
+	drainCh := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(
+			WithContext(ctx),
+			WithGRPC(grpcLn),
+			WithHTTP(httpLn),
+			WithImpl(impl),
+			WithDrain(drainCh, time.Minute),
+		)
+	}()
+
+	conn, err := grpc.DialContext(ctx, grpcLn.Addr().String(),
+		grpc.WithInsecure(),
+		grpc.WithBlock(),
+		grpc.WithTimeout(5*time.Second),
+	)
+	require.NoError(err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	grpcStatus := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(err)
+		return resp.Status
+	}
+	httpStatus := func(path string) int {
+		resp, err := http.Get("http://" + httpLn.Addr().String() + path)
+		require.NoError(err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	require.Equal(healthpb.HealthCheckResponse_SERVING, grpcStatus())
+	require.Equal(http.StatusOK, httpStatus("/healthz"))
+	require.Equal(http.StatusOK, httpStatus("/readyz"))
+
+	// An unhealthy service fails both checks
+	atomic.StoreInt32(&impl.unhealthy, 1)
+	require.Equal(http.StatusServiceUnavailable, httpStatus("/healthz"))
+	require.Equal(http.StatusServiceUnavailable, httpStatus("/readyz"))
+	atomic.StoreInt32(&impl.unhealthy, 0)
+
+	// A draining server is alive but not ready
+	close(drainCh)
+	<-impl.draining
+	require.Equal(healthpb.HealthCheckResponse_NOT_SERVING, grpcStatus())
+	require.Equal(http.StatusOK, httpStatus("/healthz"))
+	require.Equal(http.StatusServiceUnavailable, httpStatus("/readyz"))
+
+	// The server exits once drained
+	close(impl.release)
+	select {
+	case err := <-errCh:
+		require.NoError(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not exit after draining")
+	}
+}
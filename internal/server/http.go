@@ -39,7 +39,7 @@ func httpInit(group *run.Group, opts *options) error {
 
 	// If the path has a grpc prefix we assume it's a GRPC gateway request,
 	// otherwise fall back to serving the UI from the filesystem
-	rootHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	grpcHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// if strings.HasPrefix(r.URL.Path, "/grpc") {
 		grpcWrapped.ServeHTTP(w, r)
 		// } else if opts.BrowserUIEnabled {
@@ -47,6 +47,20 @@ func httpInit(group *run.Group, opts *options) error {
 		// }
 	})
 
+	// The JSON/HTTP gateway for the read-heavy and job RPCs
+	gateway, err := gatewayInit(group, opts)
+	if err != nil {
+		return err
+	}
+
+	// Metrics and health checks are served alongside the gRPC-web endpoints
+	rootHandler := http.NewServeMux()
+	rootHandler.Handle("/v1/", gateway)
+	rootHandler.Handle("/metrics", metricsHandler(opts))
+	rootHandler.Handle("/healthz", opts.health.handler(opts.health.live))
+	rootHandler.Handle("/readyz", opts.health.handler(opts.health.ready))
+	rootHandler.Handle("/", grpcHandler)
+
 	// Create our http server
 	httpSrv := &http.Server{
 		ReadHeaderTimeout: 5 * time.Second,
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsInit sets up the registry that the server metrics are collected
+// into. If the service implementation is a prometheus.Collector then its
+// metrics are included as well.
+func metricsInit(opts *options) error {
+	if opts.MetricsRegistry == nil {
+		reg := prometheus.NewRegistry()
+		reg.MustRegister(
+			prometheus.NewGoCollector(),
+			prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		)
+
+		opts.MetricsRegistry = reg
+	}
+
+	if c, ok := opts.Service.(prometheus.Collector); ok {
+		if err := opts.MetricsRegistry.Register(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// metricsHandler returns the handler that serves the server metrics in
+// the Prometheus text format.
+func metricsHandler(opts *options) http.Handler {
+	return promhttp.HandlerFor(opts.MetricsRegistry, promhttp.HandlerOpts{
+		ErrorLog: opts.Logger.Named("metrics").StandardLogger(nil),
+	})
+}
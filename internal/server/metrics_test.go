@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+var testMetricDesc = prometheus.NewDesc("vagrant_test_value", "Test value.", nil, nil)
+
+// metricsServer is a service implementation which also collects metrics.
+type metricsServer struct {
+	versionServer
+}
+
+func (metricsServer) Describe(ch chan<- *prometheus.Desc) {
+	ch <- testMetricDesc
+}
+
+func (metricsServer) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(testMetricDesc, prometheus.GaugeValue, 42)
+}
+
+func TestMetrics(t *testing.T) {
+	require := require.New(t)
+
+	grpcLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer grpcLn.Close()
+
+	httpLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer httpLn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go Run(
+		WithContext(ctx),
+		WithGRPC(grpcLn),
+		WithHTTP(httpLn),
+		WithImpl(metricsServer{}),
+	)
+
+	conn, err := grpc.DialContext(ctx, grpcLn.Addr().String(),
+		grpc.WithInsecure(),
+		grpc.WithBlock(),
+		grpc.WithTimeout(5*time.Second),
+	)
+	require.NoError(err)
+	defer conn.Close()
+
+	_, err = vagrant_server.NewVagrantClient(conn).GetVersionInfo(ctx, &emptypb.Empty{})
+	require.NoError(err)
+
+	resp, err := http.Get("http://" + httpLn.Addr().String() + "/metrics")
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.Equal(http.StatusOK, resp.StatusCode)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(err)
+
+	require.Contains(string(body),
+		`vagrant_server_grpc_request_duration_seconds_count{code="OK",method="/hashicorp.vagrant.Vagrant/GetVersionInfo"} 1`)
+	require.Contains(string(body), "vagrant_test_value 42")
+	require.Contains(string(body), "go_goroutines")
+}
@@ -0,0 +1,9 @@
+package vagrant_server
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI document for the JSON/HTTP gateway. It is
+// generated from server.proto with the bindings in gateway.yaml.
+//
+//go:embed server.swagger.json
+var OpenAPI []byte
@@ -52,12 +52,32 @@ type VagrantClient interface {
 	GetTarget(ctx context.Context, in *GetTargetRequest, opts ...grpc.CallOption) (*GetTargetResponse, error)
 	FindTarget(ctx context.Context, in *FindTargetRequest, opts ...grpc.CallOption) (*FindTargetResponse, error)
 	ListTargets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTargetsResponse, error)
+	// AcquireTargetLease takes an advisory lease on a target so that only
+	// one operation runs against it at a time. The lease expires unless it
+	// is renewed before its TTL passes, so a lease is not held forever by
+	// a process which dies. If another holder has an unexpired lease on the
+	// target, this fails with a FailedPrecondition error naming the holder.
+	// If the same job already holds the lease, that lease is returned and
+	// must be released once for each time it was acquired.
+	AcquireTargetLease(ctx context.Context, in *AcquireTargetLeaseRequest, opts ...grpc.CallOption) (*AcquireTargetLeaseResponse, error)
+	// RenewTargetLease extends the expiration of a lease. This fails with a
+	// NotFound error if the lease has been released or has expired.
+	RenewTargetLease(ctx context.Context, in *RenewTargetLeaseRequest, opts ...grpc.CallOption) (*RenewTargetLeaseResponse, error)
+	// ReleaseTargetLease releases a lease. Releasing a lease which does not
+	// exist, such as one which has expired, does nothing.
+	ReleaseTargetLease(ctx context.Context, in *ReleaseTargetLeaseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// CRUD operations for box
 	UpsertBox(ctx context.Context, in *UpsertBoxRequest, opts ...grpc.CallOption) (*UpsertBoxResponse, error)
 	DeleteBox(ctx context.Context, in *DeleteBoxRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetBox(ctx context.Context, in *GetBoxRequest, opts ...grpc.CallOption) (*GetBoxResponse, error)
 	ListBoxes(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListBoxesResponse, error)
 	FindBox(ctx context.Context, in *FindBoxRequest, opts ...grpc.CallOption) (*FindBoxResponse, error)
+	// GetVagrantfileCache returns the cached parse of a Vagrantfile by
+	// its key. This returns a NotFound error if there is no such entry.
+	GetVagrantfileCache(ctx context.Context, in *GetVagrantfileCacheRequest, opts ...grpc.CallOption) (*GetVagrantfileCacheResponse, error)
+	// UpsertVagrantfileCache caches the parse of a Vagrantfile. This replaces
+	// any entry cached for the same Vagrantfile path.
+	UpsertVagrantfileCache(ctx context.Context, in *UpsertVagrantfileCacheRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// GetLogStream reads the log stream for a deployment. This will immediately
 	// send a single LogEntry with the lines we have so far. If there are no
 	// available lines this will NOT block and instead will return an error.
@@ -95,6 +115,9 @@ type VagrantClient interface {
 	GetJobStream(ctx context.Context, in *GetJobStreamRequest, opts ...grpc.CallOption) (Vagrant_GetJobStreamClient, error)
 	// Clean out old jobs from the job database
 	PruneOldJobs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Remove projects whose path no longer exists and targets which are
+	// no longer defined by the Vagrantfile of their project
+	GarbageCollect(ctx context.Context, in *GarbageCollectRequest, opts ...grpc.CallOption) (*GarbageCollectResponse, error)
 	// GetRunner gets information about a single runner.
 	GetRunner(ctx context.Context, in *GetRunnerRequest, opts ...grpc.CallOption) (*Runner, error)
 	// GetServerConfig sets configuration for the Vagrant server.
@@ -108,6 +131,12 @@ type VagrantClient interface {
 	// for the next server start to finalize the restore. See the arguments for
 	// more information.
 	RestoreSnapshot(ctx context.Context, opts ...grpc.CallOption) (Vagrant_RestoreSnapshotClient, error)
+	// UploadBlob stores a blob of data on the server. Blobs are content
+	// addressed by their SHA-256 digest and are removed once no jobs
+	// reference them.
+	UploadBlob(ctx context.Context, opts ...grpc.CallOption) (Vagrant_UploadBlobClient, error)
+	// GetBlob streams the contents of a previously uploaded blob.
+	GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (Vagrant_GetBlobClient, error)
 	// BootstrapToken returns the initial token for the server. This can only
 	// be requested once on first startup. After initial request this will
 	// always return a PermissionDenied error.
@@ -115,9 +144,26 @@ type VagrantClient interface {
 	// Generate a new invite token that users can exchange for a login token.
 	GenerateInviteToken(ctx context.Context, in *InviteTokenRequest, opts ...grpc.CallOption) (*NewTokenResponse, error)
 	// Generate a new login token that users can use to login directly.
-	GenerateLoginToken(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NewTokenResponse, error)
+	GenerateLoginToken(ctx context.Context, in *LoginTokenRequest, opts ...grpc.CallOption) (*NewTokenResponse, error)
 	// Exchange a invite token for a login token.
 	ConvertInviteToken(ctx context.Context, in *ConvertInviteTokenRequest, opts ...grpc.CallOption) (*NewTokenResponse, error)
+	// ListTokens returns the tokens issued by the server. The tokens themselves
+	// are never stored, only information about them.
+	ListTokens(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTokensResponse, error)
+	// RevokeToken revokes a single token. A revoked token is rejected even
+	// if it has not expired.
+	RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RotateHMACKey creates a new key to sign tokens with. Tokens signed
+	// with the previous keys remain valid for the requested grace period.
+	RotateHMACKey(ctx context.Context, in *RotateHMACKeyRequest, opts ...grpc.CallOption) (*RotateHMACKeyResponse, error)
+	// ListUsers returns the users known to the server. Users are created
+	// when they exchange an invite token for a login token.
+	ListUsers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	// DeleteUser deletes a user. Any tokens of the user are rejected from then on.
+	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListAuditEvents returns the recorded calls to endpoints which mutate
+	// data, most recent first.
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
 	// RunnerConfig is called to register a runner and receive the configuration
 	// for the runner. The response is a stream so that the configuration can
 	// be updated later.
@@ -297,6 +343,33 @@ func (c *vagrantClient) ListTargets(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *vagrantClient) AcquireTargetLease(ctx context.Context, in *AcquireTargetLeaseRequest, opts ...grpc.CallOption) (*AcquireTargetLeaseResponse, error) {
+	out := new(AcquireTargetLeaseResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/AcquireTargetLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vagrantClient) RenewTargetLease(ctx context.Context, in *RenewTargetLeaseRequest, opts ...grpc.CallOption) (*RenewTargetLeaseResponse, error) {
+	out := new(RenewTargetLeaseResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/RenewTargetLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vagrantClient) ReleaseTargetLease(ctx context.Context, in *ReleaseTargetLeaseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/ReleaseTargetLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vagrantClient) UpsertBox(ctx context.Context, in *UpsertBoxRequest, opts ...grpc.CallOption) (*UpsertBoxResponse, error) {
 	out := new(UpsertBoxResponse)
 	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/UpsertBox", in, out, opts...)
@@ -342,6 +415,24 @@ func (c *vagrantClient) FindBox(ctx context.Context, in *FindBoxRequest, opts ..
 	return out, nil
 }
 
+func (c *vagrantClient) GetVagrantfileCache(ctx context.Context, in *GetVagrantfileCacheRequest, opts ...grpc.CallOption) (*GetVagrantfileCacheResponse, error) {
+	out := new(GetVagrantfileCacheResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/GetVagrantfileCache", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vagrantClient) UpsertVagrantfileCache(ctx context.Context, in *UpsertVagrantfileCacheRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/UpsertVagrantfileCache", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vagrantClient) GetLogStream(ctx context.Context, in *GetLogStreamRequest, opts ...grpc.CallOption) (Vagrant_GetLogStreamClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Vagrant_ServiceDesc.Streams[0], "/hashicorp.vagrant.Vagrant/GetLogStream", opts...)
 	if err != nil {
@@ -478,6 +569,15 @@ func (c *vagrantClient) PruneOldJobs(ctx context.Context, in *emptypb.Empty, opt
 	return out, nil
 }
 
+func (c *vagrantClient) GarbageCollect(ctx context.Context, in *GarbageCollectRequest, opts ...grpc.CallOption) (*GarbageCollectResponse, error) {
+	out := new(GarbageCollectResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/GarbageCollect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vagrantClient) GetRunner(ctx context.Context, in *GetRunnerRequest, opts ...grpc.CallOption) (*Runner, error) {
 	out := new(Runner)
 	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/GetRunner", in, out, opts...)
@@ -571,6 +671,72 @@ func (x *vagrantRestoreSnapshotClient) CloseAndRecv() (*emptypb.Empty, error) {
 	return m, nil
 }
 
+func (c *vagrantClient) UploadBlob(ctx context.Context, opts ...grpc.CallOption) (Vagrant_UploadBlobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Vagrant_ServiceDesc.Streams[4], "/hashicorp.vagrant.Vagrant/UploadBlob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vagrantUploadBlobClient{stream}
+	return x, nil
+}
+
+type Vagrant_UploadBlobClient interface {
+	Send(*UploadBlobRequest) error
+	CloseAndRecv() (*UploadBlobResponse, error)
+	grpc.ClientStream
+}
+
+type vagrantUploadBlobClient struct {
+	grpc.ClientStream
+}
+
+func (x *vagrantUploadBlobClient) Send(m *UploadBlobRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *vagrantUploadBlobClient) CloseAndRecv() (*UploadBlobResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadBlobResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vagrantClient) GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (Vagrant_GetBlobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Vagrant_ServiceDesc.Streams[5], "/hashicorp.vagrant.Vagrant/GetBlob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vagrantGetBlobClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Vagrant_GetBlobClient interface {
+	Recv() (*GetBlobResponse, error)
+	grpc.ClientStream
+}
+
+type vagrantGetBlobClient struct {
+	grpc.ClientStream
+}
+
+func (x *vagrantGetBlobClient) Recv() (*GetBlobResponse, error) {
+	m := new(GetBlobResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *vagrantClient) BootstrapToken(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NewTokenResponse, error) {
 	out := new(NewTokenResponse)
 	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/BootstrapToken", in, out, opts...)
@@ -589,7 +755,7 @@ func (c *vagrantClient) GenerateInviteToken(ctx context.Context, in *InviteToken
 	return out, nil
 }
 
-func (c *vagrantClient) GenerateLoginToken(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NewTokenResponse, error) {
+func (c *vagrantClient) GenerateLoginToken(ctx context.Context, in *LoginTokenRequest, opts ...grpc.CallOption) (*NewTokenResponse, error) {
 	out := new(NewTokenResponse)
 	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/GenerateLoginToken", in, out, opts...)
 	if err != nil {
@@ -607,8 +773,62 @@ func (c *vagrantClient) ConvertInviteToken(ctx context.Context, in *ConvertInvit
 	return out, nil
 }
 
+func (c *vagrantClient) ListTokens(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTokensResponse, error) {
+	out := new(ListTokensResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/ListTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vagrantClient) RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/RevokeToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vagrantClient) RotateHMACKey(ctx context.Context, in *RotateHMACKeyRequest, opts ...grpc.CallOption) (*RotateHMACKeyResponse, error) {
+	out := new(RotateHMACKeyResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/RotateHMACKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vagrantClient) ListUsers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/ListUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vagrantClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/DeleteUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vagrantClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.vagrant.Vagrant/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vagrantClient) RunnerConfig(ctx context.Context, opts ...grpc.CallOption) (Vagrant_RunnerConfigClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Vagrant_ServiceDesc.Streams[4], "/hashicorp.vagrant.Vagrant/RunnerConfig", opts...)
+	stream, err := c.cc.NewStream(ctx, &Vagrant_ServiceDesc.Streams[6], "/hashicorp.vagrant.Vagrant/RunnerConfig", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -639,7 +859,7 @@ func (x *vagrantRunnerConfigClient) Recv() (*RunnerConfigResponse, error) {
 }
 
 func (c *vagrantClient) RunnerJobStream(ctx context.Context, opts ...grpc.CallOption) (Vagrant_RunnerJobStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Vagrant_ServiceDesc.Streams[5], "/hashicorp.vagrant.Vagrant/RunnerJobStream", opts...)
+	stream, err := c.cc.NewStream(ctx, &Vagrant_ServiceDesc.Streams[7], "/hashicorp.vagrant.Vagrant/RunnerJobStream", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -706,12 +926,32 @@ type VagrantServer interface {
 	GetTarget(context.Context, *GetTargetRequest) (*GetTargetResponse, error)
 	FindTarget(context.Context, *FindTargetRequest) (*FindTargetResponse, error)
 	ListTargets(context.Context, *emptypb.Empty) (*ListTargetsResponse, error)
+	// AcquireTargetLease takes an advisory lease on a target so that only
+	// one operation runs against it at a time. The lease expires unless it
+	// is renewed before its TTL passes, so a lease is not held forever by
+	// a process which dies. If another holder has an unexpired lease on the
+	// target, this fails with a FailedPrecondition error naming the holder.
+	// If the same job already holds the lease, that lease is returned and
+	// must be released once for each time it was acquired.
+	AcquireTargetLease(context.Context, *AcquireTargetLeaseRequest) (*AcquireTargetLeaseResponse, error)
+	// RenewTargetLease extends the expiration of a lease. This fails with a
+	// NotFound error if the lease has been released or has expired.
+	RenewTargetLease(context.Context, *RenewTargetLeaseRequest) (*RenewTargetLeaseResponse, error)
+	// ReleaseTargetLease releases a lease. Releasing a lease which does not
+	// exist, such as one which has expired, does nothing.
+	ReleaseTargetLease(context.Context, *ReleaseTargetLeaseRequest) (*emptypb.Empty, error)
 	// CRUD operations for box
 	UpsertBox(context.Context, *UpsertBoxRequest) (*UpsertBoxResponse, error)
 	DeleteBox(context.Context, *DeleteBoxRequest) (*emptypb.Empty, error)
 	GetBox(context.Context, *GetBoxRequest) (*GetBoxResponse, error)
 	ListBoxes(context.Context, *emptypb.Empty) (*ListBoxesResponse, error)
 	FindBox(context.Context, *FindBoxRequest) (*FindBoxResponse, error)
+	// GetVagrantfileCache returns the cached parse of a Vagrantfile by
+	// its key. This returns a NotFound error if there is no such entry.
+	GetVagrantfileCache(context.Context, *GetVagrantfileCacheRequest) (*GetVagrantfileCacheResponse, error)
+	// UpsertVagrantfileCache caches the parse of a Vagrantfile. This replaces
+	// any entry cached for the same Vagrantfile path.
+	UpsertVagrantfileCache(context.Context, *UpsertVagrantfileCacheRequest) (*emptypb.Empty, error)
 	// GetLogStream reads the log stream for a deployment. This will immediately
 	// send a single LogEntry with the lines we have so far. If there are no
 	// available lines this will NOT block and instead will return an error.
@@ -749,6 +989,9 @@ type VagrantServer interface {
 	GetJobStream(*GetJobStreamRequest, Vagrant_GetJobStreamServer) error
 	// Clean out old jobs from the job database
 	PruneOldJobs(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Remove projects whose path no longer exists and targets which are
+	// no longer defined by the Vagrantfile of their project
+	GarbageCollect(context.Context, *GarbageCollectRequest) (*GarbageCollectResponse, error)
 	// GetRunner gets information about a single runner.
 	GetRunner(context.Context, *GetRunnerRequest) (*Runner, error)
 	// GetServerConfig sets configuration for the Vagrant server.
@@ -762,6 +1005,12 @@ type VagrantServer interface {
 	// for the next server start to finalize the restore. See the arguments for
 	// more information.
 	RestoreSnapshot(Vagrant_RestoreSnapshotServer) error
+	// UploadBlob stores a blob of data on the server. Blobs are content
+	// addressed by their SHA-256 digest and are removed once no jobs
+	// reference them.
+	UploadBlob(Vagrant_UploadBlobServer) error
+	// GetBlob streams the contents of a previously uploaded blob.
+	GetBlob(*GetBlobRequest, Vagrant_GetBlobServer) error
 	// BootstrapToken returns the initial token for the server. This can only
 	// be requested once on first startup. After initial request this will
 	// always return a PermissionDenied error.
@@ -769,9 +1018,26 @@ type VagrantServer interface {
 	// Generate a new invite token that users can exchange for a login token.
 	GenerateInviteToken(context.Context, *InviteTokenRequest) (*NewTokenResponse, error)
 	// Generate a new login token that users can use to login directly.
-	GenerateLoginToken(context.Context, *emptypb.Empty) (*NewTokenResponse, error)
+	GenerateLoginToken(context.Context, *LoginTokenRequest) (*NewTokenResponse, error)
 	// Exchange a invite token for a login token.
 	ConvertInviteToken(context.Context, *ConvertInviteTokenRequest) (*NewTokenResponse, error)
+	// ListTokens returns the tokens issued by the server. The tokens themselves
+	// are never stored, only information about them.
+	ListTokens(context.Context, *emptypb.Empty) (*ListTokensResponse, error)
+	// RevokeToken revokes a single token. A revoked token is rejected even
+	// if it has not expired.
+	RevokeToken(context.Context, *RevokeTokenRequest) (*emptypb.Empty, error)
+	// RotateHMACKey creates a new key to sign tokens with. Tokens signed
+	// with the previous keys remain valid for the requested grace period.
+	RotateHMACKey(context.Context, *RotateHMACKeyRequest) (*RotateHMACKeyResponse, error)
+	// ListUsers returns the users known to the server. Users are created
+	// when they exchange an invite token for a login token.
+	ListUsers(context.Context, *emptypb.Empty) (*ListUsersResponse, error)
+	// DeleteUser deletes a user. Any tokens of the user are rejected from then on.
+	DeleteUser(context.Context, *DeleteUserRequest) (*emptypb.Empty, error)
+	// ListAuditEvents returns the recorded calls to endpoints which mutate
+	// data, most recent first.
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
 	// RunnerConfig is called to register a runner and receive the configuration
 	// for the runner. The response is a stream so that the configuration can
 	// be updated later.
@@ -839,6 +1105,15 @@ func (UnimplementedVagrantServer) FindTarget(context.Context, *FindTargetRequest
 func (UnimplementedVagrantServer) ListTargets(context.Context, *emptypb.Empty) (*ListTargetsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTargets not implemented")
 }
+func (UnimplementedVagrantServer) AcquireTargetLease(context.Context, *AcquireTargetLeaseRequest) (*AcquireTargetLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcquireTargetLease not implemented")
+}
+func (UnimplementedVagrantServer) RenewTargetLease(context.Context, *RenewTargetLeaseRequest) (*RenewTargetLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewTargetLease not implemented")
+}
+func (UnimplementedVagrantServer) ReleaseTargetLease(context.Context, *ReleaseTargetLeaseRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseTargetLease not implemented")
+}
 func (UnimplementedVagrantServer) UpsertBox(context.Context, *UpsertBoxRequest) (*UpsertBoxResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpsertBox not implemented")
 }
@@ -854,6 +1129,12 @@ func (UnimplementedVagrantServer) ListBoxes(context.Context, *emptypb.Empty) (*L
 func (UnimplementedVagrantServer) FindBox(context.Context, *FindBoxRequest) (*FindBoxResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindBox not implemented")
 }
+func (UnimplementedVagrantServer) GetVagrantfileCache(context.Context, *GetVagrantfileCacheRequest) (*GetVagrantfileCacheResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVagrantfileCache not implemented")
+}
+func (UnimplementedVagrantServer) UpsertVagrantfileCache(context.Context, *UpsertVagrantfileCacheRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertVagrantfileCache not implemented")
+}
 func (UnimplementedVagrantServer) GetLogStream(*GetLogStreamRequest, Vagrant_GetLogStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetLogStream not implemented")
 }
@@ -884,6 +1165,9 @@ func (UnimplementedVagrantServer) GetJobStream(*GetJobStreamRequest, Vagrant_Get
 func (UnimplementedVagrantServer) PruneOldJobs(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PruneOldJobs not implemented")
 }
+func (UnimplementedVagrantServer) GarbageCollect(context.Context, *GarbageCollectRequest) (*GarbageCollectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GarbageCollect not implemented")
+}
 func (UnimplementedVagrantServer) GetRunner(context.Context, *GetRunnerRequest) (*Runner, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRunner not implemented")
 }
@@ -899,18 +1183,42 @@ func (UnimplementedVagrantServer) CreateSnapshot(*emptypb.Empty, Vagrant_CreateS
 func (UnimplementedVagrantServer) RestoreSnapshot(Vagrant_RestoreSnapshotServer) error {
 	return status.Errorf(codes.Unimplemented, "method RestoreSnapshot not implemented")
 }
+func (UnimplementedVagrantServer) UploadBlob(Vagrant_UploadBlobServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadBlob not implemented")
+}
+func (UnimplementedVagrantServer) GetBlob(*GetBlobRequest, Vagrant_GetBlobServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetBlob not implemented")
+}
 func (UnimplementedVagrantServer) BootstrapToken(context.Context, *emptypb.Empty) (*NewTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BootstrapToken not implemented")
 }
 func (UnimplementedVagrantServer) GenerateInviteToken(context.Context, *InviteTokenRequest) (*NewTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateInviteToken not implemented")
 }
-func (UnimplementedVagrantServer) GenerateLoginToken(context.Context, *emptypb.Empty) (*NewTokenResponse, error) {
+func (UnimplementedVagrantServer) GenerateLoginToken(context.Context, *LoginTokenRequest) (*NewTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateLoginToken not implemented")
 }
 func (UnimplementedVagrantServer) ConvertInviteToken(context.Context, *ConvertInviteTokenRequest) (*NewTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConvertInviteToken not implemented")
 }
+func (UnimplementedVagrantServer) ListTokens(context.Context, *emptypb.Empty) (*ListTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTokens not implemented")
+}
+func (UnimplementedVagrantServer) RevokeToken(context.Context, *RevokeTokenRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeToken not implemented")
+}
+func (UnimplementedVagrantServer) RotateHMACKey(context.Context, *RotateHMACKeyRequest) (*RotateHMACKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateHMACKey not implemented")
+}
+func (UnimplementedVagrantServer) ListUsers(context.Context, *emptypb.Empty) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedVagrantServer) DeleteUser(context.Context, *DeleteUserRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedVagrantServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
 func (UnimplementedVagrantServer) RunnerConfig(Vagrant_RunnerConfigServer) error {
 	return status.Errorf(codes.Unimplemented, "method RunnerConfig not implemented")
 }
@@ -1253,6 +1561,60 @@ func _Vagrant_ListTargets_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vagrant_AcquireTargetLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcquireTargetLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VagrantServer).AcquireTargetLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.vagrant.Vagrant/AcquireTargetLease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VagrantServer).AcquireTargetLease(ctx, req.(*AcquireTargetLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vagrant_RenewTargetLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewTargetLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VagrantServer).RenewTargetLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.vagrant.Vagrant/RenewTargetLease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VagrantServer).RenewTargetLease(ctx, req.(*RenewTargetLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vagrant_ReleaseTargetLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseTargetLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VagrantServer).ReleaseTargetLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.vagrant.Vagrant/ReleaseTargetLease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VagrantServer).ReleaseTargetLease(ctx, req.(*ReleaseTargetLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vagrant_UpsertBox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpsertBoxRequest)
 	if err := dec(in); err != nil {
@@ -1343,6 +1705,42 @@ func _Vagrant_FindBox_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vagrant_GetVagrantfileCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVagrantfileCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VagrantServer).GetVagrantfileCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.vagrant.Vagrant/GetVagrantfileCache",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VagrantServer).GetVagrantfileCache(ctx, req.(*GetVagrantfileCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vagrant_UpsertVagrantfileCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertVagrantfileCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VagrantServer).UpsertVagrantfileCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.vagrant.Vagrant/UpsertVagrantfileCache",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VagrantServer).UpsertVagrantfileCache(ctx, req.(*UpsertVagrantfileCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vagrant_GetLogStream_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(GetLogStreamRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1529,6 +1927,24 @@ func _Vagrant_PruneOldJobs_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vagrant_GarbageCollect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GarbageCollectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VagrantServer).GarbageCollect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.vagrant.Vagrant/GarbageCollect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VagrantServer).GarbageCollect(ctx, req.(*GarbageCollectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vagrant_GetRunner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRunnerRequest)
 	if err := dec(in); err != nil {
@@ -1630,6 +2046,53 @@ func (x *vagrantRestoreSnapshotServer) Recv() (*RestoreSnapshotRequest, error) {
 	return m, nil
 }
 
+func _Vagrant_UploadBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VagrantServer).UploadBlob(&vagrantUploadBlobServer{stream})
+}
+
+type Vagrant_UploadBlobServer interface {
+	SendAndClose(*UploadBlobResponse) error
+	Recv() (*UploadBlobRequest, error)
+	grpc.ServerStream
+}
+
+type vagrantUploadBlobServer struct {
+	grpc.ServerStream
+}
+
+func (x *vagrantUploadBlobServer) SendAndClose(m *UploadBlobResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *vagrantUploadBlobServer) Recv() (*UploadBlobRequest, error) {
+	m := new(UploadBlobRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Vagrant_GetBlob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetBlobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VagrantServer).GetBlob(m, &vagrantGetBlobServer{stream})
+}
+
+type Vagrant_GetBlobServer interface {
+	Send(*GetBlobResponse) error
+	grpc.ServerStream
+}
+
+type vagrantGetBlobServer struct {
+	grpc.ServerStream
+}
+
+func (x *vagrantGetBlobServer) Send(m *GetBlobResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Vagrant_BootstrapToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -1667,7 +2130,7 @@ func _Vagrant_GenerateInviteToken_Handler(srv interface{}, ctx context.Context,
 }
 
 func _Vagrant_GenerateLoginToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(LoginTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -1679,7 +2142,7 @@ func _Vagrant_GenerateLoginToken_Handler(srv interface{}, ctx context.Context, d
 		FullMethod: "/hashicorp.vagrant.Vagrant/GenerateLoginToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VagrantServer).GenerateLoginToken(ctx, req.(*emptypb.Empty))
+		return srv.(VagrantServer).GenerateLoginToken(ctx, req.(*LoginTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1702,6 +2165,114 @@ func _Vagrant_ConvertInviteToken_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vagrant_ListTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VagrantServer).ListTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.vagrant.Vagrant/ListTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VagrantServer).ListTokens(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vagrant_RevokeToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VagrantServer).RevokeToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.vagrant.Vagrant/RevokeToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VagrantServer).RevokeToken(ctx, req.(*RevokeTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vagrant_RotateHMACKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateHMACKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VagrantServer).RotateHMACKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.vagrant.Vagrant/RotateHMACKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VagrantServer).RotateHMACKey(ctx, req.(*RotateHMACKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vagrant_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VagrantServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.vagrant.Vagrant/ListUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VagrantServer).ListUsers(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vagrant_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VagrantServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.vagrant.Vagrant/DeleteUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VagrantServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vagrant_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VagrantServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.vagrant.Vagrant/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VagrantServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Vagrant_RunnerConfig_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(VagrantServer).RunnerConfig(&vagrantRunnerConfigServer{stream})
 }
@@ -1833,6 +2404,18 @@ var Vagrant_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTargets",
 			Handler:    _Vagrant_ListTargets_Handler,
 		},
+		{
+			MethodName: "AcquireTargetLease",
+			Handler:    _Vagrant_AcquireTargetLease_Handler,
+		},
+		{
+			MethodName: "RenewTargetLease",
+			Handler:    _Vagrant_RenewTargetLease_Handler,
+		},
+		{
+			MethodName: "ReleaseTargetLease",
+			Handler:    _Vagrant_ReleaseTargetLease_Handler,
+		},
 		{
 			MethodName: "UpsertBox",
 			Handler:    _Vagrant_UpsertBox_Handler,
@@ -1853,6 +2436,14 @@ var Vagrant_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindBox",
 			Handler:    _Vagrant_FindBox_Handler,
 		},
+		{
+			MethodName: "GetVagrantfileCache",
+			Handler:    _Vagrant_GetVagrantfileCache_Handler,
+		},
+		{
+			MethodName: "UpsertVagrantfileCache",
+			Handler:    _Vagrant_UpsertVagrantfileCache_Handler,
+		},
 		{
 			MethodName: "SetConfig",
 			Handler:    _Vagrant_SetConfig_Handler,
@@ -1885,6 +2476,10 @@ var Vagrant_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PruneOldJobs",
 			Handler:    _Vagrant_PruneOldJobs_Handler,
 		},
+		{
+			MethodName: "GarbageCollect",
+			Handler:    _Vagrant_GarbageCollect_Handler,
+		},
 		{
 			MethodName: "GetRunner",
 			Handler:    _Vagrant_GetRunner_Handler,
@@ -1913,6 +2508,30 @@ var Vagrant_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ConvertInviteToken",
 			Handler:    _Vagrant_ConvertInviteToken_Handler,
 		},
+		{
+			MethodName: "ListTokens",
+			Handler:    _Vagrant_ListTokens_Handler,
+		},
+		{
+			MethodName: "RevokeToken",
+			Handler:    _Vagrant_RevokeToken_Handler,
+		},
+		{
+			MethodName: "RotateHMACKey",
+			Handler:    _Vagrant_RotateHMACKey_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _Vagrant_ListUsers_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _Vagrant_DeleteUser_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _Vagrant_ListAuditEvents_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1935,6 +2554,16 @@ var Vagrant_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Vagrant_RestoreSnapshot_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "UploadBlob",
+			Handler:       _Vagrant_UploadBlob_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetBlob",
+			Handler:       _Vagrant_GetBlob_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "RunnerConfig",
 			Handler:       _Vagrant_RunnerConfig_Handler,
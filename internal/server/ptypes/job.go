@@ -3,6 +3,7 @@ package ptypes
 import (
 	"errors"
 	"reflect"
+	"strings"
 
 	"github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/imdario/mergo"
@@ -57,6 +58,18 @@ func ValidateJob(job *vagrant_server.Job) error {
 	)
 }
 
+// JobOperation returns the name of the operation of the job, such as
+// "run" or "noop". This is "unknown" if the job has no operation.
+func JobOperation(job *vagrant_server.Job) string {
+	if job == nil || job.Operation == nil {
+		return "unknown"
+	}
+
+	name := reflect.TypeOf(job.Operation).Elem().Name()
+	name = strings.TrimPrefix(name, "Job_")
+	return strings.ToLower(strings.TrimSuffix(name, "_"))
+}
+
 func isEmpty(v interface{}) error {
 	if reflect.ValueOf(v).IsZero() {
 		return nil
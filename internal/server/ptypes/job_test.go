@@ -47,3 +47,13 @@ func TestValidateJob(t *testing.T) {
 		})
 	}
 }
+
+func TestJobOperation(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("noop", JobOperation(TestJobNew(t, nil)))
+	require.Equal("run", JobOperation(&vagrant_server.Job{
+		Operation: &vagrant_server.Job_Run{},
+	}))
+	require.Equal("unknown", JobOperation(&vagrant_server.Job{}))
+}
@@ -26,6 +26,19 @@ func TestTarget(t testing.T, src *vagrant_server.Target) *vagrant_server.Target
 	return src
 }
 
+// TargetRemovable returns true if a target in the given state doesn't
+// exist, so its record can be removed without losing track of it.
+func TargetRemovable(state vagrant_server.Operation_PhysicalState) bool {
+	switch state {
+	case vagrant_server.Operation_NOT_CREATED,
+		vagrant_server.Operation_DESTROYED,
+		vagrant_server.Operation_UNKNOWN:
+		return true
+	default:
+		return false
+	}
+}
+
 // ValidateTarget validates the target structure.
 func ValidateTarget(t *vagrant_server.Target) error {
 	return validation.ValidateStruct(t,
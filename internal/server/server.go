@@ -2,11 +2,14 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	"github.com/oklog/run"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 
 	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
@@ -46,6 +49,15 @@ func Run(opts ...Option) error {
 		return ctx.Err()
 	}, func(error) { cancelCtx() })
 
+	// Setup our metrics, these are served by the HTTP server.
+	if err := metricsInit(&cfg); err != nil {
+		return err
+	}
+
+	// Setup our health checks, these are served by both servers.
+	cfg.health = newHealthState(cfg.Service)
+	healthInit(&group, &cfg)
+
 	// Setup our gRPC server.
 	if err := grpcInit(&group, &cfg); err != nil {
 		return err
@@ -87,6 +99,11 @@ type options struct {
 	// serves the HTTP endpoints as well.
 	GRPCListener net.Listener
 
+	// GRPCTLSConfig, if set, serves gRPC over TLS with this configuration.
+	// This is required for client certificates to be available to the
+	// server, so it is used instead of a TLS listener.
+	GRPCTLSConfig *tls.Config
+
 	// HTTPListener will setup the HTTP server. If this is nil, then
 	// the HTTP-based API will be disabled.
 	HTTPListener net.Listener
@@ -94,10 +111,35 @@ type options struct {
 	// AuthChecker, if set, activates authentication checking on the server.
 	AuthChecker AuthChecker
 
+	// ClientCertAuth, if true, identifies clients by the subject of their
+	// verified client certificate. The identity is available to the
+	// AuthChecker with IdentityFromContext.
+	ClientCertAuth bool
+
 	// BrowserUIEnabled determines if the browser UI should be mounted
 	BrowserUIEnabled bool
 
-	grpcServer *grpc.Server
+	// IdleTimeout, if non-zero, is the duration after which the server
+	// will shut down if no gRPC calls are in flight.
+	IdleTimeout time.Duration
+
+	// MetricsRegistry is where the server metrics are registered. This
+	// defaults to a new registry which includes the Go runtime and process
+	// metrics. The metrics are served on "/metrics" of the HTTP server.
+	MetricsRegistry *prometheus.Registry
+
+	// DrainCh, if set, starts draining the server when it is closed or
+	// receives a value. The server reports that it is not ready, the
+	// service is drained if it is a Drainer, and then the server exits.
+	DrainCh <-chan struct{}
+
+	// DrainTimeout is how long to wait for the service to drain. If this
+	// is zero there is no limit.
+	DrainTimeout time.Duration
+
+	grpcServer  *grpc.Server
+	grpcOptions []grpc.ServerOption
+	health      *healthState
 }
 
 // WithContext sets the context for the server. When this context is cancelled,
@@ -118,6 +160,11 @@ func WithGRPC(ln net.Listener) Option {
 	return func(opts *options) { opts.GRPCListener = ln }
 }
 
+// WithGRPCTLS serves gRPC over TLS using the given configuration.
+func WithGRPCTLS(cfg *tls.Config) Option {
+	return func(opts *options) { opts.GRPCTLSConfig = cfg }
+}
+
 // WithHTTP sets the HTTP listener. This listener must be closed manually
 // by the caller. Prior to closing the listener, it is recommended that you
 // cancel the context set with WithContext and wait for Run to return.
@@ -135,6 +182,33 @@ func WithAuthentication(ac AuthChecker) Option {
 	return func(opts *options) { opts.AuthChecker = ac }
 }
 
+// WithClientCertAuth configures the server to identify clients by their
+// verified client certificates.
+func WithClientCertAuth(enabled bool) Option {
+	return func(opts *options) { opts.ClientCertAuth = enabled }
+}
+
+// WithIdleTimeout configures the server to shut down after it has had
+// no gRPC calls in flight for the given duration.
+func WithIdleTimeout(d time.Duration) Option {
+	return func(opts *options) { opts.IdleTimeout = d }
+}
+
+// WithMetricsRegistry sets the registry that the server metrics are
+// registered with.
+func WithMetricsRegistry(reg *prometheus.Registry) Option {
+	return func(opts *options) { opts.MetricsRegistry = reg }
+}
+
+// WithDrain configures the server to drain and then exit when ch is
+// closed or receives a value. The service is given up to timeout to drain.
+func WithDrain(ch <-chan struct{}, timeout time.Duration) Option {
+	return func(opts *options) {
+		opts.DrainCh = ch
+		opts.DrainTimeout = timeout
+	}
+}
+
 // WithBrowserUI configures the server to enable the browser UI.
 func WithBrowserUI(enabled bool) Option {
 	return func(opts *options) { opts.BrowserUIEnabled = enabled }
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/mr-tron/base58"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
@@ -18,6 +19,7 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/hashicorp/vagrant/internal/server"
 	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
 )
 
@@ -46,6 +48,17 @@ var (
 	ErrInvalidToken = errors.New("invalid authentication token")
 )
 
+// userKey is the context key for the authenticated user.
+type userKey struct{}
+
+// userFromContext returns the name of the user that was authenticated
+// for the request. This is empty for endpoints which do not require
+// authentication.
+func userFromContext(ctx context.Context) string {
+	user, _ := ctx.Value(userKey{}).(string)
+	return user
+}
+
 // DecodeToken parses the string and validates it as a valid token. If the token
 // has a validity period attached to it, the period is checked here.
 func (s *service) DecodeToken(token string) (*vagrant_server.TokenTransport, *vagrant_server.Token, error) {
@@ -54,6 +67,10 @@ func (s *service) DecodeToken(token string) (*vagrant_server.TokenTransport, *va
 		return nil, nil, err
 	}
 
+	if len(data) < len(tokenMagic) {
+		return nil, nil, errors.Wrapf(ErrInvalidToken, "too short")
+	}
+
 	if subtle.ConstantTimeCompare(data[:len(tokenMagic)], []byte(tokenMagic)) != 1 {
 		return nil, nil, errors.Wrapf(ErrInvalidToken, "bad magic")
 	}
@@ -69,6 +86,11 @@ func (s *service) DecodeToken(token string) (*vagrant_server.TokenTransport, *va
 		return nil, nil, errors.Wrapf(ErrInvalidToken, "unknown key")
 	}
 
+	// Keys which have been rotated out are only valid for a grace period.
+	if key.ValidUntil != nil && key.ValidUntil.AsTime().Before(time.Now()) {
+		return nil, nil, errors.Wrapf(ErrInvalidToken, "key has expired")
+	}
+
 	h, err := blake2b.New256(key.Key)
 	if err != nil {
 		return nil, nil, err
@@ -98,43 +120,112 @@ func (s *service) DecodeToken(token string) (*vagrant_server.TokenTransport, *va
 		}
 	}
 
+	revoked, err := s.state.TokenRevoked(tokenId(&body))
+	if err != nil {
+		return nil, nil, err
+	}
+	if revoked {
+		return nil, nil, errors.Wrapf(ErrInvalidToken, "token has been revoked")
+	}
+
 	return &tt, &body, nil
 }
 
+// tokenId returns the id of the token used to list and revoke it.
+func tokenId(body *vagrant_server.Token) string {
+	return base58.Encode(body.TokenId)
+}
+
+// tokenInfo returns the information recorded about the token.
+func tokenInfo(keyId string, body *vagrant_server.Token) *vagrant_server.TokenInfo {
+	return &vagrant_server.TokenInfo{
+		Id:         tokenId(body),
+		KeyId:      keyId,
+		User:       body.User,
+		Login:      body.Login,
+		Invite:     body.Invite,
+		ValidUntil: body.ValidUntil,
+	}
+}
+
+// activeKeyId returns the id of the key that new tokens are signed with.
+func (s *service) activeKeyId() (string, error) {
+	key, err := s.state.HMACKeyActive()
+	if err != nil {
+		return "", err
+	}
+	if key == nil {
+		return DefaultKeyId, nil
+	}
+
+	return key.Id, nil
+}
+
 // Authenticate checks if the given endpoint should be allowed. This is called during a
 // gRPC request. Effects is some information about the endpoint, at present these are
 // either ["readonly"] or ["mutable"] to indicate if the endpoint will be only reading
-// data or also mutating it.
-func (s *service) Authenticate(ctx context.Context, token, endpoint string, effects []string) error {
+// data or also mutating it. The returned context carries the authenticated user.
+func (s *service) Authenticate(ctx context.Context, token, endpoint string, effects []string) (context.Context, error) {
 	// We always allow ConvertInviteToken so that folks can actually get authentication data
 	if endpoint == "ConvertInviteToken" || endpoint == "BootstrapToken" || endpoint == "GetVersionInfo" {
-		return nil
+		return ctx, nil
 	}
 
 	if token == "" {
-		return status.Errorf(codes.Unauthenticated, "Authorization token is not supplied")
+		// Clients identified by a verified certificate do not need a
+		// token. The certificate authority vouches for the identity, so
+		// it is used as the user without requiring a user record.
+		if id := server.IdentityFromContext(ctx); id != "" {
+			return context.WithValue(ctx, userKey{}, id), nil
+		}
+
+		return nil, status.Errorf(codes.Unauthenticated, "Authorization token is not supplied")
 	}
 
 	_, body, err := s.DecodeToken(token)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !body.Login {
-		return ErrInvalidToken
+		return nil, ErrInvalidToken
 	}
 
 	// If this is an entrypoint token then we can only access entrypoint APIs.
 	if body.Entrypoint != nil && !strings.HasPrefix(endpoint, "Entrypoint") {
-		return status.Errorf(codes.Unauthenticated, "Unauthorized endpoint")
+		return nil, status.Errorf(codes.Unauthenticated, "Unauthorized endpoint")
 	}
 
-	// TODO When we have a user model, this is where you'll check for the user.
+	// The default user always exists, any other user must not have
+	// been deleted.
 	if body.User != DefaultUser {
-		return ErrInvalidToken
+		if _, err := s.state.UserGet(body.User); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil, errors.Wrapf(ErrInvalidToken, "unknown user")
+			}
+
+			return nil, err
+		}
 	}
 
-	return nil
+	return context.WithValue(ctx, userKey{}, body.User), nil
+}
+
+// Audit records a call to an endpoint that mutates data. This is called
+// after the call has completed.
+func (s *service) Audit(ctx context.Context, endpoint string, err error) {
+	ev := &vagrant_server.AuditEvent{
+		User:     userFromContext(ctx),
+		Endpoint: endpoint,
+	}
+	if err != nil {
+		ev.Error = status.Convert(err).Proto()
+	}
+
+	if err := s.state.AuditEventPut(ev); err != nil {
+		hclog.FromContext(ctx).Error("failed to record audit event",
+			"endpoint", endpoint, "error", err)
+	}
 }
 
 // Generate a new token by signing the data in body.
@@ -169,6 +260,15 @@ func (s *service) GenerateToken(keyId string, metadata map[string]string, body *
 		return "", err
 	}
 
+	// Record the token so that it can be listed and revoked.
+	if len(body.TokenId) > 0 {
+		info := tokenInfo(keyId, body)
+		info.IssuedAt = timestamppb.Now()
+		if err := s.state.TokenPut(info); err != nil {
+			return "", err
+		}
+	}
+
 	var buf bytes.Buffer
 	buf.WriteString(tokenMagic)
 	buf.Write(ttData)
@@ -183,13 +283,29 @@ func (s *service) NewLoginToken(
 	keyId string,
 	metadata map[string]string,
 	entrypoint *vagrant_server.Token_Entrypoint,
+) (string, error) {
+	return s.newLoginToken(0, keyId, DefaultUser, metadata, entrypoint)
+}
+
+// newLoginToken creates a new login token for the given user. If duration
+// is non-zero the token is only valid for that long.
+func (s *service) newLoginToken(
+	duration time.Duration,
+	keyId string,
+	user string,
+	metadata map[string]string,
+	entrypoint *vagrant_server.Token_Entrypoint,
 ) (string, error) {
 	var body vagrant_server.Token
 	body.Login = true
-	body.User = DefaultUser
+	body.User = user
 	body.TokenId = make([]byte, 16)
 	body.Entrypoint = entrypoint
 
+	if duration > 0 {
+		body.ValidUntil = timestamppb.New(time.Now().UTC().Add(duration))
+	}
+
 	_, err := io.ReadFull(rand.Reader, body.TokenId)
 	if err != nil {
 		return "", err
@@ -199,8 +315,34 @@ func (s *service) NewLoginToken(
 }
 
 // Create a new login token. This is just a gRPC wrapper around NewLoginToken.
-func (s *service) GenerateLoginToken(ctx context.Context, _ *emptypb.Empty) (*vagrant_server.NewTokenResponse, error) {
-	token, err := s.NewLoginToken(DefaultKeyId, nil, nil)
+func (s *service) GenerateLoginToken(ctx context.Context, req *vagrant_server.LoginTokenRequest) (*vagrant_server.NewTokenResponse, error) {
+	var dur time.Duration
+	if req.Duration != "" {
+		var err error
+		dur, err = time.ParseDuration(req.Duration)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid duration: %s", err)
+		}
+	}
+
+	keyId, err := s.activeKeyId()
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens are created for the calling user. Users identified by a
+	// certificate have no record yet, so one is created for the token.
+	user := userFromContext(ctx)
+	if user == "" {
+		user = DefaultUser
+	}
+	if user != DefaultUser {
+		if _, err := s.state.UserCreateIfNotExist(user); err != nil {
+			return nil, err
+		}
+	}
+
+	token, err := s.newLoginToken(dur, keyId, user, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -216,9 +358,22 @@ func (s *service) NewInviteToken(
 	keyId string,
 	metadata map[string]string,
 	entrypoint *vagrant_server.Token_Entrypoint,
+) (string, error) {
+	return s.newInviteToken(duration, keyId, "", metadata, entrypoint)
+}
+
+// newInviteToken creates a new invite token for the given user. If user is
+// empty, the invite is for the default user.
+func (s *service) newInviteToken(
+	duration time.Duration,
+	keyId string,
+	user string,
+	metadata map[string]string,
+	entrypoint *vagrant_server.Token_Entrypoint,
 ) (string, error) {
 	var body vagrant_server.Token
 	body.Invite = true
+	body.User = user
 	body.TokenId = make([]byte, 16)
 	body.Entrypoint = entrypoint
 
@@ -236,6 +391,18 @@ func (s *service) NewInviteToken(
 	return s.GenerateToken(keyId, metadata, &body)
 }
 
+// requireDefaultUser returns an error unless the calling user is the
+// default user, which is the only user allowed to manage other users.
+// Calls without a user are made by the server itself.
+func requireDefaultUser(ctx context.Context) error {
+	if user := userFromContext(ctx); user != "" && user != DefaultUser {
+		return status.Errorf(codes.PermissionDenied,
+			"only the %s user can manage users", DefaultUser)
+	}
+
+	return nil
+}
+
 // Create a new invite token. This is just a gRPC wrapper around NewInviteToken.
 func (s *service) GenerateInviteToken(ctx context.Context, req *vagrant_server.InviteTokenRequest) (*vagrant_server.NewTokenResponse, error) {
 	dur, err := time.ParseDuration(req.Duration)
@@ -243,7 +410,33 @@ func (s *service) GenerateInviteToken(ctx context.Context, req *vagrant_server.I
 		return nil, err
 	}
 
-	token, err := s.NewInviteToken(dur, DefaultKeyId, nil, req.Entrypoint)
+	keyId, err := s.activeKeyId()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireDefaultUser(ctx); err != nil {
+		return nil, err
+	}
+
+	user := strings.TrimSpace(req.Username)
+	if strings.ContainsAny(user, " \t\n") {
+		return nil, status.Errorf(codes.InvalidArgument, "username must not contain whitespace")
+	}
+
+	// Invites for other users which already exist would hand out their
+	// identity, so only new users may be invited.
+	if user != "" && user != DefaultUser {
+		_, err := s.state.UserGet(user)
+		if err == nil {
+			return nil, status.Errorf(codes.AlreadyExists, "user %q already exists", user)
+		}
+		if status.Code(err) != codes.NotFound {
+			return nil, err
+		}
+	}
+
+	token, err := s.newInviteToken(dur, keyId, user, nil, req.Entrypoint)
 	if err != nil {
 		return nil, err
 	}
@@ -262,12 +455,28 @@ func (s *service) ExchangeInvite(keyId, invite string) (string, error) {
 		return "", errors.Wrapf(ErrInvalidToken, "not an invite token")
 	}
 
-	return s.NewLoginToken(keyId, tt.Metadata, body.Entrypoint)
+	// Invites bound to a user create the user when they are exchanged.
+	user := body.User
+	if user == "" {
+		user = DefaultUser
+	}
+	if user != DefaultUser {
+		if _, err := s.state.UserCreateIfNotExist(user); err != nil {
+			return "", err
+		}
+	}
+
+	return s.newLoginToken(0, keyId, user, tt.Metadata, body.Entrypoint)
 }
 
 // Given an invite token, validate it and return a login token. This is a gRPC wrapper around ExchangeInvite.
 func (s *service) ConvertInviteToken(ctx context.Context, req *vagrant_server.ConvertInviteTokenRequest) (*vagrant_server.NewTokenResponse, error) {
-	token, err := s.ExchangeInvite(DefaultKeyId, req.Token)
+	keyId, err := s.activeKeyId()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.ExchangeInvite(keyId, req.Token)
 	if err != nil {
 		return nil, err
 	}
@@ -291,3 +500,108 @@ func (s *service) BootstrapToken(ctx context.Context, req *emptypb.Empty) (*vagr
 func (s *service) Bootstrapped() bool {
 	return !s.state.HMACKeyEmpty()
 }
+
+// List the tokens issued by the server.
+func (s *service) ListTokens(ctx context.Context, _ *emptypb.Empty) (*vagrant_server.ListTokensResponse, error) {
+	tokens, err := s.state.TokenList()
+	if err != nil {
+		return nil, err
+	}
+
+	return &vagrant_server.ListTokensResponse{Tokens: tokens}, nil
+}
+
+// Revoke a token by id or by value. Tokens given by value are recorded
+// when they are revoked if the server has no record of them.
+func (s *service) RevokeToken(ctx context.Context, req *vagrant_server.RevokeTokenRequest) (*emptypb.Empty, error) {
+	switch t := req.Target.(type) {
+	case *vagrant_server.RevokeTokenRequest_Id:
+		if err := s.state.TokenRevoke(t.Id, nil); err != nil {
+			return nil, err
+		}
+
+	case *vagrant_server.RevokeTokenRequest_Token:
+		tt, body, err := s.DecodeToken(t.Token)
+		if err != nil {
+			// Tokens which are invalid cannot be used anyways, so they
+			// are reported as bad input rather than an internal error.
+			if errors.Is(err, ErrInvalidToken) {
+				return nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+
+			return nil, err
+		}
+
+		if err := s.state.TokenRevoke(tokenId(body), tokenInfo(tt.KeyId, body)); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "a token id or token must be given")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// Rotate the key that tokens are signed with. The previous keys remain
+// valid for the requested grace period.
+func (s *service) RotateHMACKey(ctx context.Context, req *vagrant_server.RotateHMACKeyRequest) (*vagrant_server.RotateHMACKeyResponse, error) {
+	var grace time.Duration
+	if req.GracePeriod != "" {
+		var err error
+		grace, err = time.ParseDuration(req.GracePeriod)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid grace period: %s", err)
+		}
+	}
+
+	key, err := s.state.HMACKeyRotate(hmacKeySize, grace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vagrant_server.RotateHMACKeyResponse{
+		KeyId:              key.Id,
+		PreviousValidUntil: timestamppb.New(key.CreatedAt.AsTime().Add(grace)),
+	}, nil
+}
+
+// List the users of the server. The default user always exists, so it is
+// always included.
+func (s *service) ListUsers(ctx context.Context, _ *emptypb.Empty) (*vagrant_server.ListUsersResponse, error) {
+	users, err := s.state.UserList()
+	if err != nil {
+		return nil, err
+	}
+
+	return &vagrant_server.ListUsersResponse{
+		Users: append([]*vagrant_server.User{{Username: DefaultUser}}, users...),
+	}, nil
+}
+
+// Delete a user. Tokens of the user are rejected once the user is deleted.
+func (s *service) DeleteUser(ctx context.Context, req *vagrant_server.DeleteUserRequest) (*emptypb.Empty, error) {
+	if err := requireDefaultUser(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.Username == DefaultUser {
+		return nil, status.Errorf(codes.FailedPrecondition, "the default user cannot be deleted")
+	}
+
+	if err := s.state.UserDelete(req.Username); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// List the recorded calls to endpoints that mutate data.
+func (s *service) ListAuditEvents(ctx context.Context, req *vagrant_server.ListAuditEventsRequest) (*vagrant_server.ListAuditEventsResponse, error) {
+	events, err := s.state.AuditEventList(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vagrant_server.ListAuditEventsResponse{Events: events}, nil
+}
@@ -3,6 +3,7 @@ package singleprocess
 import (
 	"bytes"
 	"context"
+	"net"
 	"testing"
 	"time"
 
@@ -15,7 +16,12 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/hashicorp/vagrant/internal/clicontext"
+	"github.com/hashicorp/vagrant/internal/server"
 	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	serverptypes "github.com/hashicorp/vagrant/internal/server/ptypes"
+	"github.com/hashicorp/vagrant/internal/serverclient"
+	"github.com/hashicorp/vagrant/internal/serverconfig"
 )
 
 func TestServiceAuth(t *testing.T) {
@@ -44,7 +50,7 @@ func TestServiceAuth(t *testing.T) {
 		assert.Equal(t, DefaultUser, body.User)
 		assert.Equal(t, md, tt.Metadata)
 
-		err = s.Authenticate(context.Background(), token, "test", nil)
+		_, err = s.Authenticate(context.Background(), token, "test", nil)
 		require.NoError(t, err)
 
 		// Now corrupt the token and check that validation fails
@@ -64,12 +70,25 @@ func TestServiceAuth(t *testing.T) {
 		require.NoError(t, err)
 
 		{
-			err := s.Authenticate(context.Background(), token, "EntrypointConfig", nil)
+			_, err := s.Authenticate(context.Background(), token, "EntrypointConfig", nil)
 			require.NoError(t, err)
 		}
 
 		{
-			err := s.Authenticate(context.Background(), token, "UpsertDeployment", nil)
+			_, err := s.Authenticate(context.Background(), token, "UpsertDeployment", nil)
+			require.Error(t, err)
+		}
+	})
+
+	t.Run("rejects tokens shorter than the magic", func(t *testing.T) {
+		s := impl.(*service)
+
+		for _, token := range []string{"2", base58.Encode([]byte(tokenMagic[:3]))} {
+			_, _, err := s.DecodeToken(token)
+			require.Error(t, err)
+			require.ErrorIs(t, err, ErrInvalidToken)
+
+			_, err = s.Authenticate(context.Background(), token, "test", nil)
 			require.Error(t, err)
 		}
 	})
@@ -155,6 +174,110 @@ func TestServiceAuth(t *testing.T) {
 		_, err = s.ExchangeInvite(DefaultKeyId, invite)
 		require.Error(t, err)
 	})
+
+	t.Run("list and revoke tokens", func(t *testing.T) {
+		s := impl.(*service)
+		ctx := context.Background()
+
+		token, err := s.NewLoginToken(DefaultKeyId, nil, nil)
+		require.NoError(t, err)
+		_, body, err := s.DecodeToken(token)
+		require.NoError(t, err)
+		id := tokenId(body)
+
+		resp, err := s.ListTokens(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		var found *vagrant_server.TokenInfo
+		for _, info := range resp.Tokens {
+			if info.Id == id {
+				found = info
+			}
+		}
+		require.NotNil(t, found)
+		assert.True(t, found.Login)
+		assert.NotNil(t, found.IssuedAt)
+		assert.Nil(t, found.RevokedAt)
+
+		_, err = s.RevokeToken(ctx, &vagrant_server.RevokeTokenRequest{
+			Target: &vagrant_server.RevokeTokenRequest_Id{Id: id},
+		})
+		require.NoError(t, err)
+
+		_, err = s.Authenticate(ctx, token, "test", nil)
+		require.Error(t, err)
+
+		// Tokens can also be revoked by value
+		other, err := s.NewLoginToken(DefaultKeyId, nil, nil)
+		require.NoError(t, err)
+
+		_, err = s.RevokeToken(ctx, &vagrant_server.RevokeTokenRequest{
+			Target: &vagrant_server.RevokeTokenRequest_Token{Token: other},
+		})
+		require.NoError(t, err)
+		_, _, err = s.DecodeToken(other)
+		require.Error(t, err)
+
+		_, err = s.RevokeToken(ctx, &vagrant_server.RevokeTokenRequest{
+			Target: &vagrant_server.RevokeTokenRequest_Id{Id: "nope"},
+		})
+		require.Error(t, err)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("login tokens can expire", func(t *testing.T) {
+		s := impl.(*service)
+		ctx := context.Background()
+
+		resp, err := s.GenerateLoginToken(ctx, &vagrant_server.LoginTokenRequest{
+			Duration: "1s",
+		})
+		require.NoError(t, err)
+		_, err = s.Authenticate(ctx, resp.Token, "test", nil)
+		require.NoError(t, err)
+
+		time.Sleep(2 * time.Second)
+		_, err = s.Authenticate(ctx, resp.Token, "test", nil)
+		require.Error(t, err)
+
+		_, err = s.GenerateLoginToken(ctx, &vagrant_server.LoginTokenRequest{
+			Duration: "bogus",
+		})
+		require.Error(t, err)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestServiceAuth_rotateKey(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	s := impl.(*service)
+
+	old, err := s.GenerateLoginToken(ctx, &vagrant_server.LoginTokenRequest{})
+	require.NoError(err)
+
+	// Tokens signed with the previous key remain valid for the grace period
+	resp, err := s.RotateHMACKey(ctx, &vagrant_server.RotateHMACKeyRequest{
+		GracePeriod: "1s",
+	})
+	require.NoError(err)
+	require.Equal("k2", resp.KeyId)
+	_, err = s.Authenticate(ctx, old.Token, "test", nil)
+	require.NoError(err)
+
+	current, err := s.GenerateLoginToken(ctx, &vagrant_server.LoginTokenRequest{})
+	require.NoError(err)
+	tt, _, err := s.DecodeToken(current.Token)
+	require.NoError(err)
+	require.Equal("k2", tt.KeyId)
+
+	time.Sleep(2 * time.Second)
+	_, err = s.Authenticate(ctx, old.Token, "test", nil)
+	require.Error(err)
+	_, err = s.Authenticate(ctx, current.Token, "test", nil)
+	require.NoError(err)
 }
 
 func TestServiceBootstrapToken(t *testing.T) {
@@ -180,3 +303,172 @@ func TestServiceBootstrapToken(t *testing.T) {
 		require.Nil(resp)
 	}
 }
+
+func TestServiceAuth_clientCert(t *testing.T) {
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+
+	ca := server.NewTestCA(t)
+	serverCert, serverKey := ca.Issue(t, "server")
+	clientCert, clientKey := ca.Issue(t, "runner-1")
+
+	tlsConfig, err := server.TLSConfig(serverCert, serverKey, ca.CertFile)
+	require.NoError(err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer ln.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.Run(
+		server.WithContext(ctx),
+		server.WithGRPC(ln),
+		server.WithImpl(impl),
+		server.WithAuthentication(impl.(server.AuthChecker)),
+		server.WithGRPCTLS(tlsConfig),
+		server.WithClientCertAuth(true),
+	)
+
+	conn, err := serverclient.Connect(ctx, serverclient.FromContextConfig(&clicontext.Config{
+		Server: serverconfig.Client{
+			Address:     ln.Addr().String(),
+			Tls:         true,
+			TlsCAFile:   ca.CertFile,
+			TlsCertFile: clientCert,
+			TlsKeyFile:  clientKey,
+		},
+	}))
+	require.NoError(err)
+	defer conn.Close()
+
+	// No token is sent, the client is authenticated by its certificate
+	_, err = vagrant_server.NewVagrantClient(conn).GetServerConfig(ctx, &emptypb.Empty{})
+	require.NoError(err)
+
+	// Without a certificate identity a token is still required
+	_, err = impl.(*service).Authenticate(ctx, "", "GetServerConfig", nil)
+	require.Error(err)
+	require.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func TestServiceAuth_users(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	s := impl.(*service)
+	client := server.TestServer(t, impl)
+	TestBasis(t, client, serverptypes.TestBasis(t, nil))
+
+	// Exchanging an invite bound to a user creates the user
+	invite, err := s.GenerateInviteToken(ctx, &vagrant_server.InviteTokenRequest{
+		Duration: "1m",
+		Username: "alice",
+	})
+	require.NoError(err)
+	login, err := s.ConvertInviteToken(ctx, &vagrant_server.ConvertInviteTokenRequest{
+		Token: invite.Token,
+	})
+	require.NoError(err)
+
+	users, err := s.ListUsers(ctx, &emptypb.Empty{})
+	require.NoError(err)
+	require.Len(users.Users, 2)
+	require.Equal(DefaultUser, users.Users[0].Username)
+	require.Equal("alice", users.Users[1].Username)
+
+	// The authenticated user is attached to the context
+	actx, err := s.Authenticate(ctx, login.Token, "QueueJob", nil)
+	require.NoError(err)
+	require.Equal("alice", userFromContext(actx))
+
+	// and to the jobs queued by the user
+	resp, err := s.QueueJob(actx, &vagrant_server.QueueJobRequest{
+		Job: serverptypes.TestJobNew(t, nil),
+	})
+	require.NoError(err)
+	job, err := s.state.JobById(resp.JobId, nil)
+	require.NoError(err)
+	require.Equal("alice", job.User)
+
+	// Mutating calls are audited with the user
+	s.Audit(actx, "QueueJob", nil)
+	s.Audit(actx, "DeleteTarget", status.Errorf(codes.NotFound, "nope"))
+	events, err := s.ListAuditEvents(ctx, &vagrant_server.ListAuditEventsRequest{
+		User: "alice",
+	})
+	require.NoError(err)
+	require.Len(events.Events, 2)
+	require.Equal("DeleteTarget", events.Events[0].Endpoint)
+	require.Equal(int32(codes.NotFound), events.Events[0].Error.Code)
+	require.Nil(events.Events[1].Error)
+
+	// Tokens of deleted users are rejected
+	_, err = s.DeleteUser(ctx, &vagrant_server.DeleteUserRequest{Username: "alice"})
+	require.NoError(err)
+	_, err = s.Authenticate(ctx, login.Token, "QueueJob", nil)
+	require.Error(err)
+
+	_, err = s.DeleteUser(ctx, &vagrant_server.DeleteUserRequest{Username: DefaultUser})
+	require.Error(err)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func TestServiceAuth_userPermissions(t *testing.T) {
+	ctx := context.Background()
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(t, err)
+	s := impl.(*service)
+
+	invite, err := s.GenerateInviteToken(ctx, &vagrant_server.InviteTokenRequest{
+		Duration: "1m",
+		Username: "alice",
+	})
+	require.NoError(t, err)
+	login, err := s.ConvertInviteToken(ctx, &vagrant_server.ConvertInviteTokenRequest{
+		Token: invite.Token,
+	})
+	require.NoError(t, err)
+	actx, err := s.Authenticate(ctx, login.Token, "GenerateInviteToken", nil)
+	require.NoError(t, err)
+
+	t.Run("rejects invites from other users", func(t *testing.T) {
+		for _, name := range []string{"", DefaultUser, "alice", "bob"} {
+			_, err := s.GenerateInviteToken(actx, &vagrant_server.InviteTokenRequest{
+				Duration: "1m",
+				Username: name,
+			})
+			require.Error(t, err)
+			require.Equal(t, codes.PermissionDenied, status.Code(err), name)
+		}
+	})
+
+	t.Run("rejects invites for existing users", func(t *testing.T) {
+		_, err := s.GenerateInviteToken(ctx, &vagrant_server.InviteTokenRequest{
+			Duration: "1m",
+			Username: "alice",
+		})
+		require.Error(t, err)
+		require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+		// The default user can still invite itself
+		_, err = s.GenerateInviteToken(ctx, &vagrant_server.InviteTokenRequest{
+			Duration: "1m",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects deletes from other users", func(t *testing.T) {
+		_, err := s.DeleteUser(actx, &vagrant_server.DeleteUserRequest{Username: "alice"})
+		require.Error(t, err)
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		users, err := s.ListUsers(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		require.Len(t, users.Users, 2)
+	})
+}
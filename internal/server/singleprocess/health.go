@@ -0,0 +1,63 @@
+package singleprocess
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/vagrant/internal/server"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// drainPollInterval is how often Drain checks for jobs in progress.
+const drainPollInterval = time.Second
+
+// CheckHealth implements server.HealthChecker
+func (s *service) CheckHealth(ctx context.Context) error {
+	return s.state.Check()
+}
+
+// Drain implements server.Drainer. Runners are no longer assigned jobs
+// once this is called. This returns once no jobs are assigned or running.
+func (s *service) Drain(ctx context.Context) error {
+	log := hclog.FromContext(ctx)
+	s.drainOnce.Do(func() { close(s.drainCh) })
+
+	tk := time.NewTicker(drainPollInterval)
+	defer tk.Stop()
+
+	for {
+		counts, err := s.state.JobCountByState()
+		if err != nil {
+			return err
+		}
+
+		active := counts[vagrant_server.Job_WAITING] + counts[vagrant_server.Job_RUNNING]
+		if active == 0 {
+			return nil
+		}
+
+		log.Info("waiting for jobs to complete", "jobs", active)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tk.C:
+		}
+	}
+}
+
+// draining returns true if the service has started draining.
+func (s *service) draining() bool {
+	select {
+	case <-s.drainCh:
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	_ server.HealthChecker = (*service)(nil)
+	_ server.Drainer       = (*service)(nil)
+)
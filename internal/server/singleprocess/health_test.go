@@ -0,0 +1,85 @@
+package singleprocess
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant/internal/server"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	serverptypes "github.com/hashicorp/vagrant/internal/server/ptypes"
+)
+
+func TestServiceDrain(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	// Create our server
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+	require.NoError(testServiceImpl(impl).CheckHealth(ctx))
+
+	// Initialize our basis
+	TestBasis(t, client, serverptypes.TestBasis(t, nil))
+
+	// Queue two jobs
+	for i := 0; i < 2; i++ {
+		_, err := client.QueueJob(ctx, &vagrant_server.QueueJobRequest{Job: serverptypes.TestJobNew(t, nil)})
+		require.NoError(err)
+	}
+
+	// Register our runner
+	id, _ := TestRunner(t, client, nil)
+	request := &vagrant_server.RunnerJobStreamRequest{
+		Event: &vagrant_server.RunnerJobStreamRequest_Request_{
+			Request: &vagrant_server.RunnerJobStreamRequest_Request{
+				RunnerId: id,
+			},
+		},
+	}
+
+	// Start running one of the jobs
+	stream, err := client.RunnerJobStream(ctx)
+	require.NoError(err)
+	require.NoError(stream.Send(request))
+	resp, err := stream.Recv()
+	require.NoError(err)
+	_, ok := resp.Event.(*vagrant_server.RunnerJobStreamResponse_Assignment)
+	require.True(ok, "should be an assignment")
+	require.NoError(stream.Send(&vagrant_server.RunnerJobStreamRequest{
+		Event: &vagrant_server.RunnerJobStreamRequest_Ack_{
+			Ack: &vagrant_server.RunnerJobStreamRequest_Ack{},
+		},
+	}))
+
+	// Draining should wait for the running job
+	drainCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	require.Equal(context.DeadlineExceeded, testServiceImpl(impl).Drain(drainCtx))
+
+	// The queued job should not be assigned while draining
+	other, err := client.RunnerJobStream(ctx)
+	require.NoError(err)
+	require.NoError(other.Send(request))
+	_, err = other.Recv()
+	require.Error(err)
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	// Complete the running job
+	require.NoError(stream.Send(&vagrant_server.RunnerJobStreamRequest{
+		Event: &vagrant_server.RunnerJobStreamRequest_Complete_{
+			Complete: &vagrant_server.RunnerJobStreamRequest_Complete{},
+		},
+	}))
+	_, err = stream.Recv()
+	require.Equal(io.EOF, err)
+
+	// Draining should now complete
+	require.NoError(testServiceImpl(impl).Drain(ctx))
+}
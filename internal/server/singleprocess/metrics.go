@@ -0,0 +1,117 @@
+package singleprocess
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	serverptypes "github.com/hashicorp/vagrant/internal/server/ptypes"
+)
+
+var (
+	jobsDesc = prometheus.NewDesc(
+		"vagrant_server_jobs",
+		"Number of jobs tracked by the server in each state.",
+		[]string{"state"}, nil,
+	)
+
+	runnersDesc = prometheus.NewDesc(
+		"vagrant_server_runners",
+		"Number of runners registered with the server.",
+		nil, nil,
+	)
+
+	dbSizeDesc = prometheus.NewDesc(
+		"vagrant_server_db_size_bytes",
+		"Size of the server database in bytes.",
+		nil, nil,
+	)
+)
+
+// serviceMetrics are the metrics that are recorded as the service handles
+// requests. The metrics derived from state are read when collected.
+type serviceMetrics struct {
+	jobDuration *prometheus.HistogramVec
+	pruned      *prometheus.CounterVec
+}
+
+func newServiceMetrics() *serviceMetrics {
+	return &serviceMetrics{
+		jobDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "vagrant_server",
+			Name:      "job_duration_seconds",
+			Help:      "Duration of completed jobs from acknowledgement to completion.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}, []string{"operation", "result"}),
+
+		pruned: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "vagrant_server",
+			Name:      "pruned_total",
+			Help:      "Number of records removed by pruning.",
+		}, []string{"kind"}),
+	}
+}
+
+// observeJob records the duration of a job that has completed.
+func (m *serviceMetrics) observeJob(job *vagrant_server.Job, err error) {
+	start := job.AckTime
+	if start == nil {
+		start = job.AssignTime
+	}
+	if start == nil {
+		return
+	}
+
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+
+	m.jobDuration.WithLabelValues(serverptypes.JobOperation(job), result).
+		Observe(time.Since(start.AsTime()).Seconds())
+}
+
+// Describe implements prometheus.Collector
+func (s *service) Describe(ch chan<- *prometheus.Desc) {
+	ch <- jobsDesc
+	ch <- runnersDesc
+	ch <- dbSizeDesc
+	s.metrics.jobDuration.Describe(ch)
+	s.metrics.pruned.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (s *service) Collect(ch chan<- prometheus.Metric) {
+	if counts, err := s.state.JobCountByState(); err != nil {
+		ch <- prometheus.NewInvalidMetric(jobsDesc, err)
+	} else {
+		// Report every state so that a state without jobs reads as
+		// zero rather than disappearing.
+		for v, name := range vagrant_server.Job_State_name {
+			state := vagrant_server.Job_State(v)
+			ch <- prometheus.MustNewConstMetric(jobsDesc, prometheus.GaugeValue,
+				float64(counts[state]), strings.ToLower(name))
+		}
+	}
+
+	if count, err := s.state.RunnerCount(); err != nil {
+		ch <- prometheus.NewInvalidMetric(runnersDesc, err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(runnersDesc, prometheus.GaugeValue,
+			float64(count))
+	}
+
+	if size, err := s.state.DBSize(); err != nil {
+		ch <- prometheus.NewInvalidMetric(dbSizeDesc, err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(dbSizeDesc, prometheus.GaugeValue,
+			float64(size))
+	}
+
+	s.metrics.jobDuration.Collect(ch)
+	s.metrics.pruned.Collect(ch)
+}
+
+var _ prometheus.Collector = (*service)(nil)
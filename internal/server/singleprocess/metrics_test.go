@@ -0,0 +1,95 @@
+package singleprocess
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/vagrant/internal/server"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	serverptypes "github.com/hashicorp/vagrant/internal/server/ptypes"
+)
+
+func TestServiceMetrics(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	// Create our server
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	// Initialize our basis
+	TestBasis(t, client, serverptypes.TestBasis(t, nil))
+
+	// Create a job
+	queueResp, err := client.QueueJob(ctx, &vagrant_server.QueueJobRequest{Job: serverptypes.TestJobNew(t, nil)})
+	require.NoError(err)
+
+	// Register our runner
+	id, _ := TestRunner(t, client, nil)
+
+	// The job should be queued
+	scrape := func() string {
+		reg := prometheus.NewRegistry()
+		require.NoError(reg.Register(impl.(prometheus.Collector)))
+
+		families, err := reg.Gather()
+		require.NoError(err)
+
+		var buf bytes.Buffer
+		for _, mf := range families {
+			_, err := expfmt.MetricFamilyToText(&buf, mf)
+			require.NoError(err)
+		}
+
+		return buf.String()
+	}
+	out := scrape()
+	require.Contains(out, `vagrant_server_jobs{state="queued"} 1`)
+	require.Contains(out, `vagrant_server_jobs{state="success"} 0`)
+	require.Contains(out, "vagrant_server_runners 1")
+	require.Contains(out, "vagrant_server_db_size_bytes")
+
+	// Run the job to completion
+	stream, err := client.RunnerJobStream(ctx)
+	require.NoError(err)
+	require.NoError(stream.Send(&vagrant_server.RunnerJobStreamRequest{
+		Event: &vagrant_server.RunnerJobStreamRequest_Request_{
+			Request: &vagrant_server.RunnerJobStreamRequest_Request{
+				RunnerId: id,
+			},
+		},
+	}))
+
+	resp, err := stream.Recv()
+	require.NoError(err)
+	assignment, ok := resp.Event.(*vagrant_server.RunnerJobStreamResponse_Assignment)
+	require.True(ok, "should be an assignment")
+	require.Equal(queueResp.JobId, assignment.Assignment.Job.Id)
+
+	require.NoError(stream.Send(&vagrant_server.RunnerJobStreamRequest{
+		Event: &vagrant_server.RunnerJobStreamRequest_Ack_{
+			Ack: &vagrant_server.RunnerJobStreamRequest_Ack{},
+		},
+	}))
+	require.NoError(stream.Send(&vagrant_server.RunnerJobStreamRequest{
+		Event: &vagrant_server.RunnerJobStreamRequest_Complete_{
+			Complete: &vagrant_server.RunnerJobStreamRequest_Complete{},
+		},
+	}))
+
+	_, err = stream.Recv()
+	require.Equal(io.EOF, err)
+
+	// The job should be counted as complete with its duration recorded
+	out = scrape()
+	require.Contains(out, `vagrant_server_jobs{state="queued"} 0`)
+	require.Contains(out, `vagrant_server_jobs{state="success"} 1`)
+	require.Contains(out, `vagrant_server_job_duration_seconds_count{operation="noop",result="success"} 1`)
+}
@@ -26,10 +26,13 @@ func (s *service) runPrune(
 		case <-ctx.Done():
 			return
 		case <-tk.C:
-			err := s.state.Prune()
+			jobs, err := s.state.Prune()
 			if err != nil {
 				funclog.Error("error pruning data", "error", err)
+				continue
 			}
+
+			s.metrics.pruned.WithLabelValues("job_index").Add(float64(jobs))
 		}
 	}
 }
@@ -33,13 +33,25 @@ type service struct {
 	// that we fully shut down before returning.
 	bgWg sync.WaitGroup
 
+	// metrics are recorded as requests are handled and are collected
+	// along with metrics read from state.
+	metrics *serviceMetrics
+
+	// drainCh is closed when the service starts draining. No jobs are
+	// assigned to runners after that.
+	drainCh   chan struct{}
+	drainOnce sync.Once
+
 	vagrant_server.UnimplementedVagrantServer
 }
 
 // New returns a Vagrant server implementation that uses BoltDB plus
 // in-memory locks to operate safely.
 func New(opts ...Option) (vagrant_server.VagrantServer, error) {
-	var s service
+	s := service{
+		metrics: newServiceMetrics(),
+		drainCh: make(chan struct{}),
+	}
 	var cfg config
 	for _, opt := range opts {
 		if err := opt(&s, &cfg); err != nil {
@@ -0,0 +1,111 @@
+package singleprocess
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+const (
+	// maximumBlobSize is the largest blob that can be uploaded.
+	maximumBlobSize = 512 * 1024 * 1024
+
+	// blobChunkSize is the size of the chunks sent by GetBlob.
+	blobChunkSize = 1024 * 1024
+)
+
+func (s *service) UploadBlob(
+	srv vagrant_server.Vagrant_UploadBlobServer,
+) error {
+	log := hclog.FromContext(srv.Context())
+
+	// Read our first event which must be an Open event.
+	log.Trace("waiting for Open message")
+	req, err := srv.Recv()
+	if err != nil {
+		return err
+	}
+	open, ok := req.Event.(*vagrant_server.UploadBlobRequest_Open_)
+	if !ok {
+		return status.Errorf(codes.FailedPrecondition,
+			"first message must be Open type")
+	}
+	if open.Open.Digest == "" {
+		return status.Errorf(codes.InvalidArgument,
+			"blob digest must be set")
+	}
+	if open.Open.Size > maximumBlobSize {
+		return status.Errorf(codes.InvalidArgument,
+			"blob size %d exceeds the maximum of %d bytes", open.Open.Size, maximumBlobSize)
+	}
+
+	// Read all the data chunks until the client closes the stream
+	var buf bytes.Buffer
+	for {
+		req, err := srv.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		chunk, ok := req.Event.(*vagrant_server.UploadBlobRequest_Chunk)
+		if !ok {
+			log.Info("upload received unexpected event",
+				"type", fmt.Sprintf("%T", req.Event))
+			return status.Errorf(codes.FailedPrecondition,
+				"all messages after Open must be data chunks")
+		}
+
+		if buf.Len()+len(chunk.Chunk) > maximumBlobSize {
+			return status.Errorf(codes.InvalidArgument,
+				"blob exceeds the maximum size of %d bytes", maximumBlobSize)
+		}
+		buf.Write(chunk.Chunk)
+	}
+
+	blob, err := s.state.BlobPut(open.Open.Digest, buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	log.Debug("blob uploaded", "digest", blob.Digest, "size", blob.Size)
+
+	return srv.SendAndClose(&vagrant_server.UploadBlobResponse{
+		Digest: blob.Digest,
+	})
+}
+
+func (s *service) GetBlob(
+	req *vagrant_server.GetBlobRequest,
+	srv vagrant_server.Vagrant_GetBlobServer,
+) error {
+	_, data, err := s.state.BlobGet(req.Digest)
+	if err != nil {
+		return err
+	}
+
+	for len(data) > 0 {
+		n := blobChunkSize
+		if n > len(data) {
+			n = len(data)
+		}
+
+		if err := srv.Send(&vagrant_server.GetBlobResponse{
+			Chunk: data[:n],
+		}); err != nil {
+			return err
+		}
+
+		data = data[n:]
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package singleprocess
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant/internal/server"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	serverptypes "github.com/hashicorp/vagrant/internal/server/ptypes"
+)
+
+func TestServiceUploadBlob(t *testing.T) {
+	ctx := context.Background()
+
+	// Create our server
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(t, err)
+	client := server.TestServer(t, impl)
+
+	data := bytes.Repeat([]byte("vagrant"), 1024)
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+
+	t.Run("bad open", func(t *testing.T) {
+		require := require.New(t)
+
+		stream, err := client.UploadBlob(ctx)
+		require.NoError(err)
+		require.NoError(stream.Send(&vagrant_server.UploadBlobRequest{
+			Event: &vagrant_server.UploadBlobRequest_Chunk{
+				Chunk: data,
+			},
+		}))
+
+		resp, err := stream.CloseAndRecv()
+		require.Error(err)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+		require.Nil(resp)
+	})
+
+	t.Run("digest mismatch", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := testUploadBlob(t, client, "abcd", data)
+		require.Error(err)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("upload and get", func(t *testing.T) {
+		require := require.New(t)
+
+		resp, err := testUploadBlob(t, client, digest, data)
+		require.NoError(err)
+		require.Equal(digest, resp.Digest)
+
+		stream, err := client.GetBlob(ctx, &vagrant_server.GetBlobRequest{
+			Digest: digest,
+		})
+		require.NoError(err)
+
+		var result bytes.Buffer
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(err)
+			result.Write(resp.Chunk)
+		}
+		require.Equal(data, result.Bytes())
+	})
+
+	t.Run("queue job with uploaded workspace", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := client.QueueJob(ctx, &vagrant_server.QueueJobRequest{
+			Job: serverptypes.TestJobNew(t, &vagrant_server.Job{
+				DataSource: &vagrant_server.Job_DataSource{
+					Source: &vagrant_server.Job_DataSource_Upload{
+						Upload: &vagrant_server.Job_Upload{Digest: digest},
+					},
+				},
+			}),
+		})
+		require.NoError(err)
+	})
+
+	t.Run("queue job with missing workspace", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := client.QueueJob(ctx, &vagrant_server.QueueJobRequest{
+			Job: serverptypes.TestJobNew(t, &vagrant_server.Job{
+				DataSource: &vagrant_server.Job_DataSource{
+					Source: &vagrant_server.Job_DataSource_Upload{
+						Upload: &vagrant_server.Job_Upload{Digest: "missing"},
+					},
+				},
+			}),
+		})
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+}
+
+func testUploadBlob(
+	t *testing.T,
+	client vagrant_server.VagrantClient,
+	digest string,
+	data []byte,
+) (*vagrant_server.UploadBlobResponse, error) {
+	t.Helper()
+
+	stream, err := client.UploadBlob(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&vagrant_server.UploadBlobRequest{
+		Event: &vagrant_server.UploadBlobRequest_Open_{
+			Open: &vagrant_server.UploadBlobRequest_Open{
+				Digest: digest,
+				Size:   int64(len(data)),
+			},
+		},
+	}))
+
+	for len(data) > 0 {
+		n := 1024
+		if n > len(data) {
+			n = len(data)
+		}
+
+		require.NoError(t, stream.Send(&vagrant_server.UploadBlobRequest{
+			Event: &vagrant_server.UploadBlobRequest_Chunk{
+				Chunk: data[:n],
+			},
+		}))
+		data = data[n:]
+	}
+
+	return stream.CloseAndRecv()
+}
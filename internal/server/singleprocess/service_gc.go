@@ -0,0 +1,182 @@
+package singleprocess
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	configpkg "github.com/hashicorp/vagrant/internal/config"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	serverptypes "github.com/hashicorp/vagrant/internal/server/ptypes"
+)
+
+// GarbageCollect removes projects whose directory or Vagrantfile no
+// longer exists, and targets which are no longer defined by the Ruby
+// Vagrantfile of their project. Paths are checked on the filesystem
+// of the server, so this is only useful when the server runs on the
+// same host as the projects.
+//
+// Targets are only removed if they don't exist, matching how a project
+// scrubs its targets. A target which may still exist is listed as
+// orphaned instead, so it can still be managed or destroyed. A missing
+// project is kept as long as it has orphaned targets.
+func (s *service) GarbageCollect(
+	ctx context.Context,
+	req *vagrant_server.GarbageCollectRequest,
+) (*vagrant_server.GarbageCollectResponse, error) {
+	log := hclog.FromContext(ctx)
+
+	refs, err := s.state.ProjectList()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &vagrant_server.GarbageCollectResponse{}
+
+	// removeTarget removes the stale target if it doesn't exist, or
+	// lists it as orphaned otherwise.
+	removeTarget := func(t *vagrant_server.Target, ref *vagrant_plugin_sdk.Ref_Target) error {
+		if !serverptypes.TargetRemovable(t.State) {
+			log.Debug("keeping orphaned target which may still exist",
+				"project", ref.Project.Name, "target", t.Name, "state", t.State)
+			result.Orphaned = append(result.Orphaned, ref)
+			return nil
+		}
+
+		log.Debug("removing stale target",
+			"project", ref.Project.Name, "target", t.Name, "dry-run", req.DryRun)
+
+		if !req.DryRun {
+			if err := s.state.TargetDelete(ref); err != nil {
+				return err
+			}
+		}
+		result.Targets = append(result.Targets, ref)
+		return nil
+	}
+
+	for _, ref := range refs {
+		p, err := s.state.ProjectGet(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		pref := &vagrant_plugin_sdk.Ref_Project{
+			ResourceId: p.ResourceId,
+			Name:       p.Name,
+			Path:       p.Path,
+			Basis:      p.Basis,
+		}
+
+		if projectMissing(p) {
+			// Targets which may still exist keep their project, so the
+			// project can still be used to manage them.
+			var targets []*vagrant_server.Target
+			orphaned := false
+			for _, tref := range p.Targets {
+				t, err := s.state.TargetGet(tref)
+				if err != nil {
+					return nil, err
+				}
+				targets = append(targets, t)
+				orphaned = orphaned || !serverptypes.TargetRemovable(t.State)
+			}
+			if orphaned {
+				log.Debug("keeping project with missing path which has orphaned targets",
+					"project", p.Name, "path", p.Path)
+
+				for _, t := range targets {
+					if err := removeTarget(t, targetRef(t, pref)); err != nil {
+						return nil, err
+					}
+				}
+				continue
+			}
+
+			log.Debug("removing project with missing path",
+				"project", p.Name, "path", p.Path, "dry-run", req.DryRun)
+
+			if !req.DryRun {
+				if err := s.state.ProjectDelete(pref); err != nil {
+					return nil, err
+				}
+			}
+			result.Projects = append(result.Projects, pref)
+			continue
+		}
+
+		// Only the encoding of Ruby Vagrantfiles records which targets
+		// are defined. The stored configuration is from the last time
+		// the project was loaded, so it reflects the current Vagrantfile.
+		if req.ProjectsOnly || p.Configuration.GetFormat() != vagrant_server.Vagrantfile_RUBY {
+			continue
+		}
+
+		names, ok, err := configpkg.VagrantfileTargetNames(p.Configuration.GetUnfinalized())
+		if err != nil {
+			log.Warn("failed to read targets defined by project",
+				"project", p.Name, "error", err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		defined := map[string]struct{}{}
+		for _, n := range names {
+			defined[n] = struct{}{}
+		}
+
+		for _, tref := range p.Targets {
+			t, err := s.state.TargetGet(tref)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := defined[t.Name]; ok {
+				continue
+			}
+
+			if err := removeTarget(t, targetRef(t, pref)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !req.DryRun {
+		s.metrics.pruned.WithLabelValues("projects").Add(float64(len(result.Projects)))
+		s.metrics.pruned.WithLabelValues("targets").Add(float64(len(result.Targets)))
+	}
+
+	return result, nil
+}
+
+// targetRef returns a reference to the target of the project.
+func targetRef(t *vagrant_server.Target, project *vagrant_plugin_sdk.Ref_Project) *vagrant_plugin_sdk.Ref_Target {
+	return &vagrant_plugin_sdk.Ref_Target{
+		ResourceId: t.ResourceId,
+		Name:       t.Name,
+		Project:    project,
+	}
+}
+
+// projectMissing returns true if the directory or the Vagrantfile of
+// the project no longer exists.
+func projectMissing(p *vagrant_server.Project) bool {
+	paths := []string{p.Path}
+	if vp := p.Configuration.GetPath().GetPath(); vp != "" {
+		paths = append(paths, vp)
+	}
+
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return true
+		}
+	}
+
+	return false
+}
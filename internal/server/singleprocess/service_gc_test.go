@@ -0,0 +1,181 @@
+package singleprocess
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	configpkg "github.com/hashicorp/vagrant/internal/config"
+	"github.com/hashicorp/vagrant/internal/server"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	"github.com/hashicorp/vagrant/internal/serverclient"
+)
+
+func TestServiceGarbageCollect(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) *serverclient.VagrantClient {
+		require := require.New(t)
+
+		impl, err := New(WithDB(testDB(t)))
+		require.NoError(err)
+		client := server.TestServer(t, impl)
+
+		basisResp, err := client.UpsertBasis(ctx, &vagrant_server.UpsertBasisRequest{
+			Basis: &vagrant_server.Basis{Name: "mybasis"},
+		})
+		require.NoError(err)
+		basisRef := &vagrant_plugin_sdk.Ref_Basis{ResourceId: basisResp.Basis.ResourceId}
+
+		// The directories of these projects are removed
+		missing := filepath.Join(t.TempDir(), "missing")
+		require.NoError(os.Mkdir(missing, 0755))
+		missingKept := filepath.Join(t.TempDir(), "missing-kept")
+		require.NoError(os.Mkdir(missingKept, 0755))
+		project := func(name, dir string, v *vagrant_server.Vagrantfile) *vagrant_plugin_sdk.Ref_Project {
+			resp, err := client.UpsertProject(ctx, &vagrant_server.UpsertProjectRequest{
+				Project: &vagrant_server.Project{
+					Name:          name,
+					Path:          dir,
+					Basis:         basisRef,
+					Configuration: v,
+				},
+			})
+			require.NoError(err)
+			return &vagrant_plugin_sdk.Ref_Project{ResourceId: resp.Project.ResourceId}
+		}
+		targetState := func(name string, p *vagrant_plugin_sdk.Ref_Project, state vagrant_server.Operation_PhysicalState) {
+			_, err := client.UpsertTarget(ctx, &vagrant_server.UpsertTargetRequest{
+				Target: &vagrant_server.Target{Name: name, Project: p, State: state},
+			})
+			require.NoError(err)
+		}
+		target := func(name string, p *vagrant_plugin_sdk.Ref_Project) {
+			targetState(name, p, vagrant_server.Operation_UNKNOWN)
+		}
+
+		gone := project("gone", missing, nil)
+		target("default", gone)
+		require.NoError(os.Remove(missing))
+
+		// This project is kept since one of its targets is still running
+		kept := project("kept", missingKept, nil)
+		targetState("vm", kept, vagrant_server.Operation_CREATED)
+		targetState("never", kept, vagrant_server.Operation_NOT_CREATED)
+		require.NoError(os.Remove(missingKept))
+
+		current := project("current", t.TempDir(), &vagrant_server.Vagrantfile{
+			Format:      vagrant_server.Vagrantfile_RUBY,
+			Unfinalized: testDefinedTargets(t, "web"),
+		})
+		target("web", current)
+		target("db", current)
+		targetState("old", current, vagrant_server.Operation_DESTROYED)
+		// This target is no longer defined but is still running
+		targetState("running", current, vagrant_server.Operation_CREATED)
+
+		hcl := project("hcl", t.TempDir(), &vagrant_server.Vagrantfile{
+			Format:      vagrant_server.Vagrantfile_HCL,
+			Unfinalized: &vagrant_plugin_sdk.Args_Hash{},
+		})
+		target("app", hcl)
+
+		return client
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		require := require.New(t)
+		client := setup(t)
+
+		resp, err := client.GarbageCollect(ctx, &vagrant_server.GarbageCollectRequest{
+			DryRun: true,
+		})
+		require.NoError(err)
+		require.Len(resp.Projects, 1)
+		require.Equal("gone", resp.Projects[0].Name)
+		require.ElementsMatch([]string{"db", "old", "never"}, testTargetNames(resp.Targets))
+		require.ElementsMatch([]string{"running", "vm"}, testTargetNames(resp.Orphaned))
+
+		projects, err := client.ListProjects(ctx, &emptypb.Empty{})
+		require.NoError(err)
+		require.Len(projects.Projects, 4)
+
+		targets, err := client.ListTargets(ctx, &emptypb.Empty{})
+		require.NoError(err)
+		require.Len(targets.Targets, 8)
+	})
+
+	t.Run("removes stale projects and targets", func(t *testing.T) {
+		require := require.New(t)
+		client := setup(t)
+
+		resp, err := client.GarbageCollect(ctx, &vagrant_server.GarbageCollectRequest{})
+		require.NoError(err)
+		require.Len(resp.Projects, 1)
+		require.Len(resp.Targets, 3)
+		require.Len(resp.Orphaned, 2)
+
+		projects, err := client.ListProjects(ctx, &emptypb.Empty{})
+		require.NoError(err)
+		var names []string
+		for _, p := range projects.Projects {
+			names = append(names, p.Name)
+		}
+		require.ElementsMatch([]string{"current", "hcl", "kept"}, names)
+
+		targets, err := client.ListTargets(ctx, &emptypb.Empty{})
+		require.NoError(err)
+		require.ElementsMatch([]string{"web", "running", "app", "vm"},
+			testTargetNames(targets.Targets))
+	})
+
+	t.Run("projects only", func(t *testing.T) {
+		require := require.New(t)
+		client := setup(t)
+
+		resp, err := client.GarbageCollect(ctx, &vagrant_server.GarbageCollectRequest{
+			ProjectsOnly: true,
+		})
+		require.NoError(err)
+		require.Len(resp.Projects, 1)
+		require.Equal([]string{"never"}, testTargetNames(resp.Targets))
+		require.Equal([]string{"vm"}, testTargetNames(resp.Orphaned))
+
+		targets, err := client.ListTargets(ctx, &emptypb.Empty{})
+		require.NoError(err)
+		require.Len(targets.Targets, 6)
+	})
+}
+
+// testDefinedTargets builds the unfinalized configuration of a Ruby
+// Vagrantfile which defines the named targets.
+func testDefinedTargets(t *testing.T, names ...string) *vagrant_plugin_sdk.Args_Hash {
+	var defined vagrant_plugin_sdk.Args_Array
+	for _, n := range names {
+		defined.List = append(defined.List,
+			configpkg.TestAny(t, &vagrant_plugin_sdk.Args_Symbol{Str: n}))
+	}
+
+	return configpkg.TestHash(t,
+		"vm", &vagrant_plugin_sdk.Args_ConfigData{
+			Data: configpkg.TestHash(t,
+				&vagrant_plugin_sdk.Args_Symbol{Str: "__defined_vm_keys"}, &defined,
+			),
+		},
+	)
+}
+
+// testTargetNames returns the names of the targets.
+func testTargetNames(refs []*vagrant_plugin_sdk.Ref_Target) []string {
+	var names []string
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+
+	return names
+}
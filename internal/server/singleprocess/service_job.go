@@ -26,7 +26,12 @@ func (s *service) PruneOldJobs(
 	ctx context.Context,
 	_ *emptypb.Empty,
 ) (*emptypb.Empty, error) {
-	_, err := s.state.JobsDBPruneOld(maximumJobsIndexed)
+	jobs, blobs, err := s.state.JobsDBPruneOld(maximumJobsIndexed)
+	if err == nil {
+		s.metrics.pruned.WithLabelValues("jobs").Add(float64(jobs))
+		s.metrics.pruned.WithLabelValues("blobs").Add(float64(blobs))
+	}
+
 	return &emptypb.Empty{}, err
 }
 
@@ -122,6 +127,18 @@ func (s *service) QueueJob(
 	// 	job.DataSource = project.DataSource
 	// }
 
+	// If the job uses an uploaded workspace, the blob must exist
+	if src, ok := job.DataSource.GetSource().(*vagrant_server.Job_DataSource_Upload); ok {
+		exists, err := s.state.BlobExists(src.Upload.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, status.Errorf(codes.NotFound,
+				"uploaded workspace not found for digest: %s", src.Upload.Digest)
+		}
+	}
+
 	// Get the next id
 	id, err := server.Id()
 	if err != nil {
@@ -141,6 +158,9 @@ func (s *service) QueueJob(
 		job.ExpireTime = timestamppb.New(time.Now().Add(dur))
 	}
 
+	// The user is always the one that queued the job
+	job.User = userFromContext(ctx)
+
 	// Queue the job
 	if err := s.state.JobCreate(job); err != nil {
 		return nil, err
@@ -138,9 +138,26 @@ func (s *service) RunnerJobStream(
 		return err
 	}
 
-	// Get a job assignment for this runner
-	job, err := s.state.JobAssignForRunner(ctx, runner)
+	// Get a job assignment for this runner. We stop waiting for one if
+	// the server starts draining.
+	if s.draining() {
+		return status.Errorf(codes.Unavailable, "server is draining")
+	}
+	assignCtx, assignCancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-s.drainCh:
+			assignCancel()
+		case <-assignCtx.Done():
+		}
+	}()
+	job, err := s.state.JobAssignForRunner(assignCtx, runner)
+	assignCancel()
 	if err != nil {
+		if s.draining() {
+			return status.Errorf(codes.Unavailable, "server is draining")
+		}
+
 		return err
 	}
 
@@ -352,10 +369,21 @@ func (s *service) handleJobStreamRequest(
 	log.Trace("event received", "event", req.Event)
 	switch event := req.Event.(type) {
 	case *vagrant_server.RunnerJobStreamRequest_Complete_:
-		return s.state.JobComplete(job.Id, event.Complete.Result, nil)
+		if err := s.state.JobComplete(job.Id, event.Complete.Result, nil); err != nil {
+			return err
+		}
+
+		s.metrics.observeJob(job.Job, nil)
+		return nil
 
 	case *vagrant_server.RunnerJobStreamRequest_Error_:
-		return s.state.JobComplete(job.Id, nil, status.FromProto(event.Error.Error).Err())
+		jobErr := status.FromProto(event.Error.Error).Err()
+		if err := s.state.JobComplete(job.Id, nil, jobErr); err != nil {
+			return err
+		}
+
+		s.metrics.observeJob(job.Job, jobErr)
+		return nil
 
 	case *vagrant_server.RunnerJobStreamRequest_Heartbeat_:
 		return s.state.JobHeartbeat(job.Id)
@@ -0,0 +1,93 @@
+package singleprocess
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// defaultTargetLeaseTTL is used when a lease request does not set a TTL.
+const defaultTargetLeaseTTL = time.Minute
+
+func (s *service) AcquireTargetLease(
+	ctx context.Context,
+	req *vagrant_server.AcquireTargetLeaseRequest,
+) (*vagrant_server.AcquireTargetLeaseResponse, error) {
+	ttl, err := targetLeaseTTL(req.Ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the target so the lease is only taken on targets which
+	// exist, and so the lease names the target
+	target, err := s.state.TargetGet(req.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	lease, err := s.state.TargetLeaseAcquire(&vagrant_server.TargetLease{
+		Target: &vagrant_plugin_sdk.Ref_Target{
+			ResourceId: target.ResourceId,
+			Name:       target.Name,
+			Project:    target.Project,
+		},
+		JobId:  req.JobId,
+		Holder: req.Holder,
+	}, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vagrant_server.AcquireTargetLeaseResponse{Lease: lease}, nil
+}
+
+func (s *service) RenewTargetLease(
+	ctx context.Context,
+	req *vagrant_server.RenewTargetLeaseRequest,
+) (*vagrant_server.RenewTargetLeaseResponse, error) {
+	ttl, err := targetLeaseTTL(req.Ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	lease, err := s.state.TargetLeaseRenew(req.LeaseId, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vagrant_server.RenewTargetLeaseResponse{Lease: lease}, nil
+}
+
+func (s *service) ReleaseTargetLease(
+	ctx context.Context,
+	req *vagrant_server.ReleaseTargetLeaseRequest,
+) (*emptypb.Empty, error) {
+	if err := s.state.TargetLeaseRelease(req.LeaseId); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// targetLeaseTTL parses the TTL of a lease request.
+func targetLeaseTTL(v string) (time.Duration, error) {
+	if v == "" {
+		return defaultTargetLeaseTTL, nil
+	}
+
+	ttl, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid ttl %q: %s", v, err)
+	}
+	if ttl <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "ttl must be positive")
+	}
+
+	return ttl, nil
+}
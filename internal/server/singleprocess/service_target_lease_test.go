@@ -0,0 +1,92 @@
+package singleprocess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant/internal/server"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func TestServiceTargetLease(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	basisResp, err := client.UpsertBasis(ctx, &vagrant_server.UpsertBasisRequest{
+		Basis: &vagrant_server.Basis{Name: "mybasis"},
+	})
+	require.NoError(err)
+	projectResp, err := client.UpsertProject(ctx, &vagrant_server.UpsertProjectRequest{
+		Project: &vagrant_server.Project{
+			Name:  "myproject",
+			Basis: &vagrant_plugin_sdk.Ref_Basis{ResourceId: basisResp.Basis.ResourceId},
+		},
+	})
+	require.NoError(err)
+	targetResp, err := client.UpsertTarget(ctx, &vagrant_server.UpsertTargetRequest{
+		Target: &vagrant_server.Target{
+			Name:    "mytarget",
+			Project: &vagrant_plugin_sdk.Ref_Project{ResourceId: projectResp.Project.ResourceId},
+		},
+	})
+	require.NoError(err)
+	ref := &vagrant_plugin_sdk.Ref_Target{ResourceId: targetResp.Target.ResourceId}
+
+	acquired, err := client.AcquireTargetLease(ctx, &vagrant_server.AcquireTargetLeaseRequest{
+		Target: ref,
+		JobId:  "job-a",
+		Ttl:    "1m",
+	})
+	require.NoError(err)
+	require.Equal("mytarget", acquired.Lease.Target.Name)
+
+	// The error for a held lease names the target and the job
+	_, err = client.AcquireTargetLease(ctx, &vagrant_server.AcquireTargetLeaseRequest{
+		Target: ref,
+		JobId:  "job-b",
+	})
+	require.Error(err)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+	require.Contains(err.Error(), "mytarget")
+	require.Contains(err.Error(), "job-a")
+
+	renewed, err := client.RenewTargetLease(ctx, &vagrant_server.RenewTargetLeaseRequest{
+		LeaseId: acquired.Lease.Id,
+		Ttl:     "2m",
+	})
+	require.NoError(err)
+	require.True(renewed.Lease.ExpireTime.AsTime().After(acquired.Lease.ExpireTime.AsTime()))
+
+	_, err = client.ReleaseTargetLease(ctx, &vagrant_server.ReleaseTargetLeaseRequest{
+		LeaseId: acquired.Lease.Id,
+	})
+	require.NoError(err)
+
+	_, err = client.AcquireTargetLease(ctx, &vagrant_server.AcquireTargetLeaseRequest{
+		Target: ref,
+		JobId:  "job-b",
+	})
+	require.NoError(err)
+
+	// Invalid TTLs are rejected
+	_, err = client.RenewTargetLease(ctx, &vagrant_server.RenewTargetLeaseRequest{
+		LeaseId: acquired.Lease.Id,
+		Ttl:     "soon",
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// Targets which don't exist can't be leased
+	_, err = client.AcquireTargetLease(ctx, &vagrant_server.AcquireTargetLeaseRequest{
+		Target: &vagrant_plugin_sdk.Ref_Target{ResourceId: "nope"},
+	})
+	require.Equal(codes.NotFound, status.Code(err))
+}
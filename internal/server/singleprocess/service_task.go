@@ -6,6 +6,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
 	"github.com/hashicorp/vagrant/internal/server"
 	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
 	"github.com/hashicorp/vagrant/internal/server/singleprocess/state"
@@ -30,6 +31,46 @@ func (s *service) UpsertTask(
 		result.Id = id
 	}
 
+	// The user is recorded for auditing, so any user sent by the caller
+	// is ignored. Tasks are upserted by the runner executing the job, so
+	// the user is the one that queued the job rather than the caller.
+	result.User = userFromContext(ctx)
+	if result.JobId != "" {
+		job, err := s.state.JobById(result.JobId, nil)
+		if err != nil {
+			return nil, err
+		}
+		if job != nil {
+			result.User = job.User
+		}
+	}
+
+	// Tasks are indexed by their scope, which needs to be a complete
+	// reference
+	switch scope := result.Scope.(type) {
+	case *vagrant_server.Task_Target:
+		ref, err := s.taskScopeRef(scope.Target)
+		if err != nil {
+			return nil, err
+		}
+		scope.Target = ref.(*vagrant_plugin_sdk.Ref_Target)
+	case *vagrant_server.Task_Project:
+		ref, err := s.taskScopeRef(scope.Project)
+		if err != nil {
+			return nil, err
+		}
+		scope.Project = ref.(*vagrant_plugin_sdk.Ref_Project)
+	case *vagrant_server.Task_Basis:
+		ref, err := s.taskScopeRef(scope.Basis)
+		if err != nil {
+			return nil, err
+		}
+		scope.Basis = ref.(*vagrant_plugin_sdk.Ref_Basis)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument,
+			"task must be scoped to a basis, project, or target")
+	}
+
 	if err := s.state.TaskPut(!insert, result); err != nil {
 		return nil, err
 	}
@@ -37,12 +78,25 @@ func (s *service) UpsertTask(
 	return &vagrant_server.UpsertTaskResponse{Task: result}, nil
 }
 
-// TODO: test
 func (s *service) ListTasks(
 	ctx context.Context,
 	req *vagrant_server.ListTasksRequest,
 ) (*vagrant_server.ListTasksResponse, error) {
-	result, err := s.state.TaskList(req.Scope,
+	var scope interface{}
+	switch r := req.Scope.(type) {
+	case *vagrant_server.ListTasksRequest_Target:
+		scope = r.Target
+	case *vagrant_server.ListTasksRequest_Project:
+		scope = r.Project
+	case *vagrant_server.ListTasksRequest_Basis:
+		scope = r.Basis
+	}
+	ref, err := s.taskScopeRef(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.state.TaskList(ref,
 		state.ListWithStatusFilter(req.Status...),
 		state.ListWithOrder(req.Order),
 		state.ListWithPhysicalState(req.PhysicalState),
@@ -55,12 +109,25 @@ func (s *service) ListTasks(
 	return &vagrant_server.ListTasksResponse{Tasks: result}, nil
 }
 
-// TODO: test
 func (s *service) GetLatestTask(
 	ctx context.Context,
 	req *vagrant_server.GetLatestTaskRequest,
 ) (*vagrant_server.Task, error) {
-	return s.state.TaskLatest(req.Scope)
+	var scope interface{}
+	switch r := req.Scope.(type) {
+	case *vagrant_server.GetLatestTaskRequest_Target:
+		scope = r.Target
+	case *vagrant_server.GetLatestTaskRequest_Project:
+		scope = r.Project
+	case *vagrant_server.GetLatestTaskRequest_Basis:
+		scope = r.Basis
+	}
+	ref, err := s.taskScopeRef(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.state.TaskLatest(ref)
 }
 
 // GetTask returns a Task based on ID
@@ -70,3 +137,65 @@ func (s *service) GetTask(
 ) (*vagrant_server.Task, error) {
 	return s.state.TaskGet(req.Ref)
 }
+
+// taskScopeRef returns a complete reference to the basis, project, or
+// target of a task scope. Tasks are indexed by the resource IDs of each
+// level of their scope, which a reference from a request may not have.
+func (s *service) taskScopeRef(ref interface{}) (interface{}, error) {
+	switch r := ref.(type) {
+	case *vagrant_plugin_sdk.Ref_Target:
+		if r == nil {
+			break
+		}
+		t, err := s.state.TargetGet(r)
+		if err != nil {
+			return nil, err
+		}
+		p, err := s.taskScopeRef(t.Project)
+		if err != nil {
+			return nil, err
+		}
+
+		return &vagrant_plugin_sdk.Ref_Target{
+			ResourceId: t.ResourceId,
+			Name:       t.Name,
+			Project:    p.(*vagrant_plugin_sdk.Ref_Project),
+		}, nil
+	case *vagrant_plugin_sdk.Ref_Project:
+		if r == nil {
+			break
+		}
+		p, err := s.state.ProjectGet(r)
+		if err != nil {
+			return nil, err
+		}
+		b, err := s.taskScopeRef(p.Basis)
+		if err != nil {
+			return nil, err
+		}
+
+		return &vagrant_plugin_sdk.Ref_Project{
+			ResourceId: p.ResourceId,
+			Name:       p.Name,
+			Path:       p.Path,
+			Basis:      b.(*vagrant_plugin_sdk.Ref_Basis),
+		}, nil
+	case *vagrant_plugin_sdk.Ref_Basis:
+		if r == nil {
+			break
+		}
+		b, err := s.state.BasisGet(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return &vagrant_plugin_sdk.Ref_Basis{
+			ResourceId: b.ResourceId,
+			Name:       b.Name,
+			Path:       b.Path,
+		}, nil
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument,
+		"a basis, project, or target is required")
+}
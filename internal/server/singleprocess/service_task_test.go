@@ -7,7 +7,9 @@ import (
 	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
 	"github.com/hashicorp/vagrant/internal/server"
 	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	serverptypes "github.com/hashicorp/vagrant/internal/server/ptypes"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestServiceTask(t *testing.T) {
@@ -15,14 +17,13 @@ func TestServiceTask(t *testing.T) {
 
 	t.Run("set and get", func(t *testing.T) {
 		require := require.New(t)
-		t.Skip("We are leaving the Task Set/Get operations broken for now; see TODO in state.TaskPut")
 		db := testDB(t)
 		impl, err := New(WithDB(db))
 		require.NoError(err)
 		client := server.TestServer(t, impl)
 
 		// need a basis to have a project
-		_, err = client.UpsertBasis(ctx, &vagrant_server.UpsertBasisRequest{
+		basisResp, err := client.UpsertBasis(ctx, &vagrant_server.UpsertBasisRequest{
 			Basis: &vagrant_server.Basis{
 				Name: "mybasis",
 			},
@@ -31,14 +32,15 @@ func TestServiceTask(t *testing.T) {
 
 		resp, err := client.UpsertTask(ctx, &vagrant_server.UpsertTaskRequest{
 			Task: &vagrant_server.Task{
-				Scope: &vagrant_server.Task_Basis{Basis: &vagrant_plugin_sdk.Ref_Basis{Name: "mybasis"}},
+				Scope: &vagrant_server.Task_Basis{Basis: &vagrant_plugin_sdk.Ref_Basis{ResourceId: basisResp.Basis.ResourceId}},
 				Task:  "mytask",
 			},
 		})
 		require.NoError(err)
 		require.NotNil(resp)
 		require.NotEmpty(resp.Task.Id)
-		require.Equal("mybasis", resp.Task.Task)
+		require.Equal("mytask", resp.Task.Task)
+		require.Equal("mybasis", resp.Task.GetBasis().Name)
 
 		getResp, err := client.GetTask(ctx, &vagrant_server.GetTaskRequest{
 			Ref: &vagrant_server.Ref_Operation{Target: &vagrant_server.Ref_Operation_Id{Id: resp.Task.Id}},
@@ -47,4 +49,89 @@ func TestServiceTask(t *testing.T) {
 		require.NotNil(getResp)
 		require.Equal("mytask", getResp.Task)
 	})
+	t.Run("list and latest", func(t *testing.T) {
+		require := require.New(t)
+		db := testDB(t)
+		impl, err := New(WithDB(db))
+		require.NoError(err)
+		client := server.TestServer(t, impl)
+
+		basisResp, err := client.UpsertBasis(ctx, &vagrant_server.UpsertBasisRequest{
+			Basis: &vagrant_server.Basis{Name: "mybasis"},
+		})
+		require.NoError(err)
+		projectResp, err := client.UpsertProject(ctx, &vagrant_server.UpsertProjectRequest{
+			Project: &vagrant_server.Project{
+				Name:  "myproject",
+				Basis: &vagrant_plugin_sdk.Ref_Basis{ResourceId: basisResp.Basis.ResourceId},
+			},
+		})
+		require.NoError(err)
+
+		// Only the resource ID of the project is given, the rest of the
+		// scope is filled in by the server
+		projectRef := &vagrant_plugin_sdk.Ref_Project{ResourceId: projectResp.Project.ResourceId}
+		for _, state := range []vagrant_server.Status_State{
+			vagrant_server.Status_SUCCESS,
+			vagrant_server.Status_ERROR,
+		} {
+			_, err := client.UpsertTask(ctx, &vagrant_server.UpsertTaskRequest{
+				Task: &vagrant_server.Task{
+					Scope:  &vagrant_server.Task_Project{Project: projectRef},
+					Task:   "up",
+					Status: &vagrant_server.Status{State: state, StartTime: timestamppb.Now(), CompleteTime: timestamppb.Now()},
+				},
+			})
+			require.NoError(err)
+		}
+
+		listResp, err := client.ListTasks(ctx, &vagrant_server.ListTasksRequest{
+			Scope: &vagrant_server.ListTasksRequest_Project{Project: projectRef},
+		})
+		require.NoError(err)
+		require.Len(listResp.Tasks, 2)
+
+		latest, err := client.GetLatestTask(ctx, &vagrant_server.GetLatestTaskRequest{
+			Scope: &vagrant_server.GetLatestTaskRequest_Project{Project: projectRef},
+		})
+		require.NoError(err)
+		require.Equal(vagrant_server.Status_SUCCESS, latest.Status.State)
+
+		// A task must have a scope
+		_, err = client.UpsertTask(ctx, &vagrant_server.UpsertTaskRequest{
+			Task: &vagrant_server.Task{Task: "up"},
+		})
+		require.Error(err)
+	})
+	t.Run("records the authenticated user", func(t *testing.T) {
+		require := require.New(t)
+		impl, err := New(WithDB(testDB(t)))
+		require.NoError(err)
+		s := impl.(*service)
+
+		basis := &vagrant_server.Basis{Name: "mybasis"}
+		require.NoError(s.state.BasisPut(basis))
+		scope := &vagrant_server.Task_Basis{
+			Basis: &vagrant_plugin_sdk.Ref_Basis{ResourceId: basis.ResourceId},
+		}
+
+		// The user sent with the task is ignored
+		userCtx := context.WithValue(ctx, userKey{}, "alice")
+		resp, err := s.UpsertTask(userCtx, &vagrant_server.UpsertTaskRequest{
+			Task: &vagrant_server.Task{Scope: scope, Task: "up", User: "mallory"},
+		})
+		require.NoError(err)
+		require.Equal("alice", resp.Task.User)
+
+		// Tasks of a job are recorded as the user that queued the job
+		require.NoError(s.state.JobCreate(serverptypes.TestJobNew(t, &vagrant_server.Job{
+			Id:   "A",
+			User: "bob",
+		})))
+		resp, err = s.UpsertTask(userCtx, &vagrant_server.UpsertTaskRequest{
+			Task: &vagrant_server.Task{Scope: scope, Task: "up", JobId: "A", User: "mallory"},
+		})
+		require.NoError(err)
+		require.Equal("bob", resp.Task.User)
+	})
 }
@@ -0,0 +1,42 @@
+package singleprocess
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func (s *service) GetVagrantfileCache(
+	ctx context.Context,
+	req *vagrant_server.GetVagrantfileCacheRequest,
+) (*vagrant_server.GetVagrantfileCacheResponse, error) {
+	result, err := s.state.VagrantfileCacheGet(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vagrant_server.GetVagrantfileCacheResponse{Cache: result}, nil
+}
+
+func (s *service) UpsertVagrantfileCache(
+	ctx context.Context,
+	req *vagrant_server.UpsertVagrantfileCacheRequest,
+) (*emptypb.Empty, error) {
+	cache := req.Cache
+	if cache == nil || cache.Key == "" || cache.Path == "" {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"vagrantfile cache entry must have a key and path")
+	}
+
+	cache.UpdatedAt = timestamppb.Now()
+	if err := s.state.VagrantfileCachePut(cache); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,51 @@
+package singleprocess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant/internal/server"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func TestServiceVagrantfileCache(t *testing.T) {
+	ctx := context.Background()
+
+	require := require.New(t)
+	impl, err := New(WithDB(testDB(t)))
+	require.NoError(err)
+	client := server.TestServer(t, impl)
+
+	_, err = client.GetVagrantfileCache(ctx, &vagrant_server.GetVagrantfileCacheRequest{
+		Key: "foo",
+	})
+	require.Equal(codes.NotFound, status.Code(err))
+
+	// The key and path are required
+	_, err = client.UpsertVagrantfileCache(ctx, &vagrant_server.UpsertVagrantfileCacheRequest{
+		Cache: &vagrant_server.VagrantfileCache{Key: "foo"},
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = client.UpsertVagrantfileCache(ctx, &vagrant_server.UpsertVagrantfileCacheRequest{
+		Cache: &vagrant_server.VagrantfileCache{
+			Key:  "foo",
+			Path: "/a/Vagrantfile",
+			Vagrantfile: &vagrant_server.Vagrantfile{
+				Format: vagrant_server.Vagrantfile_RUBY,
+			},
+		},
+	})
+	require.NoError(err)
+
+	resp, err := client.GetVagrantfileCache(ctx, &vagrant_server.GetVagrantfileCacheRequest{
+		Key: "foo",
+	})
+	require.NoError(err)
+	require.Equal(vagrant_server.Vagrantfile_RUBY, resp.Cache.Vagrantfile.Format)
+	require.NotNil(resp.Cache.UpdatedAt)
+}
@@ -0,0 +1,98 @@
+package state
+
+import (
+	"encoding/binary"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+var auditBucket = []byte("audit_events")
+
+// The maximum number of audit events to keep. Once this is reached, the
+// oldest event is removed whenever a new event is recorded.
+const auditMaxEvents = 50000
+
+func init() {
+	dbBuckets = append(dbBuckets, auditBucket)
+}
+
+// AuditEventPut records an audit event. The id and time of the event are
+// set if they are not set already.
+func (s *State) AuditEventPut(ev *vagrant_server.AuditEvent) error {
+	if ev.Id == "" {
+		id, err := s.newResourceId()
+		if err != nil {
+			return err
+		}
+		ev.Id = id
+	}
+	if ev.Time == nil {
+		ev.Time = timestamppb.Now()
+	}
+
+	return s.db.Update(func(dbTxn *bolt.Tx) error {
+		b := dbTxn.Bucket(auditBucket)
+
+		// Events are keyed by sequence so they can be iterated in the
+		// order they were recorded, and the oldest can be found quickly.
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		if err := dbPut(b, auditKey(seq), ev); err != nil {
+			return err
+		}
+
+		if seq > auditMaxEvents {
+			return b.Delete(auditKey(seq - auditMaxEvents))
+		}
+
+		return nil
+	})
+}
+
+// AuditEventList returns the audit events matching the request, most
+// recent first.
+func (s *State) AuditEventList(req *vagrant_server.ListAuditEventsRequest) ([]*vagrant_server.AuditEvent, error) {
+	var result []*vagrant_server.AuditEvent
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		c := dbTxn.Bucket(auditBucket).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			var ev vagrant_server.AuditEvent
+			if err := proto.Unmarshal(v, &ev); err != nil {
+				return err
+			}
+
+			// Events are ordered by time so we can stop at the first
+			// event which is too old.
+			if req.Since != nil && ev.Time.AsTime().Before(req.Since.AsTime()) {
+				break
+			}
+			if req.User != "" && ev.User != req.User {
+				continue
+			}
+			if req.Endpoint != "" && ev.Endpoint != req.Endpoint {
+				continue
+			}
+
+			result = append(result, &ev)
+			if req.Limit > 0 && len(result) >= int(req.Limit) {
+				break
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+func auditKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return key
+}
@@ -0,0 +1,61 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func TestAuditEvent(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	defer s.Close()
+
+	old := time.Now().Add(-time.Hour)
+	require.NoError(s.AuditEventPut(&vagrant_server.AuditEvent{
+		User:     "alice",
+		Endpoint: "QueueJob",
+		Time:     timestamppb.New(old),
+	}))
+	require.NoError(s.AuditEventPut(&vagrant_server.AuditEvent{
+		User:     "bob",
+		Endpoint: "DeleteTarget",
+	}))
+	require.NoError(s.AuditEventPut(&vagrant_server.AuditEvent{
+		User:     "alice",
+		Endpoint: "DeleteTarget",
+	}))
+
+	// All events, most recent first
+	events, err := s.AuditEventList(&vagrant_server.ListAuditEventsRequest{})
+	require.NoError(err)
+	require.Len(events, 3)
+	require.Equal("alice", events[0].User)
+	require.Equal("DeleteTarget", events[0].Endpoint)
+	require.NotEmpty(events[0].Id)
+	require.Equal("QueueJob", events[2].Endpoint)
+
+	// Filtered
+	events, err = s.AuditEventList(&vagrant_server.ListAuditEventsRequest{User: "alice"})
+	require.NoError(err)
+	require.Len(events, 2)
+
+	events, err = s.AuditEventList(&vagrant_server.ListAuditEventsRequest{Endpoint: "DeleteTarget"})
+	require.NoError(err)
+	require.Len(events, 2)
+
+	events, err = s.AuditEventList(&vagrant_server.ListAuditEventsRequest{
+		Since: timestamppb.New(old.Add(time.Minute)),
+	})
+	require.NoError(err)
+	require.Len(events, 2)
+
+	events, err = s.AuditEventList(&vagrant_server.ListAuditEventsRequest{Limit: 1})
+	require.NoError(err)
+	require.Len(events, 1)
+}
@@ -0,0 +1,184 @@
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+var (
+	// blobBucket stores the metadata for uploaded blobs
+	blobBucket = []byte("blobs")
+
+	// blobDataBucket stores the raw data for uploaded blobs
+	blobDataBucket = []byte("blob-data")
+
+	// blobOrphanTimeout is how long an uploaded blob is kept when no
+	// job references it. This gives clients time to queue the job that
+	// uses the blob after uploading it.
+	blobOrphanTimeout = 1 * time.Hour
+)
+
+func init() {
+	dbBuckets = append(dbBuckets, blobBucket, blobDataBucket)
+}
+
+// BlobPut stores the given data as a blob. The data must match the
+// given hex encoded SHA-256 digest. If a blob with the digest already
+// exists its upload time is refreshed.
+func (s *State) BlobPut(digest string, data []byte) (*vagrant_server.Blob, error) {
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != digest {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"blob digest mismatch: expected %s, got %s", digest, actual)
+	}
+
+	blob := &vagrant_server.Blob{
+		Digest:     digest,
+		Size:       int64(len(data)),
+		CreateTime: timestamppb.Now(),
+	}
+
+	err := s.db.Update(func(dbTxn *bolt.Tx) error {
+		id := []byte(digest)
+		if err := dbPut(dbTxn.Bucket(blobBucket), id, blob); err != nil {
+			return err
+		}
+
+		b := dbTxn.Bucket(blobDataBucket)
+		if b.Get(id) != nil {
+			return nil
+		}
+
+		if err := b.Put(id, data); err != nil {
+			return status.Errorf(codes.Aborted, "failed to write data: %s", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blob, nil
+}
+
+// BlobGet returns the metadata and data for the blob with the given digest.
+func (s *State) BlobGet(digest string) (*vagrant_server.Blob, []byte, error) {
+	var (
+		blob vagrant_server.Blob
+		data []byte
+	)
+
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		id := []byte(digest)
+		if err := dbGet(dbTxn.Bucket(blobBucket), id, &blob); err != nil {
+			return err
+		}
+
+		raw := dbTxn.Bucket(blobDataBucket).Get(id)
+		if raw == nil {
+			return status.Errorf(codes.NotFound, "blob data not found for digest: %s", digest)
+		}
+
+		// Data from bolt is only valid for the life of the transaction
+		data = make([]byte, len(raw))
+		copy(data, raw)
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &blob, data, nil
+}
+
+// BlobExists returns true if a blob with the given digest is stored.
+func (s *State) BlobExists(digest string) (bool, error) {
+	var exists bool
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		exists = dbTxn.Bucket(blobBucket).Get([]byte(digest)) != nil
+		return nil
+	})
+
+	return exists, err
+}
+
+// BlobsPruneUnreferenced deletes any blobs that are not referenced by a
+// job and that were uploaded longer ago than the orphan timeout.
+func (s *State) BlobsPruneUnreferenced() (int, error) {
+	var deleted int
+	err := s.db.Update(func(dbTxn *bolt.Tx) error {
+		var err error
+		deleted, err = s.blobsPruneUnreferenced(dbTxn)
+		return err
+	})
+
+	return deleted, err
+}
+
+func (s *State) blobsPruneUnreferenced(dbTxn *bolt.Tx) (int, error) {
+	// Collect the blobs that are still in use by a job
+	referenced := map[string]struct{}{}
+	err := dbTxn.Bucket(jobBucket).ForEach(func(k, v []byte) error {
+		var job vagrant_server.Job
+		if err := proto.Unmarshal(v, &job); err != nil {
+			return err
+		}
+
+		if src, ok := job.DataSource.GetSource().(*vagrant_server.Job_DataSource_Upload); ok {
+			referenced[src.Upload.Digest] = struct{}{}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Collect the blobs to delete first since keys can't be deleted
+	// while iterating the bucket.
+	cutoff := time.Now().Add(-blobOrphanTimeout)
+	var toDelete [][]byte
+	bucket := dbTxn.Bucket(blobBucket)
+	err = bucket.ForEach(func(k, v []byte) error {
+		if _, ok := referenced[string(k)]; ok {
+			return nil
+		}
+
+		var blob vagrant_server.Blob
+		if err := proto.Unmarshal(v, &blob); err != nil {
+			return err
+		}
+
+		if blob.CreateTime.AsTime().Before(cutoff) {
+			toDelete = append(toDelete, append([]byte(nil), k...))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	dataBucket := dbTxn.Bucket(blobDataBucket)
+	for _, k := range toDelete {
+		if err := bucket.Delete(k); err != nil {
+			return 0, err
+		}
+		if err := dataBucket.Delete(k); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(toDelete), nil
+}
@@ -0,0 +1,133 @@
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	serverptypes "github.com/hashicorp/vagrant/internal/server/ptypes"
+)
+
+func TestBlob(t *testing.T) {
+	t.Run("Put and Get", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		data := []byte("hello")
+		digest := testBlobDigest(data)
+
+		blob, err := s.BlobPut(digest, data)
+		require.NoError(err)
+		require.Equal(digest, blob.Digest)
+		require.Equal(int64(len(data)), blob.Size)
+
+		exists, err := s.BlobExists(digest)
+		require.NoError(err)
+		require.True(exists)
+
+		blob, result, err := s.BlobGet(digest)
+		require.NoError(err)
+		require.Equal(digest, blob.Digest)
+		require.Equal(data, result)
+	})
+
+	t.Run("Put with mismatched digest", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		_, err := s.BlobPut(testBlobDigest([]byte("other")), []byte("hello"))
+		require.Error(err)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Get missing", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		_, _, err := s.BlobGet("nope")
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Prune unreferenced", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		// Reset the orphan timeout so everything is eligible
+		defer func(v time.Duration) { blobOrphanTimeout = v }(blobOrphanTimeout)
+		blobOrphanTimeout = 0
+
+		used := []byte("used")
+		unused := []byte("unused")
+		for _, data := range [][]byte{used, unused} {
+			_, err := s.BlobPut(testBlobDigest(data), data)
+			require.NoError(err)
+		}
+
+		// Create a job referencing one of the blobs
+		require.NoError(s.JobCreate(serverptypes.TestJobNew(t, &vagrant_server.Job{
+			Id: "A",
+			DataSource: &vagrant_server.Job_DataSource{
+				Source: &vagrant_server.Job_DataSource_Upload{
+					Upload: &vagrant_server.Job_Upload{
+						Digest: testBlobDigest(used),
+					},
+				},
+			},
+		})))
+
+		deleted, err := s.BlobsPruneUnreferenced()
+		require.NoError(err)
+		require.Equal(1, deleted)
+
+		exists, err := s.BlobExists(testBlobDigest(used))
+		require.NoError(err)
+		require.True(exists)
+
+		exists, err = s.BlobExists(testBlobDigest(unused))
+		require.NoError(err)
+		require.False(exists)
+
+		// Pruning the job should remove the blob it used
+		_, _, err = s.JobsDBPruneOld(0)
+		require.NoError(err)
+
+		exists, err = s.BlobExists(testBlobDigest(used))
+		require.NoError(err)
+		require.False(exists)
+	})
+
+	t.Run("Prune keeps recent uploads", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		data := []byte("hello")
+		_, err := s.BlobPut(testBlobDigest(data), data)
+		require.NoError(err)
+
+		deleted, err := s.BlobsPruneUnreferenced()
+		require.NoError(err)
+		require.Equal(0, deleted)
+	})
+}
+
+func testBlobDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
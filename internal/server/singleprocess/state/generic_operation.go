@@ -397,14 +397,7 @@ func (op *genericOperation) dbPut(
 	value proto.Message,
 ) (err error) {
 	// Get our ref and ensure that it's created
-	var ref interface{}
-	for _, k := range []string{"Machine", "Project", "Basis"} {
-		ref = op.valueField(value, k)
-		if ref != nil {
-			break
-		}
-	}
-
+	ref := op.valueRef(value)
 	if ref == nil {
 		return status.Errorf(codes.Internal,
 			"state: Machine, Project, or Basis must be set on value %T", value)
@@ -545,17 +538,7 @@ func (op *genericOperation) indexInit(s *State, dbTxn *bolt.Tx, memTxn *memdb.Tx
 		if v := op.valueField(result, "Sequence"); v != nil {
 			seq := v.(uint64)
 
-			var current *uint64
-			for _, k := range []string{"Machine", "Project", "Basis"} {
-				ref := op.valueField(result, k)
-				if ref == nil {
-					continue
-				}
-				current = op.getSeq(ref)
-				if current != nil {
-					break
-				}
-			}
+			current := op.getSeq(op.valueRef(result))
 			if current != nil && seq > *current {
 				*current = seq
 			}
@@ -601,16 +584,19 @@ func (op *genericOperation) indexPut(s *State, txn *memdb.Txn, value proto.Messa
 	// Get any reference information we can extract from the operation
 	var basis, project, machine string
 
-	if ref := op.valueField(value, "Machine").(*vagrant_plugin_sdk.Ref_Target); ref != nil {
+	switch ref := op.valueRef(value).(type) {
+	case *vagrant_plugin_sdk.Ref_Target:
 		basis = ref.Project.Basis.ResourceId
 		project = ref.Project.ResourceId
 		machine = ref.ResourceId
-	} else if ref := op.valueField(value, "Project").(*vagrant_plugin_sdk.Ref_Project); ref != nil {
+	case *vagrant_plugin_sdk.Ref_Project:
 		basis = ref.Basis.ResourceId
 		project = ref.ResourceId
-	} else {
-		ref := op.valueField(value, "Basis").(*vagrant_plugin_sdk.Ref_Basis)
+	case *vagrant_plugin_sdk.Ref_Basis:
 		basis = ref.ResourceId
+	default:
+		return status.Errorf(codes.Internal,
+			"state: Machine, Project, or Basis must be set on value %T", value)
 	}
 
 	return txn.Insert(op.memTableName(), &operationIndexRecord{
@@ -624,6 +610,39 @@ func (op *genericOperation) indexPut(s *State, txn *memdb.Txn, value proto.Messa
 	})
 }
 
+// valueRef returns the reference to the machine, project, or basis the
+// value is for. This is read from the Machine, Project, or Basis field,
+// or from the Scope field if the value uses a oneof for its scope.
+func (op *genericOperation) valueRef(value interface{}) interface{} {
+	for _, k := range []string{"Machine", "Project", "Basis"} {
+		if f := op.valueFieldReflect(value, k); f.IsValid() && !f.IsNil() {
+			return f.Interface()
+		}
+	}
+
+	// The oneof wrapper is a pointer to a struct with the reference
+	// as its only field
+	f := op.valueFieldReflect(value, "Scope")
+	if !f.IsValid() || f.IsNil() {
+		return nil
+	}
+	wrapper := f.Elem()
+	for wrapper.Kind() == reflect.Ptr {
+		if wrapper.IsNil() {
+			return nil
+		}
+		wrapper = wrapper.Elem()
+	}
+	if wrapper.Kind() != reflect.Struct || wrapper.NumField() == 0 {
+		return nil
+	}
+	if ref := wrapper.Field(0); ref.Kind() == reflect.Ptr && !ref.IsNil() {
+		return ref.Interface()
+	}
+
+	return nil
+}
+
 func (op *genericOperation) valueField(value interface{}, field string) interface{} {
 	fv := op.valueFieldReflect(value, field)
 	if !fv.IsValid() {
@@ -677,15 +696,15 @@ func (op *genericOperation) memSchema() *memdb.TableSchema {
 							Field:     "Basis",
 							Lowercase: false,
 						},
-						&memdb.StringFieldIndex{
+						&optionalStringFieldIndex{memdb.StringFieldIndex{
 							Field:     "Project",
 							Lowercase: false,
-						},
+						}},
 
-						&memdb.StringFieldIndex{
+						&optionalStringFieldIndex{memdb.StringFieldIndex{
 							Field:     "Machine",
 							Lowercase: false,
-						},
+						}},
 
 						&IndexTime{
 							Field: "StartTime",
@@ -704,15 +723,15 @@ func (op *genericOperation) memSchema() *memdb.TableSchema {
 							Field:     "Basis",
 							Lowercase: false,
 						},
-						&memdb.StringFieldIndex{
+						&optionalStringFieldIndex{memdb.StringFieldIndex{
 							Field:     "Project",
 							Lowercase: false,
-						},
+						}},
 
-						&memdb.StringFieldIndex{
+						&optionalStringFieldIndex{memdb.StringFieldIndex{
 							Field:     "Machine",
 							Lowercase: false,
-						},
+						}},
 
 						&IndexTime{
 							Field: "CompleteTime",
@@ -731,15 +750,15 @@ func (op *genericOperation) memSchema() *memdb.TableSchema {
 							Field:     "Basis",
 							Lowercase: false,
 						},
-						&memdb.StringFieldIndex{
+						&optionalStringFieldIndex{memdb.StringFieldIndex{
 							Field:     "Project",
 							Lowercase: false,
-						},
+						}},
 
-						&memdb.StringFieldIndex{
+						&optionalStringFieldIndex{memdb.StringFieldIndex{
 							Field:     "Machine",
 							Lowercase: false,
-						},
+						}},
 
 						&memdb.UintFieldIndex{
 							Field: "Sequence",
@@ -751,6 +770,23 @@ func (op *genericOperation) memSchema() *memdb.TableSchema {
 	}
 }
 
+// optionalStringFieldIndex indexes a string field which may be empty.
+// Operations for a basis have no project or machine, and a compound
+// index can't have a missing value before the values that follow it.
+type optionalStringFieldIndex struct {
+	memdb.StringFieldIndex
+}
+
+func (idx *optionalStringFieldIndex) FromObject(obj interface{}) (bool, []byte, error) {
+	ok, val, err := idx.StringFieldIndex.FromObject(obj)
+	if err != nil || ok {
+		return ok, val, err
+	}
+
+	// Matches the value FromArgs gives for an empty string
+	return true, []byte("\x00"), nil
+}
+
 // operationIndexRecord is the record we store in MemDB to perform
 // indexed lookup operations by project, app, time, etc.
 type operationIndexRecord struct {
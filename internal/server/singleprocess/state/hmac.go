@@ -2,10 +2,13 @@ package state
 
 import (
 	"crypto/rand"
+	"fmt"
 	"io"
 	"sync/atomic"
+	"time"
 
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"github.com/hashicorp/go-memdb"
 	bolt "go.etcd.io/bbolt"
 
@@ -73,6 +76,7 @@ func (s *State) hmacKeyCreate(
 	var key vagrant_server.HMACKey
 	key.Id = id
 	key.Key = raw
+	key.CreatedAt = timestamppb.Now()
 
 	// Persist our data
 	if err := dbPut(b, []byte(id), &key); err != nil {
@@ -83,6 +87,107 @@ func (s *State) hmacKeyCreate(
 	return &key, s.hmacKeyIndexSet(memTxn, &key)
 }
 
+// HMACKeyRotate creates a new HMAC key of the given size and sets all keys
+// which are currently valid to expire once the grace period has passed. The
+// new key is returned, it is the key which HMACKeyActive will return from now on.
+func (s *State) HMACKeyRotate(size int, grace time.Duration) (*vagrant_server.HMACKey, error) {
+	memTxn := s.inmem.Txn(true)
+	defer memTxn.Abort()
+
+	validUntil := timestamppb.New(time.Now().Add(grace))
+
+	var result *vagrant_server.HMACKey
+	err := s.db.Update(func(dbTxn *bolt.Tx) error {
+		keys, err := s.hmacKeyList(memTxn)
+		if err != nil {
+			return err
+		}
+
+		// Expire all the keys which are still valid. We leave alone the
+		// keys which already expire, so rotating twice does not extend them.
+		b := dbTxn.Bucket(hmacKeyBucket)
+		for _, key := range keys {
+			if key.ValidUntil != nil {
+				continue
+			}
+
+			key = proto.Clone(key).(*vagrant_server.HMACKey)
+			key.ValidUntil = validUntil
+			if err := dbPut(b, []byte(key.Id), key); err != nil {
+				return err
+			}
+			if err := s.hmacKeyIndexSet(memTxn, key); err != nil {
+				return err
+			}
+		}
+
+		// Key ids are sequential so the key in use is easy to spot.
+		var id string
+		for i := len(keys) + 1; ; i++ {
+			id = fmt.Sprintf("k%d", i)
+			existing, err := s.hmacKeyGet(dbTxn, memTxn, id)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				break
+			}
+		}
+
+		result, err = s.hmacKeyCreate(dbTxn, memTxn, id, size)
+		return err
+	})
+	if err == nil {
+		memTxn.Commit()
+	}
+
+	return result, err
+}
+
+// HMACKeyActive returns the key which new tokens should be signed with. This
+// is the most recently created key which has not been rotated out. This will
+// return a nil value if there is no such key.
+func (s *State) HMACKeyActive() (*vagrant_server.HMACKey, error) {
+	memTxn := s.inmem.Txn(false)
+	defer memTxn.Abort()
+
+	keys, err := s.hmacKeyList(memTxn)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *vagrant_server.HMACKey
+	for _, key := range keys {
+		if key.ValidUntil != nil {
+			continue
+		}
+		if result == nil || result.CreatedAt.AsTime().Before(key.CreatedAt.AsTime()) {
+			result = key
+		}
+	}
+
+	return result, nil
+}
+
+func (s *State) hmacKeyList(memTxn *memdb.Txn) ([]*vagrant_server.HMACKey, error) {
+	iter, err := memTxn.Get(hmacKeyIndexTableName, hmacKeyIndexIdIndexName+"_prefix", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*vagrant_server.HMACKey
+	for {
+		next := iter.Next()
+		if next == nil {
+			break
+		}
+
+		result = append(result, next.(*hmacKeyIndexRecord).Key)
+	}
+
+	return result, nil
+}
+
 // HMACKeyGet gets an HMAC key by ID. This will return a nil value if it
 // doesn't exist.
 func (s *State) HMACKeyGet(id string) (*vagrant_server.HMACKey, error) {
@@ -2,6 +2,7 @@ package state
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -55,4 +56,39 @@ func TestHMAC(t *testing.T) {
 			require.Equal(key2.Key, key.Key)
 		}
 	})
+	t.Run("Rotate", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		k1, err := s.HMACKeyCreateIfNotExist("k1", 32)
+		require.NoError(err)
+
+		active, err := s.HMACKeyActive()
+		require.NoError(err)
+		require.Equal(k1.Id, active.Id)
+
+		k2, err := s.HMACKeyRotate(32, time.Hour)
+		require.NoError(err)
+		require.Equal("k2", k2.Id)
+		require.Nil(k2.ValidUntil)
+
+		active, err = s.HMACKeyActive()
+		require.NoError(err)
+		require.Equal(k2.Id, active.Id)
+
+		// The previous key is kept for the grace period
+		prev, err := s.HMACKeyGet("k1")
+		require.NoError(err)
+		require.NotNil(prev.ValidUntil)
+		require.True(prev.ValidUntil.AsTime().After(time.Now()))
+
+		// Rotating again does not extend the previous keys
+		_, err = s.HMACKeyRotate(32, 2*time.Hour)
+		require.NoError(err)
+		again, err := s.HMACKeyGet("k1")
+		require.NoError(err)
+		require.Equal(prev.ValidUntil.AsTime(), again.ValidUntil.AsTime())
+	})
 }
@@ -211,6 +211,30 @@ func (s *State) JobList() ([]*vagrant_server.Job, error) {
 	return result, nil
 }
 
+// JobCountByState returns the number of jobs tracked in memory in each
+// state. States without any jobs are not included.
+func (s *State) JobCountByState() (map[vagrant_server.Job_State]int, error) {
+	memTxn := s.inmem.Txn(false)
+	defer memTxn.Abort()
+
+	iter, err := memTxn.Get(jobTableName, jobIdIndexName+"_prefix", "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[vagrant_server.Job_State]int{}
+	for {
+		next := iter.Next()
+		if next == nil {
+			break
+		}
+
+		result[next.(*jobIndex).State]++
+	}
+
+	return result, nil
+}
+
 // JobById looks up a job by ID. The returned Job will be a deep copy
 // of the job so it is safe to read/write. If the job can't be found,
 // a nil result with no error is returned.
@@ -1019,13 +1043,15 @@ func (s *State) jobsPruneOld(memTxn *memdb.Txn, max int) (int, error) {
 	})
 }
 
-func (s *State) JobsDBPruneOld(max int) (int, error) {
+// JobsDBPruneOld prunes the oldest jobs from the database until at most
+// max remain, along with any uploaded blobs which are no longer used by a
+// job. The number of jobs and blobs pruned is returned.
+func (s *State) JobsDBPruneOld(max int) (jobs int, blobs int, err error) {
 	cnt := dbCount(s.db, jobTableName)
 	toDelete := cnt - max
-	var deleted int
 
 	// Prune jobs from boltDB
-	s.db.Update(func(tx *bolt.Tx) error {
+	err = s.db.Update(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket([]byte(jobTableName))
 		cur := bucket.Cursor()
 		key, _ := cur.First()
@@ -1042,15 +1068,27 @@ func (s *State) JobsDBPruneOld(max int) (int, error) {
 				return err
 			}
 
-			deleted++
+			jobs++
 			if toDelete <= 0 {
 				break
 			}
 			key, _ = cur.Next()
 		}
+
+		// Remove any uploaded blobs that were only used by pruned jobs
+		blobs, err = s.blobsPruneUnreferenced(tx)
+		if err != nil {
+			return err
+		}
+		s.log.Debug("pruned unreferenced blobs", "removed-blobs", blobs)
+
 		return nil
 	})
-	return deleted, nil
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return jobs, blobs, nil
 }
 
 // Job returns the Job for an index.
@@ -560,6 +560,13 @@ func TestJobComplete(t *testing.T) {
 		require.Nil(job.Error)
 		require.NotNil(job.Result)
 		require.NotNil(job.Result.Run)
+
+		// It should be counted as successful
+		counts, err := s.JobCountByState()
+		require.NoError(err)
+		require.Equal(map[vagrant_server.Job_State]int{
+			vagrant_server.Job_SUCCESS: 1,
+		}, counts)
 	})
 
 	t.Run("error", func(t *testing.T) {
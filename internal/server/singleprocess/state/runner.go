@@ -81,6 +81,24 @@ func (s *State) RunnerById(id string) (*vagrant_server.Runner, error) {
 	return raw.(*runnerRecord).Runner, nil
 }
 
+// RunnerCount returns the number of registered runners.
+func (s *State) RunnerCount() (int, error) {
+	txn := s.inmem.Txn(false)
+	defer txn.Abort()
+
+	iter, err := txn.Get(runnerTableName, runnerIdIndexName+"_prefix", "")
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for iter.Next() != nil {
+		count++
+	}
+
+	return count, nil
+}
+
 // runnerEmpty returns true if there are no runners registered.
 func (s *State) runnerEmpty(memTxn *memdb.Txn) (bool, error) {
 	iter, err := memTxn.LowerBound(runnerTableName, runnerIdIndexName, "")
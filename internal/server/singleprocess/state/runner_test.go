@@ -19,6 +19,11 @@ func TestRunner_crud(t *testing.T) {
 	rec := &vagrant_server.Runner{Id: "A"}
 	require.NoError(s.RunnerCreate(rec))
 
+	// It should be counted
+	count, err := s.RunnerCount()
+	require.NoError(err)
+	require.Equal(1, count)
+
 	// We should be able to find it
 	found, err := s.RunnerById(rec.Id)
 	require.NoError(err)
@@ -33,6 +38,10 @@ func TestRunner_crud(t *testing.T) {
 	require.Nil(found)
 	require.Equal(codes.NotFound, status.Code(err))
 
+	count, err = s.RunnerCount()
+	require.NoError(err)
+	require.Equal(0, count)
+
 	// Delete again should be fine
 	require.NoError(s.RunnerDelete(rec.Id))
 }
@@ -4,9 +4,11 @@ package state
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
@@ -52,6 +54,10 @@ type State struct {
 	// bootstrap token.
 	hmacKeyNotEmpty uint32
 
+	// indexed is flipped to 1 once the in-memory indexes have been
+	// built from the persisted data.
+	indexed uint32
+
 	// indexers is used to track whether an indexer was called. This is
 	// initialized during New and set to nil at the end of New.
 	indexers map[uintptr]struct{}
@@ -115,6 +121,7 @@ func New(log hclog.Logger, db *bolt.DB) (*State, error) {
 		return nil, err
 	}
 	memTxn.Commit()
+	atomic.StoreUint32(&s.indexed, 1)
 
 	return s, nil
 }
@@ -137,23 +144,53 @@ func (s *State) Close() error {
 	return s.db.Close()
 }
 
+// Check verifies that the state store is usable. The in-memory indexes
+// must be built and the persisted database must be readable.
+func (s *State) Check() error {
+	if atomic.LoadUint32(&s.indexed) == 0 {
+		return errors.New("state indexes are not built")
+	}
+
+	return s.db.View(func(dbTxn *bolt.Tx) error {
+		for _, b := range dbBuckets {
+			if dbTxn.Bucket(b) == nil {
+				return fmt.Errorf("database bucket %q is missing", b)
+			}
+		}
+
+		return nil
+	})
+}
+
+// DBSize returns the size of the persisted database in bytes.
+func (s *State) DBSize() (int64, error) {
+	var size int64
+	err := s.db.View(func(dbTxn *bolt.Tx) error {
+		size = dbTxn.Size()
+		return nil
+	})
+
+	return size, err
+}
+
 // Prune should be called in a on a regular interval to allow State
-// to prune out old data.
-func (s *State) Prune() error {
+// to prune out old data. The number of jobs pruned is returned.
+func (s *State) Prune() (jobs int, err error) {
 	memTxn := s.inmem.Txn(true)
 	defer memTxn.Abort()
 
 	// Prune jobs from memdb
-	jobs, err := s.jobsPruneOld(memTxn, maximumJobsInMem)
+	jobs, err = s.jobsPruneOld(memTxn, maximumJobsInMem)
 	if err != nil {
-		return err
+		return 0, err
 	}
+
 	s.log.Debug("Finished pruning data",
 		"removed-jobs", jobs,
 	)
 	memTxn.Commit()
 
-	return nil
+	return jobs, nil
 }
 
 // schemaFn is an interface function used to create and return new memdb schema
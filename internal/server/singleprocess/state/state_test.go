@@ -2,10 +2,24 @@ package state
 
 import (
 	"math/rand"
+	"testing"
 	"time"
+
+	"github.com/stretchr/testify/require"
 )
 
 func init() {
 	// Seed our test randomness
 	rand.Seed(time.Now().UnixNano())
 }
+
+func TestStateCheck(t *testing.T) {
+	require := require.New(t)
+
+	s := TestState(t)
+	require.NoError(s.Check())
+
+	// A closed database can't be read
+	require.NoError(s.Close())
+	require.Error(s.Check())
+}
@@ -7,6 +7,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
 	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
@@ -223,6 +224,7 @@ func (s *State) targetPut(
 		}
 
 	}
+	value.UpdatedAt = timestamppb.Now()
 
 	s.log.Trace("storing target to db", "target", value)
 	id := s.targetId(value)
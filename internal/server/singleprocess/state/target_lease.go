@@ -0,0 +1,253 @@
+package state
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// Leases are only stored in memory. A lease must be renewed by a live
+// holder to be kept, so there is nothing worth keeping across restarts
+// of the server.
+const (
+	targetLeaseTableName       = "target-leases"
+	targetLeaseIdIndexName     = "id"
+	targetLeaseTargetIndexName = "target"
+)
+
+func init() {
+	schemas = append(schemas, targetLeaseSchema)
+}
+
+func targetLeaseSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: targetLeaseTableName,
+		Indexes: map[string]*memdb.IndexSchema{
+			targetLeaseIdIndexName: {
+				Name:         targetLeaseIdIndexName,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Id",
+				},
+			},
+			targetLeaseTargetIndexName: {
+				Name:         targetLeaseTargetIndexName,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "TargetId",
+				},
+			},
+		},
+	}
+}
+
+type targetLeaseRecord struct {
+	// The full lease. All other fields are derivatives of this.
+	Lease *vagrant_server.TargetLease
+
+	// Id of the lease
+	Id string
+
+	// Resource ID of the target the lease is held on
+	TargetId string
+
+	// Number of times the lease has been acquired by its job and not
+	// released yet. The lease is only removed once this reaches zero.
+	Count int
+}
+
+// TargetLeaseAcquire takes a lease on the target of the given lease which
+// expires after ttl. The lease is assigned a new ID. If the target already
+// has an unexpired lease held by a different job, a FailedPrecondition
+// error naming that job is returned.
+//
+// If the target already has an unexpired lease held by the same job, such
+// as when an operation runs another operation on the same target, that
+// lease is returned and extended instead. It must then be released as many
+// times as it was acquired before the target is unlocked.
+func (s *State) TargetLeaseAcquire(
+	lease *vagrant_server.TargetLease,
+	ttl time.Duration,
+) (*vagrant_server.TargetLease, error) {
+	if lease.Target.GetResourceId() == "" {
+		return nil, status.Error(codes.InvalidArgument,
+			"target resource id is required to acquire a lease")
+	}
+
+	id, err := s.newResourceId()
+	if err != nil {
+		return nil, err
+	}
+
+	txn := s.inmem.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First(targetLeaseTableName, targetLeaseTargetIndexName,
+		lease.Target.ResourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if raw != nil {
+		record := raw.(*targetLeaseRecord)
+		current := record.Lease
+		if current.ExpireTime.AsTime().After(now) {
+			if current.JobId == "" || current.JobId != lease.JobId {
+				return nil, targetLeaseHeldError(lease.Target.Name, current)
+			}
+
+			// The lease is held by the same job, so it is shared. The
+			// expiration is never moved closer since the outer holder
+			// may have asked for a longer TTL.
+			result := proto.Clone(current).(*vagrant_server.TargetLease)
+			if expire := now.Add(ttl); expire.After(current.ExpireTime.AsTime()) {
+				result.ExpireTime = timestamppb.New(expire)
+			}
+
+			updated := newTargetLeaseRecord(result)
+			updated.Count = record.Count + 1
+			if err := txn.Insert(targetLeaseTableName, updated); err != nil {
+				return nil, err
+			}
+			txn.Commit()
+
+			return result, nil
+		}
+
+		// The lease has expired, so it is replaced
+		if err := txn.Delete(targetLeaseTableName, raw); err != nil {
+			return nil, err
+		}
+	}
+
+	result := proto.Clone(lease).(*vagrant_server.TargetLease)
+	result.Id = id
+	result.ExpireTime = timestamppb.New(now.Add(ttl))
+
+	if err := txn.Insert(targetLeaseTableName, newTargetLeaseRecord(result)); err != nil {
+		return nil, err
+	}
+	txn.Commit()
+
+	return result, nil
+}
+
+// TargetLeaseRenew extends the lease with the given ID to expire ttl from
+// now. A NotFound error is returned if the lease does not exist or has
+// already expired.
+func (s *State) TargetLeaseRenew(
+	id string,
+	ttl time.Duration,
+) (*vagrant_server.TargetLease, error) {
+	txn := s.inmem.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First(targetLeaseTableName, targetLeaseIdIndexName, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if raw == nil || !raw.(*targetLeaseRecord).Lease.ExpireTime.AsTime().After(now) {
+		return nil, status.Errorf(codes.NotFound,
+			"lease %q does not exist or has expired", id)
+	}
+
+	// Records must not be modified in place, so the lease is copied
+	record := raw.(*targetLeaseRecord)
+	result := proto.Clone(record.Lease).(*vagrant_server.TargetLease)
+	result.ExpireTime = timestamppb.New(now.Add(ttl))
+
+	updated := newTargetLeaseRecord(result)
+	updated.Count = record.Count
+	if err := txn.Insert(targetLeaseTableName, updated); err != nil {
+		return nil, err
+	}
+	txn.Commit()
+
+	return result, nil
+}
+
+// TargetLeaseRelease releases the lease with the given ID. A lease which
+// was acquired more than once by its job is kept until it has been
+// released as many times. It is not an error if the lease does not exist.
+func (s *State) TargetLeaseRelease(id string) error {
+	txn := s.inmem.Txn(true)
+	defer txn.Abort()
+
+	raw, err := txn.First(targetLeaseTableName, targetLeaseIdIndexName, id)
+	if err != nil || raw == nil {
+		return err
+	}
+
+	record := raw.(*targetLeaseRecord)
+	if record.Count > 1 {
+		updated := newTargetLeaseRecord(record.Lease)
+		updated.Count = record.Count - 1
+		err = txn.Insert(targetLeaseTableName, updated)
+	} else {
+		err = txn.Delete(targetLeaseTableName, raw)
+	}
+	if err != nil {
+		return err
+	}
+	txn.Commit()
+
+	return nil
+}
+
+// TargetLeaseGet returns the unexpired lease on the target with the given
+// resource ID, or a NotFound error if there is none.
+func (s *State) TargetLeaseGet(targetId string) (*vagrant_server.TargetLease, error) {
+	txn := s.inmem.Txn(false)
+	defer txn.Abort()
+
+	raw, err := txn.First(targetLeaseTableName, targetLeaseTargetIndexName, targetId)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil || !raw.(*targetLeaseRecord).Lease.ExpireTime.AsTime().After(time.Now()) {
+		return nil, status.Errorf(codes.NotFound, "target is not leased")
+	}
+
+	return raw.(*targetLeaseRecord).Lease, nil
+}
+
+// targetLeaseHeldError returns the error for a target which can't be
+// leased because of the current lease.
+func targetLeaseHeldError(name string, current *vagrant_server.TargetLease) error {
+	holder := current.Holder
+	if holder == "" {
+		holder = "an unknown process"
+	}
+	if current.JobId != "" {
+		return status.Errorf(codes.FailedPrecondition,
+			"target %q is locked by job %s (%s) until %s",
+			name, current.JobId, holder,
+			current.ExpireTime.AsTime().Local().Format(time.RFC3339))
+	}
+
+	return status.Errorf(codes.FailedPrecondition,
+		"target %q is locked by %s until %s",
+		name, holder, current.ExpireTime.AsTime().Local().Format(time.RFC3339))
+}
+
+// newTargetLeaseRecord creates a targetLeaseRecord from a lease which has
+// been acquired once.
+func newTargetLeaseRecord(lease *vagrant_server.TargetLease) *targetLeaseRecord {
+	return &targetLeaseRecord{
+		Lease:    lease,
+		Id:       lease.Id,
+		TargetId: lease.Target.ResourceId,
+		Count:    1,
+	}
+}
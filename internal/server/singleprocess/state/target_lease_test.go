@@ -0,0 +1,192 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func TestTargetLease(t *testing.T) {
+	target := &vagrant_plugin_sdk.Ref_Target{
+		ResourceId: "AbCdE",
+		Name:       "default",
+	}
+
+	t.Run("Acquire and Release", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		lease, err := s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			JobId:  "job-a",
+		}, time.Minute)
+		require.NoError(err)
+		require.NotEmpty(lease.Id)
+		require.True(lease.ExpireTime.AsTime().After(time.Now()))
+
+		current, err := s.TargetLeaseGet(target.ResourceId)
+		require.NoError(err)
+		require.Equal(lease.Id, current.Id)
+
+		// Another job can't acquire the lease
+		_, err = s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			JobId:  "job-b",
+		}, time.Minute)
+		require.Error(err)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+		require.Contains(err.Error(), "job-a")
+
+		require.NoError(s.TargetLeaseRelease(lease.Id))
+		_, err = s.TargetLeaseGet(target.ResourceId)
+		require.Equal(codes.NotFound, status.Code(err))
+
+		// Once released, another job can acquire it
+		_, err = s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			JobId:  "job-b",
+		}, time.Minute)
+		require.NoError(err)
+
+		// Releasing again is fine
+		require.NoError(s.TargetLeaseRelease(lease.Id))
+	})
+
+	t.Run("Acquire by the same job", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		first, err := s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			JobId:  "job-a",
+		}, time.Minute)
+		require.NoError(err)
+
+		second, err := s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			JobId:  "job-a",
+		}, time.Minute)
+		require.NoError(err)
+		require.Equal(first.Id, second.Id)
+
+		// Leases held outside of a job are never shared
+		require.NoError(s.TargetLeaseRelease(second.Id))
+		require.NoError(s.TargetLeaseRelease(first.Id))
+		_, err = s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			Holder: "host:1",
+		}, time.Minute)
+		require.NoError(err)
+		_, err = s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			Holder: "host:2",
+		}, time.Minute)
+		require.Error(err)
+		require.Contains(err.Error(), "host:1")
+	})
+
+	t.Run("Nested acquire and release", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		outer, err := s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			JobId:  "job-a",
+		}, time.Minute)
+		require.NoError(err)
+
+		// The inner acquire shares and extends the outer lease
+		inner, err := s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			JobId:  "job-a",
+		}, time.Hour)
+		require.NoError(err)
+		require.Equal(outer.Id, inner.Id)
+		require.True(inner.ExpireTime.AsTime().After(outer.ExpireTime.AsTime()))
+
+		// Releasing the inner lease keeps the target locked, and the
+		// outer holder can still renew it
+		require.NoError(s.TargetLeaseRelease(inner.Id))
+		_, err = s.TargetLeaseRenew(outer.Id, time.Minute)
+		require.NoError(err)
+		_, err = s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			JobId:  "job-b",
+		}, time.Minute)
+		require.Equal(codes.FailedPrecondition, status.Code(err))
+
+		// Releasing the outer lease unlocks the target
+		require.NoError(s.TargetLeaseRelease(outer.Id))
+		_, err = s.TargetLeaseGet(target.ResourceId)
+		require.Equal(codes.NotFound, status.Code(err))
+		_, err = s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			JobId:  "job-b",
+		}, time.Minute)
+		require.NoError(err)
+	})
+
+	t.Run("Expire", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		lease, err := s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			JobId:  "job-a",
+		}, 10*time.Millisecond)
+		require.NoError(err)
+
+		time.Sleep(20 * time.Millisecond)
+
+		// An expired lease can't be renewed, and doesn't stop
+		// another job from acquiring the target
+		_, err = s.TargetLeaseRenew(lease.Id, time.Minute)
+		require.Equal(codes.NotFound, status.Code(err))
+		_, err = s.TargetLeaseGet(target.ResourceId)
+		require.Equal(codes.NotFound, status.Code(err))
+
+		_, err = s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			JobId:  "job-b",
+		}, time.Minute)
+		require.NoError(err)
+	})
+
+	t.Run("Renew", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		lease, err := s.TargetLeaseAcquire(&vagrant_server.TargetLease{
+			Target: target,
+			JobId:  "job-a",
+		}, 50*time.Millisecond)
+		require.NoError(err)
+
+		renewed, err := s.TargetLeaseRenew(lease.Id, time.Minute)
+		require.NoError(err)
+		require.Equal(lease.Id, renewed.Id)
+		require.True(renewed.ExpireTime.AsTime().After(lease.ExpireTime.AsTime()))
+
+		time.Sleep(60 * time.Millisecond)
+
+		current, err := s.TargetLeaseGet(target.ResourceId)
+		require.NoError(err)
+		require.Equal(lease.Id, current.Id)
+	})
+}
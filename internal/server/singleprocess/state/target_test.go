@@ -2,6 +2,7 @@ package state
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
@@ -143,6 +144,37 @@ func TestTarget(t *testing.T) {
 		}
 	})
 
+	t.Run("Put sets updated time", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+		projectRef := testProject(t, s)
+
+		ref := &vagrant_plugin_sdk.Ref_Target{
+			ResourceId: "AbCdE",
+			Project:    projectRef,
+		}
+		before := time.Now()
+		require.NoError(s.TargetPut(serverptypes.TestTarget(t, &vagrant_server.Target{
+			ResourceId: ref.ResourceId,
+			Project:    projectRef,
+			Name:       "test",
+		})))
+
+		resp, err := s.TargetGet(ref)
+		require.NoError(err)
+		require.NotNil(resp.UpdatedAt)
+		first := resp.UpdatedAt.AsTime()
+		require.False(first.Before(before.Truncate(time.Microsecond)))
+
+		// Saving the target again moves the updated time forward
+		require.NoError(s.TargetPut(resp))
+		resp, err = s.TargetGet(ref)
+		require.NoError(err)
+		require.False(resp.UpdatedAt.AsTime().Before(first))
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		require := require.New(t)
 
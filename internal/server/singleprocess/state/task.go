@@ -15,16 +15,9 @@ func init() {
 
 // TaskPut inserts or updates a task record.
 func (s *State) TaskPut(update bool, t *vagrant_server.Task) error {
-	// TODO(phinze): This Put operation will always fail because the Task
-	// struct has neither a Basis, a Project, nor a Target set. This is ok for
-	// now because nobody is using Task operations directly - Tasks seem to
-	// enter state only from being nested in Jobs. At some point we'll need to
-	// swing around and decide if we want to fix this wiring or if it's
-	// unnecessary and okay to delete.
-	//
-	// If we do decide to fix it... the thing to sort out here will be how to
-	// transform the basis, project, or target referenced by t.Scope into the
-	// form expected by taskOp.Put().
+	// The task is indexed by the basis, project, or target set as its
+	// scope. References must be complete, including the resource ID of
+	// the basis of any project.
 	return taskOp.Put(s, update, t)
 }
 
@@ -0,0 +1,121 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/proto/vagrant_plugin_sdk"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	serverptypes "github.com/hashicorp/vagrant/internal/server/ptypes"
+)
+
+func TestTask(t *testing.T) {
+	t.Run("Put and Get", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+		projectRef := testProject(t, s)
+
+		require.NoError(s.TaskPut(false, &vagrant_server.Task{
+			Id:          "a",
+			Scope:       &vagrant_server.Task_Project{Project: projectRef},
+			Task:        "up",
+			CommandName: "up",
+			Status: &vagrant_server.Status{
+				State:     vagrant_server.Status_RUNNING,
+				StartTime: timestamppb.Now(),
+			},
+		}))
+
+		task, err := s.TaskGet(&vagrant_server.Ref_Operation{
+			Target: &vagrant_server.Ref_Operation_Id{Id: "a"},
+		})
+		require.NoError(err)
+		require.Equal("up", task.CommandName)
+		require.Equal(uint64(1), task.Sequence)
+		require.Equal(projectRef.ResourceId, task.GetProject().ResourceId)
+
+		// Update keeps the sequence number
+		task.Status.State = vagrant_server.Status_SUCCESS
+		task.Status.CompleteTime = timestamppb.Now()
+		task.Sequence = 10
+		require.NoError(s.TaskPut(true, task))
+
+		task, err = s.TaskGet(&vagrant_server.Ref_Operation{
+			Target: &vagrant_server.Ref_Operation_Id{Id: "a"},
+		})
+		require.NoError(err)
+		require.Equal(vagrant_server.Status_SUCCESS, task.Status.State)
+		require.Equal(uint64(1), task.Sequence)
+	})
+
+	t.Run("List and Latest by target", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+		projectRef := testProject(t, s)
+
+		require.NoError(s.TargetPut(serverptypes.TestTarget(t, &vagrant_server.Target{
+			ResourceId: "test-target",
+			Project:    projectRef,
+			Name:       "default",
+		})))
+		targetRef := &vagrant_plugin_sdk.Ref_Target{
+			ResourceId: "test-target",
+			Project:    projectRef,
+			Name:       "default",
+		}
+
+		start := time.Now().Add(-time.Hour)
+		for i, state := range []vagrant_server.Status_State{
+			vagrant_server.Status_SUCCESS,
+			vagrant_server.Status_ERROR,
+		} {
+			require.NoError(s.TaskPut(false, &vagrant_server.Task{
+				Id:          string(rune('a' + i)),
+				Scope:       &vagrant_server.Task_Target{Target: targetRef},
+				CommandName: "up",
+				Status: &vagrant_server.Status{
+					State:        state,
+					StartTime:    timestamppb.New(start.Add(time.Duration(i) * time.Minute)),
+					CompleteTime: timestamppb.New(start.Add(time.Duration(i)*time.Minute + time.Second)),
+				},
+			}))
+		}
+
+		// A task for the project is not listed for the target
+		require.NoError(s.TaskPut(false, &vagrant_server.Task{
+			Id:    "c",
+			Scope: &vagrant_server.Task_Project{Project: projectRef},
+			Status: &vagrant_server.Status{
+				State:     vagrant_server.Status_SUCCESS,
+				StartTime: timestamppb.Now(),
+			},
+		}))
+
+		tasks, err := s.TaskList(targetRef)
+		require.NoError(err)
+		require.Len(tasks, 2)
+		// Most recently started first
+		require.Equal("b", tasks[0].Id)
+		require.Equal("a", tasks[1].Id)
+
+		latest, err := s.TaskLatest(targetRef)
+		require.NoError(err)
+		require.Equal("a", latest.Id)
+	})
+
+	t.Run("Put requires a scope", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.Error(s.TaskPut(false, &vagrant_server.Task{Id: "a"}))
+	})
+}
@@ -0,0 +1,200 @@
+package state
+
+import (
+	"github.com/hashicorp/go-memdb"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+var tokenBucket = []byte("tokens")
+
+func init() {
+	dbBuckets = append(dbBuckets, tokenBucket)
+	dbIndexers = append(dbIndexers, (*State).tokenIndexInit)
+	schemas = append(schemas, tokenIndexSchema)
+}
+
+// TokenPut records information about an issued token.
+func (s *State) TokenPut(info *vagrant_server.TokenInfo) error {
+	memTxn := s.inmem.Txn(true)
+	defer memTxn.Abort()
+
+	err := s.db.Update(func(dbTxn *bolt.Tx) error {
+		return s.tokenPut(dbTxn, memTxn, info)
+	})
+	if err == nil {
+		memTxn.Commit()
+	}
+
+	return err
+}
+
+// TokenGet gets the information about a token by ID.
+func (s *State) TokenGet(id string) (*vagrant_server.TokenInfo, error) {
+	memTxn := s.inmem.Txn(false)
+	defer memTxn.Abort()
+
+	result, err := s.tokenGet(memTxn, id)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, status.Errorf(codes.NotFound, "token ID not found")
+	}
+
+	return result, nil
+}
+
+// TokenList returns the information about all recorded tokens.
+func (s *State) TokenList() ([]*vagrant_server.TokenInfo, error) {
+	memTxn := s.inmem.Txn(false)
+	defer memTxn.Abort()
+
+	iter, err := memTxn.Get(tokenIndexTableName, tokenIndexIdIndexName+"_prefix", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*vagrant_server.TokenInfo
+	for {
+		next := iter.Next()
+		if next == nil {
+			break
+		}
+
+		result = append(result, next.(*tokenIndexRecord).Token)
+	}
+
+	return result, nil
+}
+
+// TokenRevoke marks the token with the given ID as revoked. If the token
+// has not been recorded, info is recorded in its place so that tokens not
+// issued through this server can be revoked too. Revoking a token that is
+// already revoked is a no-op.
+func (s *State) TokenRevoke(id string, info *vagrant_server.TokenInfo) error {
+	memTxn := s.inmem.Txn(true)
+	defer memTxn.Abort()
+
+	err := s.db.Update(func(dbTxn *bolt.Tx) error {
+		existing, err := s.tokenGet(memTxn, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			if info == nil {
+				return status.Errorf(codes.NotFound, "token ID not found")
+			}
+
+			existing = info
+		}
+		if existing.RevokedAt != nil {
+			return nil
+		}
+
+		existing = proto.Clone(existing).(*vagrant_server.TokenInfo)
+		existing.Id = id
+		existing.RevokedAt = timestamppb.Now()
+		return s.tokenPut(dbTxn, memTxn, existing)
+	})
+	if err == nil {
+		memTxn.Commit()
+	}
+
+	return err
+}
+
+// TokenRevoked returns true if the token with the given ID has been revoked.
+func (s *State) TokenRevoked(id string) (bool, error) {
+	memTxn := s.inmem.Txn(false)
+	defer memTxn.Abort()
+
+	info, err := s.tokenGet(memTxn, id)
+	if err != nil {
+		return false, err
+	}
+
+	return info != nil && info.RevokedAt != nil, nil
+}
+
+func (s *State) tokenPut(
+	dbTxn *bolt.Tx,
+	memTxn *memdb.Txn,
+	value *vagrant_server.TokenInfo,
+) error {
+	b := dbTxn.Bucket(tokenBucket)
+	if err := dbPut(b, []byte(value.Id), value); err != nil {
+		return err
+	}
+
+	return s.tokenIndexSet(memTxn, value)
+}
+
+func (s *State) tokenGet(
+	memTxn *memdb.Txn,
+	id string,
+) (*vagrant_server.TokenInfo, error) {
+	raw, err := memTxn.First(tokenIndexTableName, tokenIndexIdIndexName, id)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	return raw.(*tokenIndexRecord).Token, nil
+}
+
+// tokenIndexSet writes an index record for a single token.
+func (s *State) tokenIndexSet(txn *memdb.Txn, value *vagrant_server.TokenInfo) error {
+	record := &tokenIndexRecord{
+		Id:    value.Id,
+		Token: value,
+	}
+
+	return txn.Insert(tokenIndexTableName, record)
+}
+
+// tokenIndexInit initializes the token index from persisted data.
+func (s *State) tokenIndexInit(dbTxn *bolt.Tx, memTxn *memdb.Txn) error {
+	bucket := dbTxn.Bucket(tokenBucket)
+	return bucket.ForEach(func(k, v []byte) error {
+		var value vagrant_server.TokenInfo
+		if err := proto.Unmarshal(v, &value); err != nil {
+			return err
+		}
+
+		return s.tokenIndexSet(memTxn, &value)
+	})
+}
+
+func tokenIndexSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: tokenIndexTableName,
+		Indexes: map[string]*memdb.IndexSchema{
+			tokenIndexIdIndexName: {
+				Name:         tokenIndexIdIndexName,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Id",
+				},
+			},
+		},
+	}
+}
+
+const (
+	tokenIndexTableName   = "token-index"
+	tokenIndexIdIndexName = "id"
+)
+
+type tokenIndexRecord struct {
+	Id    string
+	Token *vagrant_server.TokenInfo
+}
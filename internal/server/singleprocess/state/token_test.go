@@ -0,0 +1,80 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func TestToken(t *testing.T) {
+	t.Run("Put, Get and List", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		_, err := s.TokenGet("foo")
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+
+		require.NoError(s.TokenPut(&vagrant_server.TokenInfo{Id: "foo", Login: true}))
+		require.NoError(s.TokenPut(&vagrant_server.TokenInfo{Id: "bar", Invite: true}))
+
+		info, err := s.TokenGet("foo")
+		require.NoError(err)
+		require.True(info.Login)
+
+		list, err := s.TokenList()
+		require.NoError(err)
+		require.Len(list, 2)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.TokenPut(&vagrant_server.TokenInfo{Id: "foo", Login: true}))
+
+		revoked, err := s.TokenRevoked("foo")
+		require.NoError(err)
+		require.False(revoked)
+
+		require.NoError(s.TokenRevoke("foo", nil))
+		revoked, err = s.TokenRevoked("foo")
+		require.NoError(err)
+		require.True(revoked)
+
+		// Unknown tokens need their info to be revoked
+		err = s.TokenRevoke("bar", nil)
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+
+		require.NoError(s.TokenRevoke("bar", &vagrant_server.TokenInfo{Login: true}))
+		revoked, err = s.TokenRevoked("bar")
+		require.NoError(err)
+		require.True(revoked)
+	})
+
+	t.Run("Revocations survive a reload", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.TokenPut(&vagrant_server.TokenInfo{Id: "foo", Login: true}))
+		require.NoError(s.TokenRevoke("foo", nil))
+
+		s = TestStateReinit(t, s)
+		defer s.Close()
+
+		revoked, err := s.TokenRevoked("foo")
+		require.NoError(err)
+		require.True(revoked)
+	})
+}
@@ -0,0 +1,184 @@
+package state
+
+import (
+	"github.com/hashicorp/go-memdb"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+var userBucket = []byte("users")
+
+func init() {
+	dbBuckets = append(dbBuckets, userBucket)
+	dbIndexers = append(dbIndexers, (*State).userIndexInit)
+	schemas = append(schemas, userIndexSchema)
+}
+
+// UserCreateIfNotExist creates a new user with the given username. If the
+// user exists already it will be returned.
+func (s *State) UserCreateIfNotExist(username string) (*vagrant_server.User, error) {
+	memTxn := s.inmem.Txn(true)
+	defer memTxn.Abort()
+
+	var result *vagrant_server.User
+	err := s.db.Update(func(dbTxn *bolt.Tx) error {
+		var err error
+		result, err = s.userGet(memTxn, username)
+		if err != nil || result != nil {
+			return err
+		}
+
+		result = &vagrant_server.User{
+			Username:  username,
+			CreatedAt: timestamppb.Now(),
+		}
+
+		b := dbTxn.Bucket(userBucket)
+		if err := dbPut(b, []byte(username), result); err != nil {
+			return err
+		}
+
+		return s.userIndexSet(memTxn, result)
+	})
+	if err == nil {
+		memTxn.Commit()
+	}
+
+	return result, err
+}
+
+// UserGet gets a user by username.
+func (s *State) UserGet(username string) (*vagrant_server.User, error) {
+	memTxn := s.inmem.Txn(false)
+	defer memTxn.Abort()
+
+	result, err := s.userGet(memTxn, username)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found: %s", username)
+	}
+
+	return result, nil
+}
+
+// UserList returns all users.
+func (s *State) UserList() ([]*vagrant_server.User, error) {
+	memTxn := s.inmem.Txn(false)
+	defer memTxn.Abort()
+
+	iter, err := memTxn.Get(userIndexTableName, userIndexUsernameIndexName+"_prefix", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*vagrant_server.User
+	for {
+		next := iter.Next()
+		if next == nil {
+			break
+		}
+
+		result = append(result, next.(*userIndexRecord).User)
+	}
+
+	return result, nil
+}
+
+// UserDelete deletes a user by username.
+func (s *State) UserDelete(username string) error {
+	memTxn := s.inmem.Txn(true)
+	defer memTxn.Abort()
+
+	err := s.db.Update(func(dbTxn *bolt.Tx) error {
+		existing, err := s.userGet(memTxn, username)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return status.Errorf(codes.NotFound, "user not found: %s", username)
+		}
+
+		if err := dbTxn.Bucket(userBucket).Delete([]byte(existing.Username)); err != nil {
+			return err
+		}
+
+		_, err = memTxn.DeleteAll(userIndexTableName, userIndexUsernameIndexName, username)
+		return err
+	})
+	if err == nil {
+		memTxn.Commit()
+	}
+
+	return err
+}
+
+func (s *State) userGet(
+	memTxn *memdb.Txn,
+	username string,
+) (*vagrant_server.User, error) {
+	raw, err := memTxn.First(userIndexTableName, userIndexUsernameIndexName, username)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	return raw.(*userIndexRecord).User, nil
+}
+
+// userIndexSet writes an index record for a single user.
+func (s *State) userIndexSet(txn *memdb.Txn, value *vagrant_server.User) error {
+	record := &userIndexRecord{
+		Username: value.Username,
+		User:     value,
+	}
+
+	return txn.Insert(userIndexTableName, record)
+}
+
+// userIndexInit initializes the user index from persisted data.
+func (s *State) userIndexInit(dbTxn *bolt.Tx, memTxn *memdb.Txn) error {
+	bucket := dbTxn.Bucket(userBucket)
+	return bucket.ForEach(func(k, v []byte) error {
+		var value vagrant_server.User
+		if err := proto.Unmarshal(v, &value); err != nil {
+			return err
+		}
+
+		return s.userIndexSet(memTxn, &value)
+	})
+}
+
+func userIndexSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: userIndexTableName,
+		Indexes: map[string]*memdb.IndexSchema{
+			userIndexUsernameIndexName: {
+				Name:         userIndexUsernameIndexName,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Username",
+				},
+			},
+		},
+	}
+}
+
+const (
+	userIndexTableName         = "user-index"
+	userIndexUsernameIndexName = "id"
+)
+
+type userIndexRecord struct {
+	Username string
+	User     *vagrant_server.User
+}
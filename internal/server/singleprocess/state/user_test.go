@@ -0,0 +1,63 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUser(t *testing.T) {
+	t.Run("Create, Get, List and Delete", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		_, err := s.UserGet("alice")
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+
+		user, err := s.UserCreateIfNotExist("alice")
+		require.NoError(err)
+		require.Equal("alice", user.Username)
+		require.NotNil(user.CreatedAt)
+
+		// Creating again returns the existing user
+		again, err := s.UserCreateIfNotExist("alice")
+		require.NoError(err)
+		require.Equal(user.CreatedAt.AsTime(), again.CreatedAt.AsTime())
+
+		_, err = s.UserCreateIfNotExist("bob")
+		require.NoError(err)
+
+		list, err := s.UserList()
+		require.NoError(err)
+		require.Len(list, 2)
+
+		require.NoError(s.UserDelete("alice"))
+		_, err = s.UserGet("alice")
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+
+		err = s.UserDelete("alice")
+		require.Equal(codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Users survive a reload", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		_, err := s.UserCreateIfNotExist("alice")
+		require.NoError(err)
+
+		s = TestStateReinit(t, s)
+		defer s.Close()
+
+		_, err = s.UserGet("alice")
+		require.NoError(err)
+	})
+}
@@ -0,0 +1,142 @@
+package state
+
+import (
+	"github.com/hashicorp/go-memdb"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+var vagrantfileCacheBucket = []byte("vagrantfile_cache")
+
+func init() {
+	dbBuckets = append(dbBuckets, vagrantfileCacheBucket)
+	dbIndexers = append(dbIndexers, (*State).vagrantfileCacheIndexInit)
+	schemas = append(schemas, vagrantfileCacheIndexSchema)
+}
+
+// VagrantfileCacheGet gets a cached Vagrantfile parse by key.
+func (s *State) VagrantfileCacheGet(key string) (*vagrant_server.VagrantfileCache, error) {
+	memTxn := s.inmem.Txn(false)
+	defer memTxn.Abort()
+
+	raw, err := memTxn.First(vagrantfileCacheIndexTableName, vagrantfileCacheIndexIdIndexName, key)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, status.Errorf(codes.NotFound, "vagrantfile cache entry not found")
+	}
+
+	return raw.(*vagrantfileCacheIndexRecord).Cache, nil
+}
+
+// VagrantfileCachePut stores a Vagrantfile parse. Any entry stored for the
+// same path is removed, since it can no longer match the file on disk.
+func (s *State) VagrantfileCachePut(value *vagrant_server.VagrantfileCache) error {
+	memTxn := s.inmem.Txn(true)
+	defer memTxn.Abort()
+
+	err := s.db.Update(func(dbTxn *bolt.Tx) error {
+		b := dbTxn.Bucket(vagrantfileCacheBucket)
+
+		iter, err := memTxn.Get(vagrantfileCacheIndexTableName, vagrantfileCacheIndexPathIndexName, value.Path)
+		if err != nil {
+			return err
+		}
+
+		var stale []*vagrantfileCacheIndexRecord
+		for {
+			next := iter.Next()
+			if next == nil {
+				break
+			}
+
+			stale = append(stale, next.(*vagrantfileCacheIndexRecord))
+		}
+
+		for _, record := range stale {
+			if err := b.Delete([]byte(record.Id)); err != nil {
+				return err
+			}
+			if err := memTxn.Delete(vagrantfileCacheIndexTableName, record); err != nil {
+				return err
+			}
+		}
+
+		if err := dbPut(b, []byte(value.Key), value); err != nil {
+			return err
+		}
+
+		return s.vagrantfileCacheIndexSet(memTxn, value)
+	})
+	if err == nil {
+		memTxn.Commit()
+	}
+
+	return err
+}
+
+// vagrantfileCacheIndexSet writes an index record for a single entry.
+func (s *State) vagrantfileCacheIndexSet(txn *memdb.Txn, value *vagrant_server.VagrantfileCache) error {
+	record := &vagrantfileCacheIndexRecord{
+		Id:    value.Key,
+		Path:  value.Path,
+		Cache: value,
+	}
+
+	return txn.Insert(vagrantfileCacheIndexTableName, record)
+}
+
+// vagrantfileCacheIndexInit initializes the cache index from persisted data.
+func (s *State) vagrantfileCacheIndexInit(dbTxn *bolt.Tx, memTxn *memdb.Txn) error {
+	bucket := dbTxn.Bucket(vagrantfileCacheBucket)
+	return bucket.ForEach(func(k, v []byte) error {
+		var value vagrant_server.VagrantfileCache
+		if err := proto.Unmarshal(v, &value); err != nil {
+			return err
+		}
+
+		return s.vagrantfileCacheIndexSet(memTxn, &value)
+	})
+}
+
+func vagrantfileCacheIndexSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: vagrantfileCacheIndexTableName,
+		Indexes: map[string]*memdb.IndexSchema{
+			vagrantfileCacheIndexIdIndexName: {
+				Name:         vagrantfileCacheIndexIdIndexName,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Id",
+				},
+			},
+
+			vagrantfileCacheIndexPathIndexName: {
+				Name:         vagrantfileCacheIndexPathIndexName,
+				AllowMissing: true,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Path",
+				},
+			},
+		},
+	}
+}
+
+const (
+	vagrantfileCacheIndexTableName     = "vagrantfile-cache-index"
+	vagrantfileCacheIndexIdIndexName   = "id"
+	vagrantfileCacheIndexPathIndexName = "path"
+)
+
+type vagrantfileCacheIndexRecord struct {
+	Id    string
+	Path  string
+	Cache *vagrant_server.VagrantfileCache
+}
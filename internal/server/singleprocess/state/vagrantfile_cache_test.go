@@ -0,0 +1,81 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+func TestVagrantfileCache(t *testing.T) {
+	t.Run("Put and Get", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		_, err := s.VagrantfileCacheGet("foo")
+		require.Error(err)
+		require.Equal(codes.NotFound, status.Code(err))
+
+		require.NoError(s.VagrantfileCachePut(&vagrant_server.VagrantfileCache{
+			Key:  "foo",
+			Path: "/a/Vagrantfile",
+			Vagrantfile: &vagrant_server.Vagrantfile{
+				Format: vagrant_server.Vagrantfile_RUBY,
+			},
+		}))
+
+		cache, err := s.VagrantfileCacheGet("foo")
+		require.NoError(err)
+		require.Equal("/a/Vagrantfile", cache.Path)
+		require.Equal(vagrant_server.Vagrantfile_RUBY, cache.Vagrantfile.Format)
+	})
+
+	t.Run("Put replaces entries for the same path", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.VagrantfileCachePut(&vagrant_server.VagrantfileCache{
+			Key: "foo", Path: "/a/Vagrantfile",
+		}))
+		require.NoError(s.VagrantfileCachePut(&vagrant_server.VagrantfileCache{
+			Key: "bar", Path: "/b/Vagrantfile",
+		}))
+		require.NoError(s.VagrantfileCachePut(&vagrant_server.VagrantfileCache{
+			Key: "baz", Path: "/a/Vagrantfile",
+		}))
+
+		_, err := s.VagrantfileCacheGet("foo")
+		require.Equal(codes.NotFound, status.Code(err))
+
+		_, err = s.VagrantfileCacheGet("bar")
+		require.NoError(err)
+
+		_, err = s.VagrantfileCacheGet("baz")
+		require.NoError(err)
+	})
+
+	t.Run("Persists across restarts", func(t *testing.T) {
+		require := require.New(t)
+
+		s := TestState(t)
+		defer s.Close()
+
+		require.NoError(s.VagrantfileCachePut(&vagrant_server.VagrantfileCache{
+			Key: "foo", Path: "/a/Vagrantfile",
+		}))
+
+		s, err := TestStateRestart(t, s)
+		require.NoError(err)
+		defer s.Close()
+
+		_, err = s.VagrantfileCacheGet("foo")
+		require.NoError(err)
+	})
+}
@@ -2,7 +2,18 @@ package server
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
 	"net"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/mitchellh/go-testing-interface"
 	"github.com/stretchr/testify/require"
@@ -132,3 +143,99 @@ func testVersionInfoResponse() *vagrant_server.GetVersionInfoResponse {
 		},
 	}
 }
+
+// TestCA is a certificate authority that issues certificates for tests
+// using TLS.
+type TestCA struct {
+	// CertFile is the path to the PEM encoded CA certificate.
+	CertFile string
+
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	dir  string
+}
+
+// NewTestCA creates a new certificate authority. The files for the CA
+// and any certificates it issues are removed when the test completes.
+func NewTestCA(t testing.T) *TestCA {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "vagrant-test-ca")
+	require.NoError(err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Vagrant Test CA"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(err)
+
+	ca := &TestCA{
+		CertFile: filepath.Join(dir, "ca.pem"),
+		cert:     cert,
+		key:      key,
+		dir:      dir,
+	}
+	require.NoError(ioutil.WriteFile(ca.CertFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: der,
+	}), 0600))
+
+	return ca
+}
+
+// Issue issues a certificate with the given common name that is valid for
+// both servers and clients on the loopback address. The paths to the PEM
+// encoded certificate and key are returned.
+func (ca *TestCA) Issue(t testing.T, cn string) (certFile, keyFile string) {
+	require := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	require.NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+		},
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(err)
+
+	certFile = filepath.Join(ca.dir, cn+".pem")
+	keyFile = filepath.Join(ca.dir, cn+"-key.pem")
+	require.NoError(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: der,
+	}), 0600))
+	require.NoError(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "EC PRIVATE KEY",
+		Bytes: keyDer,
+	}), 0600))
+
+	return certFile, keyFile
+}
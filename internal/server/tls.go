@@ -0,0 +1,123 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"time"
+)
+
+// selfSignedValidity is how long a generated self-signed certificate
+// is valid for.
+const selfSignedValidity = 365 * 24 * time.Hour
+
+// TLSConfig returns the TLS configuration for a server listener. If
+// certFile and keyFile are set, the certificate is loaded from them.
+// Otherwise a self-signed certificate is generated for the local host.
+// If clientCAFile is set, clients must present a certificate signed by
+// one of the CAs in that file.
+func TLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	var cert tls.Certificate
+	var err error
+	if certFile != "" || keyFile != "" {
+		cert, err = tls.LoadX509KeyPair(certFile, keyFile)
+	} else {
+		hosts := []string{"localhost", "127.0.0.1", "::1"}
+		if hostname, herr := os.Hostname(); herr == nil && hostname != "" {
+			hosts = append(hosts, hostname)
+		}
+		cert, err = SelfSignedCertificate(hosts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		pool, err := CertPool(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return cfg, nil
+}
+
+// CertPool returns a certificate pool with the PEM encoded certificates
+// in the given file.
+func CertPool(path string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+
+	return pool, nil
+}
+
+// SelfSignedCertificate generates a self-signed certificate that is
+// valid for the given hosts. Hosts may be host names or IP addresses.
+func SelfSignedCertificate(hosts ...string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"Vagrant"}},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfSignedCertificate(t *testing.T) {
+	require := require.New(t)
+
+	cert, err := SelfSignedCertificate("localhost", "127.0.0.1")
+	require.NoError(err)
+	require.NotNil(cert.Leaf)
+	require.Equal([]string{"localhost"}, cert.Leaf.DNSNames)
+	require.Len(cert.Leaf.IPAddresses, 1)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert.Leaf)
+	_, err = cert.Leaf.Verify(x509.VerifyOptions{
+		DNSName: "localhost",
+		Roots:   pool,
+	})
+	require.NoError(err)
+}
+
+func TestTLSConfig(t *testing.T) {
+	require := require.New(t)
+
+	cfg, err := TLSConfig("", "", "")
+	require.NoError(err)
+	require.Len(cfg.Certificates, 1)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	ln = tls.NewListener(ln, cfg)
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte("hello"))
+	}()
+
+	conn, err := tls.Dial("tcp", ln.Addr().String(), &tls.Config{
+		InsecureSkipVerify: true,
+	})
+	require.NoError(err)
+	defer conn.Close()
+
+	data, err := ioutil.ReadAll(conn)
+	require.NoError(err)
+	require.Equal("hello", string(data))
+
+	_, err = TLSConfig("missing.crt", "missing.key", "")
+	require.Error(err)
+}
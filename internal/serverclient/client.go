@@ -3,7 +3,9 @@ package serverclient
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"time"
 
@@ -93,9 +95,14 @@ func Connect(ctx context.Context, opts ...ConnectOption) (*grpc.ClientConn, erro
 
 	if !cfg.Tls {
 		grpcOpts = append(grpcOpts, grpc.WithInsecure())
-	} else if cfg.TlsSkipVerify {
+	} else {
+		tlsConfig, err := cfg.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+
 		grpcOpts = append(grpcOpts, grpc.WithTransportCredentials(
-			credentials.NewTLS(&tls.Config{InsecureSkipVerify: true}),
+			credentials.NewTLS(tlsConfig),
 		))
 	}
 
@@ -133,6 +140,9 @@ func ContextConfig(opts ...ConnectOption) (*clicontext.Config, error) {
 			Address:       cfg.Addr,
 			Tls:           cfg.Tls,
 			TlsSkipVerify: cfg.TlsSkipVerify,
+			TlsCertFile:   cfg.TlsCertFile,
+			TlsKeyFile:    cfg.TlsKeyFile,
+			TlsCAFile:     cfg.TlsCAFile,
 			RequireAuth:   cfg.Token != "",
 			AuthToken:     cfg.Token,
 		},
@@ -143,12 +153,45 @@ type connectConfig struct {
 	Addr          string
 	Tls           bool
 	TlsSkipVerify bool
+	TlsCertFile   string
+	TlsKeyFile    string
+	TlsCAFile     string
 	Auth          bool
 	Token         string
 	Optional      bool // See Optional func
 	Timeout       time.Duration
 }
 
+// tlsConfig returns the TLS configuration to connect to the server
+// with. If a client certificate is configured it is presented to the
+// server for mutual TLS.
+func (c *connectConfig) tlsConfig() (*tls.Config, error) {
+	cfg := &tls.Config{InsecureSkipVerify: c.TlsSkipVerify}
+
+	if c.TlsCAFile != "" {
+		data, err := ioutil.ReadFile(c.TlsCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s", c.TlsCAFile)
+		}
+	}
+
+	if c.TlsCertFile != "" || c.TlsKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.TlsCertFile, c.TlsKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
 func WithAddr(addr string) ConnectOption {
 	return func(c *connectConfig) error {
 		c.Addr = addr
@@ -164,6 +207,9 @@ func FromEnv() ConnectOption {
 			c.Addr = v
 			c.Tls = os.Getenv(EnvServerTls) != ""
 			c.TlsSkipVerify = os.Getenv(EnvServerTlsSkipVerify) != ""
+			c.TlsCertFile = os.Getenv(EnvServerTlsCertFile)
+			c.TlsKeyFile = os.Getenv(EnvServerTlsKeyFile)
+			c.TlsCAFile = os.Getenv(EnvServerTlsCAFile)
 			c.Auth = os.Getenv(EnvServerToken) != ""
 		}
 
@@ -178,6 +224,9 @@ func FromContextConfig(cfg *clicontext.Config) ConnectOption {
 			c.Addr = cfg.Server.Address
 			c.Tls = cfg.Server.Tls
 			c.TlsSkipVerify = cfg.Server.TlsSkipVerify
+			c.TlsCertFile = cfg.Server.TlsCertFile
+			c.TlsKeyFile = cfg.Server.TlsKeyFile
+			c.TlsCAFile = cfg.Server.TlsCAFile
 			if cfg.Server.RequireAuth {
 				c.Auth = true
 				c.Token = cfg.Server.AuthToken
@@ -265,6 +314,13 @@ const (
 	EnvServerTls           = "VAGRANT_SERVER_TLS"
 	EnvServerTlsSkipVerify = "VAGRANT_SERVER_TLS_SKIP_VERIFY"
 
+	// EnvServerTlsCertFile and EnvServerTlsKeyFile are the client
+	// certificate and key to present to the server for mutual TLS.
+	// EnvServerTlsCAFile is the CA bundle to verify the server with.
+	EnvServerTlsCertFile = "VAGRANT_SERVER_TLS_CERT_FILE"
+	EnvServerTlsKeyFile  = "VAGRANT_SERVER_TLS_KEY_FILE"
+	EnvServerTlsCAFile   = "VAGRANT_SERVER_TLS_CA_FILE"
+
 	// EnvServerToken is the token for authenticated with the server.
 	EnvServerToken = "VAGRANT_SERVER_TOKEN"
 
@@ -9,6 +9,13 @@ type Client struct {
 	Tls           bool `hcl:"tls,optional"`
 	TlsSkipVerify bool `hcl:"tls_skip_verify,optional"`
 
+	// TlsCertFile and TlsKeyFile are the client certificate and key to
+	// present to a server that requires mutual TLS. TlsCAFile, if set,
+	// is the CA bundle used to verify the server certificate.
+	TlsCertFile string `hcl:"tls_cert_file,optional"`
+	TlsKeyFile  string `hcl:"tls_key_file,optional"`
+	TlsCAFile   string `hcl:"tls_ca_file,optional"`
+
 	// AddressInternal is a temporary config to work with local deployments
 	// on platforms such as Docker for Mac. We need to discuss a more
 	// long term approach to this.
@@ -39,6 +46,10 @@ type Config struct {
 
 	// CEBConfig configures the entrypoint binary for deployments
 	CEBConfig *CEBConfig `hcl:"entrypoint_config,block"`
+
+	// DrainTimeout is how long a terminated server waits for running
+	// jobs to complete before exiting, such as "5m".
+	DrainTimeout string `hcl:"drain_timeout,optional"`
 }
 
 // CEBConfig is specific configuration for the entrypoint binaries
@@ -54,6 +65,15 @@ type Listener struct {
 	TLSDisable  bool   `hcl:"tls_disable,optional"`
 	TLSCertFile string `hcl:"tls_cert_file,optional"`
 	TLSKeyFile  string `hcl:"tls_key_file,optional"`
+
+	// TLSClientCAFile is a CA bundle used to verify client certificates.
+	// If set, clients must present a certificate signed by one of these CAs.
+	TLSClientCAFile string `hcl:"tls_client_ca_file,optional"`
+
+	// TLSClientCertAuth, if true, allows clients with a verified client
+	// certificate to authenticate without a token. The certificate
+	// subject is used as the identity of the client.
+	TLSClientCertAuth bool `hcl:"tls_client_cert_auth,optional"`
 }
 
 // URL is the configuration for the URL service.
@@ -0,0 +1,15 @@
+package serverconfig
+
+import (
+	"github.com/hashicorp/hcl/v2/hclsimple"
+)
+
+// Load loads the server configuration from the HCL file at the given path.
+func Load(path string) (*Config, error) {
+	var cfg Config
+	if err := hclsimple.DecodeFile(path, nil, &cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
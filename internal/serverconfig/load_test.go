@@ -0,0 +1,48 @@
+package serverconfig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	require := require.New(t)
+
+	td, err := ioutil.TempDir("", "vagrant-serverconfig")
+	require.NoError(err)
+	defer os.RemoveAll(td)
+
+	path := filepath.Join(td, "server.hcl")
+	require.NoError(ioutil.WriteFile(path, []byte(`
+db_path       = "/var/lib/vagrant/data.db"
+drain_timeout = "10m"
+
+grpc {
+  address       = "0.0.0.0:9701"
+  tls_cert_file = "/etc/vagrant/server.crt"
+  tls_key_file  = "/etc/vagrant/server.key"
+}
+
+http {
+  address     = "127.0.0.1:9702"
+  tls_disable = true
+}
+`), 0644))
+
+	cfg, err := Load(path)
+	require.NoError(err)
+	require.Equal("/var/lib/vagrant/data.db", cfg.DBPath)
+	require.Equal("10m", cfg.DrainTimeout)
+	require.Equal("0.0.0.0:9701", cfg.GRPC.Addr)
+	require.Equal("/etc/vagrant/server.crt", cfg.GRPC.TLSCertFile)
+	require.False(cfg.GRPC.TLSDisable)
+	require.Equal("127.0.0.1:9702", cfg.HTTP.Addr)
+	require.True(cfg.HTTP.TLSDisable)
+
+	_, err = Load(filepath.Join(td, "missing.hcl"))
+	require.Error(err)
+}
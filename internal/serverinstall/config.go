@@ -1,6 +1,6 @@
 package serverinstall
 
-// Config is the configuration for the Kubernetes installation template.
+// Config is the configuration for the server installation.
 type Config struct {
 	Namespace          string
 	ServiceName        string
@@ -16,4 +16,28 @@ type Config struct {
 	CPURequest         string
 	MemRequest         string
 	StorageRequest     string
+
+	// Nomad specific configuration
+	NomadRegion         string
+	NomadDatacenters    []string
+	NomadNamespace      string
+	NomadPolicyOverride bool
+}
+
+// DefaultConfig returns the default installation configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		Namespace:        "default",
+		ServiceName:      "vagrant",
+		ServerName:       "vagrant-server",
+		ServerImage:      "hashicorp/vagrant:latest",
+		ImagePullPolicy:  "Always",
+		Replicas:         1,
+		CPURequest:       "100m",
+		MemRequest:       "256Mi",
+		StorageRequest:   "1Gi",
+		NomadRegion:      "global",
+		NomadDatacenters: []string{"dc1"},
+		NomadNamespace:   "default",
+	}
 }
@@ -169,7 +169,7 @@ func InstallDocker(
 		Image:        scfg.ServerImage,
 		ExposedPorts: nat.PortSet{npGRPC: struct{}{}, npHTTP: struct{}{}},
 		Env:          []string{"PORT=" + grpcPort},
-		Cmd:          []string{"server", "run", "-accept-tos", "-vvv", "-db=/data/data.db", "-listen-grpc=0.0.0.0:9701", "-listen-http=0.0.0.0:9702"},
+		Cmd:          []string{"server", "run", "-VV", "--db=/data/data.db", "--listen-grpc=0.0.0.0:9701", "--listen-http=0.0.0.0:9702", "--disable-bootstrap"},
 	}
 
 	bindings := nat.PortMap{}
@@ -198,7 +198,7 @@ func InstallDocker(
 		"vagrant-type": "server",
 	}
 
-	cr, err := cli.ContainerCreate(ctx, &cfg, &hostconfig, &netconfig, "vagrant-server")
+	cr, err := cli.ContainerCreate(ctx, &cfg, &hostconfig, &netconfig, nil, "vagrant-server")
 	if err != nil {
 		return nil, nil, "", err
 	}
@@ -218,3 +218,117 @@ func InstallDocker(
 
 	return &clicfg, &addr, httpAddr, nil
 }
+
+// UpgradeDocker replaces the running Vagrant server container with one
+// using the latest version of the configured image. The server data is
+// stored in a volume so it is kept across the upgrade.
+func UpgradeDocker(
+	ctx context.Context, ui terminal.UI, scfg *Config) (
+	*clicontext.Config, *vagrant_server.ServerConfig_AdvertiseAddr, string, error,
+) {
+	if err := pullDockerServer(ctx, ui, scfg); err != nil {
+		return nil, nil, "", err
+	}
+
+	return InstallDocker(ctx, ui, scfg)
+}
+
+// pullDockerServer pulls the latest version of the server image and
+// removes the existing server container so it can be recreated.
+func pullDockerServer(ctx context.Context, ui terminal.UI, scfg *Config) error {
+	sg := ui.StepGroup()
+	defer sg.Wait()
+
+	s := sg.Add("Initializing Docker client...")
+	defer func() { s.Abort() }()
+
+	cli, err := client.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		return err
+	}
+	cli.NegotiateAPIVersion(ctx)
+
+	s.Update("Pulling image: %s", scfg.ServerImage)
+	resp, err := cli.ImagePull(ctx, scfg.ServerImage, types.ImagePullOptions{})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	err = jsonmessage.DisplayJSONMessagesStream(resp, s.TermOutput(), 0, false, nil)
+	if err != nil {
+		return fmt.Errorf("unable to stream pull logs to the terminal: %s", err)
+	}
+
+	s.Update("Removing existing Vagrant server container...")
+	found, err := removeDockerServer(ctx, cli)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("no existing Vagrant server found in Docker")
+	}
+
+	s.Update("Existing Vagrant server container removed")
+	s.Done()
+	return nil
+}
+
+// UninstallDocker removes the Vagrant server container and its data volume.
+func UninstallDocker(ctx context.Context, ui terminal.UI, scfg *Config) error {
+	sg := ui.StepGroup()
+	defer sg.Wait()
+
+	s := sg.Add("Initializing Docker client...")
+	defer func() { s.Abort() }()
+
+	cli, err := client.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		return err
+	}
+	cli.NegotiateAPIVersion(ctx)
+
+	s.Update("Removing Vagrant server container...")
+	found, err := removeDockerServer(ctx, cli)
+	if err != nil {
+		return err
+	}
+	if !found {
+		s.Update("No Vagrant server container found")
+		s.Status(terminal.StatusWarn)
+	}
+
+	s.Update("Removing Vagrant server data volume...")
+	if err := cli.VolumeRemove(ctx, "vagrant-server", true); err != nil && !client.IsErrNotFound(err) {
+		return err
+	}
+
+	s.Update("Vagrant server removed from Docker")
+	s.Done()
+	return nil
+}
+
+// removeDockerServer stops and removes any Vagrant server containers. It
+// returns whether a container was found.
+func removeDockerServer(ctx context.Context, cli *client.Client) (bool, error) {
+	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{
+		All: true,
+		Filters: filters.NewArgs(filters.KeyValuePair{
+			Key:   "label",
+			Value: "vagrant-type=server",
+		}),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, c := range containers {
+		if err := cli.ContainerRemove(ctx, c.ID, types.ContainerRemoveOptions{
+			Force: true,
+		}); err != nil {
+			return false, err
+		}
+	}
+
+	return len(containers) > 0, nil
+}
@@ -1,13 +1,27 @@
 package serverinstall
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
 
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/clicontext"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+	"github.com/hashicorp/vagrant/internal/serverconfig"
 )
 
 // NewStatefulSet creates a new Vagrant Statefulset for deployment in Kubernetes.
@@ -27,12 +41,28 @@ func (c *Config) NewStatefulSet() (*appsv1.StatefulSet, error) {
 		return nil, fmt.Errorf("could not parse storage request resource %s: %s", c.StorageRequest, err)
 	}
 
+	pullPolicy := apiv1.PullAlways
+	if c.ImagePullPolicy != "" {
+		pullPolicy = apiv1.PullPolicy(c.ImagePullPolicy)
+	}
+
+	var pullSecrets []apiv1.LocalObjectReference
+	if c.ImagePullSecret != "" {
+		pullSecrets = append(pullSecrets, apiv1.LocalObjectReference{
+			Name: c.ImagePullSecret,
+		})
+	}
+
 	securityContext := &apiv1.PodSecurityContext{}
 	if !c.OpenShift {
 		securityContext.FSGroup = int64Ptr(1000)
 	}
 
 	return &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "StatefulSet",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      c.ServerName,
 			Namespace: c.Namespace,
@@ -55,17 +85,13 @@ func (c *Config) NewStatefulSet() (*appsv1.StatefulSet, error) {
 					},
 				},
 				Spec: apiv1.PodSpec{
-					ImagePullSecrets: []apiv1.LocalObjectReference{
-						{
-							Name: c.ImagePullSecret,
-						},
-					},
-					SecurityContext: securityContext,
+					ImagePullSecrets: pullSecrets,
+					SecurityContext:  securityContext,
 					Containers: []apiv1.Container{
 						{
 							Name:            "server",
 							Image:           c.ServerImage,
-							ImagePullPolicy: apiv1.PullAlways,
+							ImagePullPolicy: pullPolicy,
 							Env: []apiv1.EnvVar{
 								{
 									Name:  "HOME",
@@ -76,11 +102,11 @@ func (c *Config) NewStatefulSet() (*appsv1.StatefulSet, error) {
 							Args: []string{
 								"server",
 								"run",
-								"-accept-tos",
-								"-vvv",
-								"-db=/data/data.db",
-								"-listen-grpc=0.0.0.0:9701",
-								"-listen-http=0.0.0.0:9702",
+								"-VV",
+								"--db=/data/data.db",
+								"--listen-grpc=0.0.0.0:9701",
+								"--listen-http=0.0.0.0:9702",
+								"--disable-bootstrap",
 							},
 							Ports: []apiv1.ContainerPort{
 								{
@@ -141,6 +167,10 @@ func (c *Config) NewStatefulSet() (*appsv1.StatefulSet, error) {
 // NewService creates a new Vagrant LoadBalancer for deployment in Kubernetes.
 func (c *Config) NewService() (*apiv1.Service, error) {
 	return &apiv1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      c.ServiceName,
 			Namespace: c.Namespace,
@@ -168,6 +198,230 @@ func (c *Config) NewService() (*apiv1.Service, error) {
 	}, nil
 }
 
+// WriteManifests writes the Kubernetes manifests for the server to w as
+// a multi-document YAML stream. The output can be reviewed or applied
+// with kubectl.
+func (c *Config) WriteManifests(w io.Writer) error {
+	ss, err := c.NewStatefulSet()
+	if err != nil {
+		return err
+	}
+	svc, err := c.NewService()
+	if err != nil {
+		return err
+	}
+
+	for i, obj := range []interface{}{ss, svc} {
+		// Encode through JSON so the Kubernetes field names are used
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		var raw interface{}
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		out, err := yaml.Marshal(raw)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InstallKubernetes installs the Vagrant server to the Kubernetes cluster
+// configured for kubectl and waits for its service to be assigned an
+// external address.
+func InstallKubernetes(
+	ctx context.Context, ui terminal.UI, scfg *Config) (
+	*clicontext.Config, *vagrant_server.ServerConfig_AdvertiseAddr, string, error,
+) {
+	sg := ui.StepGroup()
+	defer sg.Wait()
+
+	s := sg.Add("Checking for existing installation...")
+	defer func() { s.Abort() }()
+
+	if _, err := kubectl(ctx, scfg, nil, "get", "statefulset", scfg.ServerName); err == nil {
+		s.Update("Detected existing Vagrant server.")
+		s.Status(terminal.StatusWarn)
+		s.Done()
+
+		return kubernetesServerAddr(ctx, scfg)
+	}
+
+	s.Update("Installing Vagrant server to Kubernetes")
+	if err := kubectlApply(ctx, scfg); err != nil {
+		return nil, nil, "", err
+	}
+
+	s.Update("Waiting for Vagrant server to be ready")
+	if _, err := kubectl(ctx, scfg, nil, "rollout", "status",
+		"statefulset/"+scfg.ServerName, "--timeout=10m"); err != nil {
+		return nil, nil, "", err
+	}
+
+	clicfg, addr, httpAddr, err := kubernetesServerAddr(ctx, scfg)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	s.Update("Vagrant server ready")
+	s.Done()
+	return clicfg, addr, httpAddr, nil
+}
+
+// UpgradeKubernetes applies the current manifests to the existing server
+// installation and restarts it so the latest image is used. The server
+// data is kept in its persistent volume.
+func UpgradeKubernetes(
+	ctx context.Context, ui terminal.UI, scfg *Config) (
+	*clicontext.Config, *vagrant_server.ServerConfig_AdvertiseAddr, string, error,
+) {
+	sg := ui.StepGroup()
+	defer sg.Wait()
+
+	s := sg.Add("Checking for existing installation...")
+	defer func() { s.Abort() }()
+
+	if _, err := kubectl(ctx, scfg, nil, "get", "statefulset", scfg.ServerName); err != nil {
+		return nil, nil, "", fmt.Errorf("no existing Vagrant server found in Kubernetes: %w", err)
+	}
+
+	s.Update("Upgrading Vagrant server in Kubernetes")
+	if err := kubectlApply(ctx, scfg); err != nil {
+		return nil, nil, "", err
+	}
+	if _, err := kubectl(ctx, scfg, nil, "rollout", "restart",
+		"statefulset/"+scfg.ServerName); err != nil {
+		return nil, nil, "", err
+	}
+
+	s.Update("Waiting for Vagrant server to be ready")
+	if _, err := kubectl(ctx, scfg, nil, "rollout", "status",
+		"statefulset/"+scfg.ServerName, "--timeout=10m"); err != nil {
+		return nil, nil, "", err
+	}
+
+	clicfg, addr, httpAddr, err := kubernetesServerAddr(ctx, scfg)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	s.Update("Vagrant server upgraded")
+	s.Done()
+	return clicfg, addr, httpAddr, nil
+}
+
+// UninstallKubernetes removes the Vagrant server and its data volume from
+// the Kubernetes cluster.
+func UninstallKubernetes(ctx context.Context, ui terminal.UI, scfg *Config) error {
+	sg := ui.StepGroup()
+	defer sg.Wait()
+
+	s := sg.Add("Removing Vagrant server from Kubernetes...")
+	defer func() { s.Abort() }()
+
+	if _, err := kubectl(ctx, scfg, nil, "delete", "--ignore-not-found",
+		"statefulset/"+scfg.ServerName, "service/"+scfg.ServiceName); err != nil {
+		return err
+	}
+
+	s.Update("Removing Vagrant server data volume...")
+	if _, err := kubectl(ctx, scfg, nil, "delete", "--ignore-not-found",
+		"persistentvolumeclaim/data-"+scfg.ServerName+"-0"); err != nil {
+		return err
+	}
+
+	s.Update("Vagrant server removed from Kubernetes")
+	s.Done()
+	return nil
+}
+
+// kubernetesServerAddr waits for the server service to be assigned an
+// external address and returns the connection configuration for it.
+func kubernetesServerAddr(ctx context.Context, scfg *Config) (
+	*clicontext.Config, *vagrant_server.ServerConfig_AdvertiseAddr, string, error,
+) {
+	var host string
+	for {
+		out, err := kubectl(ctx, scfg, nil, "get", "service", scfg.ServiceName, "-o",
+			"jsonpath={.status.loadBalancer.ingress[0].ip}{.status.loadBalancer.ingress[0].hostname}")
+		if err != nil {
+			return nil, nil, "", err
+		}
+		host = strings.TrimSpace(string(out))
+		if host != "" {
+			break
+		}
+
+		select {
+		case <-time.After(2 * time.Second):
+		case <-ctx.Done():
+			return nil, nil, "", ctx.Err()
+		}
+	}
+
+	grpcAddr := host + ":9701"
+	clicfg := &clicontext.Config{
+		Server: serverconfig.Client{
+			Address:       grpcAddr,
+			Tls:           true,
+			TlsSkipVerify: true,
+		},
+	}
+	addr := &vagrant_server.ServerConfig_AdvertiseAddr{
+		Addr:          scfg.ServiceName + ":9701",
+		Tls:           true,
+		TlsSkipVerify: true,
+	}
+
+	return clicfg, addr, host + ":9702", nil
+}
+
+// kubectlApply applies the server manifests using kubectl.
+func kubectlApply(ctx context.Context, scfg *Config) error {
+	var buf bytes.Buffer
+	if err := scfg.WriteManifests(&buf); err != nil {
+		return err
+	}
+
+	_, err := kubectl(ctx, scfg, &buf, "apply", "-f", "-")
+	return err
+}
+
+// kubectl runs kubectl within the configured namespace and returns
+// its output.
+func kubectl(ctx context.Context, scfg *Config, stdin io.Reader, args ...string) ([]byte, error) {
+	cmdArgs := args
+	if scfg.Namespace != "" {
+		cmdArgs = append([]string{"--namespace", scfg.Namespace}, args...)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "kubectl", cmdArgs...)
+	cmd.Stdin = stdin
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("kubectl %s failed: %w: %s",
+			strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
+
 func int32Ptr(i int32) *int32 {
 	return &i
 }
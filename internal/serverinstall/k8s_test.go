@@ -0,0 +1,62 @@
+package serverinstall
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestConfigWriteManifests(t *testing.T) {
+	require := require.New(t)
+
+	cfg := DefaultConfig()
+	cfg.Namespace = "vagrant"
+	cfg.ServerImage = "example.com/vagrant:1.0"
+
+	var buf bytes.Buffer
+	require.NoError(cfg.WriteManifests(&buf))
+
+	var docs []map[string]interface{}
+	dec := yaml.NewDecoder(&buf)
+	for {
+		var doc map[string]interface{}
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		docs = append(docs, doc)
+	}
+	require.Len(docs, 2)
+
+	require.Equal("StatefulSet", docs[0]["kind"])
+	require.Equal("apps/v1", docs[0]["apiVersion"])
+	meta := docs[0]["metadata"].(map[interface{}]interface{})
+	require.Equal("vagrant-server", meta["name"])
+	require.Equal("vagrant", meta["namespace"])
+
+	spec := docs[0]["spec"].(map[interface{}]interface{})
+	tmpl := spec["template"].(map[interface{}]interface{})
+	containers := tmpl["spec"].(map[interface{}]interface{})["containers"].([]interface{})
+	container := containers[0].(map[interface{}]interface{})
+	require.Equal("example.com/vagrant:1.0", container["image"])
+	require.Contains(container["args"], "--db=/data/data.db")
+
+	// The installer requests the bootstrap token, so the server must
+	// not use it up on its first start
+	require.Contains(container["args"], "--disable-bootstrap")
+
+	require.Equal("Service", docs[1]["kind"])
+	require.Equal("v1", docs[1]["apiVersion"])
+}
+
+func TestConfigNewStatefulSet_invalid(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MemRequest = "lots"
+
+	_, err := cfg.NewStatefulSet()
+	require.Error(t, err)
+}
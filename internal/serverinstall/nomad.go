@@ -8,18 +8,10 @@ import (
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
 	"github.com/hashicorp/vagrant/internal/clicontext"
-	"github.com/hashicorp/vagrant/internal/pkg/flag"
 	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
 	"github.com/hashicorp/vagrant/internal/serverconfig"
 )
 
-var (
-	nomadRegionF         string
-	nomadDatacentersF    []string
-	nomadNamespaceF      string
-	nomadPolicyOverrideF bool
-)
-
 // InstallNomad registers a vagrant-server job with a Nomad cluster
 func InstallNomad(
 	ctx context.Context, ui terminal.UI, scfg *Config) (
@@ -90,9 +82,78 @@ func InstallNomad(
 	}
 
 	s.Update("Installing Vagrant server to Nomad")
+	return registerNomadJob(ctx, client, s, scfg)
+}
+
+// UpgradeNomad updates an existing vagrant-server job in a Nomad cluster
+// to use the configured server image. Nomad performs the update in place
+// and the data in the allocation directory is kept.
+func UpgradeNomad(
+	ctx context.Context, ui terminal.UI, scfg *Config) (
+	*clicontext.Config, *vagrant_server.ServerConfig_AdvertiseAddr, string, error,
+) {
+	sg := ui.StepGroup()
+	defer sg.Wait()
+
+	s := sg.Add("Initializing Nomad client...")
+	defer func() { s.Abort() }()
+
+	client, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	s.Update("Checking for existing Vagrant server...")
+	if _, _, err := client.Jobs().Info("vagrant-server", nil); err != nil {
+		return nil, nil, "", fmt.Errorf("no existing Vagrant server found in Nomad: %w", err)
+	}
+
+	s.Update("Upgrading Vagrant server in Nomad")
+	return registerNomadJob(ctx, client, s, scfg)
+}
+
+// UninstallNomad deregisters the vagrant-server job from a Nomad cluster.
+func UninstallNomad(ctx context.Context, ui terminal.UI, scfg *Config) error {
+	sg := ui.StepGroup()
+	defer sg.Wait()
+
+	s := sg.Add("Initializing Nomad client...")
+	defer func() { s.Abort() }()
+
+	client, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return err
+	}
+
+	s.Update("Removing Vagrant server from Nomad")
+	q := &api.WriteOptions{Namespace: scfg.NomadNamespace, Region: scfg.NomadRegion}
+	if _, _, err := client.Jobs().Deregister("vagrant-server", true, q); err != nil {
+		return err
+	}
+
+	s.Update("Vagrant server removed from Nomad")
+	s.Done()
+	return nil
+}
+
+// registerNomadJob registers the vagrant-server job and waits for its
+// allocation to be running.
+func registerNomadJob(
+	ctx context.Context, client *api.Client, s terminal.Step, scfg *Config) (
+	*clicontext.Config, *vagrant_server.ServerConfig_AdvertiseAddr, string, error,
+) {
+	var (
+		clicfg   clicontext.Config
+		addr     vagrant_server.ServerConfig_AdvertiseAddr
+		httpAddr string
+	)
+
+	addr.Tls = true
+	addr.TlsSkipVerify = true
+
 	job := vagrantNomadJob(scfg)
 	jobOpts := &api.RegisterOptions{
-		PolicyOverride: nomadPolicyOverrideF,
+		PolicyOverride: scfg.NomadPolicyOverride,
 	}
 
 	resp, _, err := client.Jobs().RegisterOpts(job, jobOpts, nil)
@@ -183,10 +244,14 @@ EVAL:
 	return &clicfg, &addr, httpAddr, nil
 }
 
+// nomadDrainTimeout is how long the server waits for running jobs when
+// Nomad stops it.
+const nomadDrainTimeout = 5 * time.Minute
+
 func vagrantNomadJob(scfg *Config) *api.Job {
-	job := api.NewServiceJob("vagrant-server", "vagrant-server", nomadRegionF, 50)
-	job.Namespace = &nomadNamespaceF
-	job.Datacenters = nomadDatacentersF
+	job := api.NewServiceJob("vagrant-server", "vagrant-server", scfg.NomadRegion, 50)
+	job.Namespace = &scfg.NomadNamespace
+	job.Datacenters = scfg.NomadDatacenters
 	job.Meta = scfg.ServiceAnnotations
 	tg := api.NewTaskGroup("vagrant-server", 1)
 	tg.Networks = []*api.NetworkResource{
@@ -214,11 +279,36 @@ func vagrantNomadJob(scfg *Config) *api.Job {
 	task.Config = map[string]interface{}{
 		"image": scfg.ServerImage,
 		"ports": []string{"server", "ui"},
-		"args":  []string{"server", "run", "-accept-tos", "-vvv", "-db=/alloc/data.db", "-listen-grpc=0.0.0.0:9701", "-listen-http=0.0.0.0:9702"},
+		"args": []string{"server", "run", "-VV", "--db=/alloc/data.db", "--listen-grpc=0.0.0.0:9701", "--listen-http=0.0.0.0:9702",
+			"--drain-timeout=" + nomadDrainTimeout.String(), "--disable-bootstrap"},
 	}
 	task.Env = map[string]string{
 		"PORT": "9701",
 	}
+
+	// The server drains on SIGTERM, give it time to do so before it is
+	// killed. Nomad caps this at the max_kill_timeout of the client.
+	killTimeout := nomadDrainTimeout + 30*time.Second
+	task.KillTimeout = &killTimeout
+
+	// Only route to the server once it reports that it is ready
+	task.Services = []*api.Service{
+		{
+			Name:      "vagrant-server",
+			PortLabel: "ui",
+			Checks: []api.ServiceCheck{
+				{
+					Name:          "vagrant-server-ready",
+					Type:          "http",
+					Protocol:      "https",
+					Path:          "/readyz",
+					TLSSkipVerify: true,
+					Interval:      10 * time.Second,
+					Timeout:       2 * time.Second,
+				},
+			},
+		},
+	}
 	tg.AddTask(task)
 
 	return job
@@ -253,34 +343,3 @@ func getHTTPFromAllocID(allocID string, client *api.Client) (string, error) {
 
 	return "", nil
 }
-
-// NomadFlags config values for Nomad
-func NomadFlags(f *flag.Set) {
-	f.StringVar(&flag.StringVar{
-		Name:    "nomad-region",
-		Target:  &nomadRegionF,
-		Default: "global",
-		Usage:   "Nomad region to install to if using Nomad platform",
-	})
-
-	f.StringSliceVar(&flag.StringSliceVar{
-		Name:    "nomad-dc",
-		Target:  &nomadDatacentersF,
-		Default: []string{"dc1"},
-		Usage:   "Nomad datacenters to install to if using Nomad platform",
-	})
-
-	f.StringVar(&flag.StringVar{
-		Name:    "nomad-namespace",
-		Target:  &nomadNamespaceF,
-		Default: "default",
-		Usage:   "Nomad namespace to install to if using Nomad platform",
-	})
-
-	f.BoolVar(&flag.BoolVar{
-		Name:    "nomad-policy-override",
-		Target:  &nomadPolicyOverrideF,
-		Default: false,
-		Usage:   "Override the Nomad sentinel policy if using enterprise Nomad platform",
-	})
-}
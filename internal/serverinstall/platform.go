@@ -0,0 +1,48 @@
+package serverinstall
+
+import (
+	"context"
+
+	"github.com/hashicorp/vagrant-plugin-sdk/terminal"
+	"github.com/hashicorp/vagrant/internal/clicontext"
+	"github.com/hashicorp/vagrant/internal/server/proto/vagrant_server"
+)
+
+// Platform installs, upgrades and uninstalls the Vagrant server on a
+// specific platform.
+type Platform struct {
+	// Install installs the server. The returned values are the client
+	// configuration to connect to the server, the address the server
+	// advertises to deployments, and the HTTP address.
+	Install func(context.Context, terminal.UI, *Config) (
+		*clicontext.Config, *vagrant_server.ServerConfig_AdvertiseAddr, string, error)
+
+	// Upgrade upgrades an existing server installation, keeping its data.
+	// It returns the same values as Install.
+	Upgrade func(context.Context, terminal.UI, *Config) (
+		*clicontext.Config, *vagrant_server.ServerConfig_AdvertiseAddr, string, error)
+
+	// Uninstall removes the server and its data.
+	Uninstall func(context.Context, terminal.UI, *Config) error
+}
+
+// Platforms are the platforms the server can be installed to.
+var Platforms = map[string]*Platform{
+	"docker": {
+		Install:   InstallDocker,
+		Upgrade:   UpgradeDocker,
+		Uninstall: UninstallDocker,
+	},
+
+	"kubernetes": {
+		Install:   InstallKubernetes,
+		Upgrade:   UpgradeKubernetes,
+		Uninstall: UninstallKubernetes,
+	},
+
+	"nomad": {
+		Install:   InstallNomad,
+		Upgrade:   UpgradeNomad,
+		Uninstall: UninstallNomad,
+	},
+}
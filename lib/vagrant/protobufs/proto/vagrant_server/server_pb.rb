@@ -36,6 +36,21 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
       value :HCL, 1
       value :RUBY, 2
     end
+    add_message "hashicorp.vagrant.VagrantfileCache" do
+      optional :key, :string, 1
+      optional :path, :string, 2
+      optional :vagrantfile, :message, 3, "hashicorp.vagrant.Vagrantfile"
+      optional :updated_at, :message, 4, "google.protobuf.Timestamp"
+    end
+    add_message "hashicorp.vagrant.GetVagrantfileCacheRequest" do
+      optional :key, :string, 1
+    end
+    add_message "hashicorp.vagrant.GetVagrantfileCacheResponse" do
+      optional :cache, :message, 1, "hashicorp.vagrant.VagrantfileCache"
+    end
+    add_message "hashicorp.vagrant.UpsertVagrantfileCacheRequest" do
+      optional :cache, :message, 1, "hashicorp.vagrant.VagrantfileCache"
+    end
     add_message "hashicorp.vagrant.Basis" do
       optional :resource_id, :string, 1
       optional :name, :string, 2
@@ -80,6 +95,7 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
       optional :configuration, :message, 10, "hashicorp.vagrant.sdk.Args.ConfigData"
       optional :record, :message, 11, "google.protobuf.Any"
       optional :provider, :string, 12
+      optional :updated_at, :message, 13, "google.protobuf.Timestamp"
       optional :remote_enabled, :bool, 100
       optional :data_source, :message, 101, "hashicorp.vagrant.Job.DataSource"
     end
@@ -230,6 +246,7 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
       optional :result, :message, 107, "hashicorp.vagrant.Job.Result"
       optional :cancel_time, :message, 108, "google.protobuf.Timestamp"
       optional :expire_time, :message, 109, "google.protobuf.Timestamp"
+      optional :user, :string, 110
       oneof :operation do
         optional :noop, :message, 50, "hashicorp.vagrant.Job.Noop"
         optional :auth, :message, 51, "hashicorp.vagrant.Job.AuthOp"
@@ -237,6 +254,9 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
         optional :validate, :message, 53, "hashicorp.vagrant.Job.ValidateOp"
         optional :run, :message, 54, "hashicorp.vagrant.Job.RunOp"
         optional :init, :message, 55, "hashicorp.vagrant.Job.InitOp"
+        optional :config, :message, 56, "hashicorp.vagrant.Job.ConfigOp"
+        optional :global_status, :message, 57, "hashicorp.vagrant.Job.GlobalStatusOp"
+        optional :provider_list, :message, 58, "hashicorp.vagrant.Job.ProviderListOp"
       end
     end
     add_message "hashicorp.vagrant.Job.Result" do
@@ -245,11 +265,16 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
       optional :validate, :message, 3, "hashicorp.vagrant.Job.ValidateResult"
       optional :init, :message, 4, "hashicorp.vagrant.Job.InitResult"
       optional :run, :message, 5, "hashicorp.vagrant.Job.RunResult"
+      optional :config, :message, 6, "hashicorp.vagrant.Job.ConfigResult"
+      optional :global_status, :message, 7, "hashicorp.vagrant.Job.GlobalStatusResult"
+      optional :provider_list, :message, 8, "hashicorp.vagrant.Job.ProviderListResult"
     end
     add_message "hashicorp.vagrant.Job.DataSource" do
       oneof :source do
         optional :local, :message, 1, "hashicorp.vagrant.Job.Local"
         optional :git, :message, 2, "hashicorp.vagrant.Job.Git"
+        optional :archive, :message, 3, "hashicorp.vagrant.Job.Archive"
+        optional :upload, :message, 4, "hashicorp.vagrant.Job.Upload"
       end
     end
     add_message "hashicorp.vagrant.Job.Local" do
@@ -259,11 +284,30 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
       optional :ref, :string, 2
       optional :path, :string, 3
     end
+    add_message "hashicorp.vagrant.Job.Archive" do
+      optional :url, :string, 1
+      optional :checksum, :string, 2
+      optional :path, :string, 3
+    end
+    add_message "hashicorp.vagrant.Job.Upload" do
+      optional :digest, :string, 1
+    end
     add_message "hashicorp.vagrant.Job.Noop" do
     end
     add_message "hashicorp.vagrant.Job.ValidateOp" do
+      repeated :targets, :string, 1
     end
     add_message "hashicorp.vagrant.Job.ValidateResult" do
+      repeated :targets, :message, 1, "hashicorp.vagrant.Job.ValidateResult.Target"
+    end
+    add_message "hashicorp.vagrant.Job.ValidateResult.Target" do
+      optional :name, :string, 1
+      repeated :errors, :message, 2, "hashicorp.vagrant.Job.ValidateResult.Problem"
+      repeated :warnings, :message, 3, "hashicorp.vagrant.Job.ValidateResult.Problem"
+    end
+    add_message "hashicorp.vagrant.Job.ValidateResult.Problem" do
+      optional :namespace, :string, 1
+      optional :message, :string, 2
     end
     add_message "hashicorp.vagrant.Job.InitOp" do
     end
@@ -294,6 +338,14 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
       optional :run_result, :bool, 2
       optional :run_error, :message, 3, "google.rpc.Status"
       optional :exit_code, :sint32, 4
+      repeated :target_results, :message, 5, "hashicorp.vagrant.Job.RunResult.TargetResult"
+    end
+    add_message "hashicorp.vagrant.Job.RunResult.TargetResult" do
+      optional :target, :message, 1, "hashicorp.vagrant.sdk.Ref.Target"
+      optional :run_result, :bool, 2
+      optional :run_error, :message, 3, "google.rpc.Status"
+      optional :exit_code, :sint32, 4
+      optional :skipped, :bool, 5
     end
     add_message "hashicorp.vagrant.Job.AuthOp" do
       optional :check_only, :bool, 1
@@ -319,6 +371,48 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
       optional :component, :message, 1, "hashicorp.vagrant.Component"
       optional :docs, :message, 2, "hashicorp.vagrant.Documentation"
     end
+    add_message "hashicorp.vagrant.Job.ConfigOp" do
+      optional :target, :string, 1
+      optional :provider, :string, 2
+    end
+    add_message "hashicorp.vagrant.Job.ConfigResult" do
+      optional :target, :string, 1
+      optional :finalized, :message, 2, "hashicorp.vagrant.sdk.Args.Hash"
+      map :sources, :string, :string, 3
+    end
+    add_message "hashicorp.vagrant.Job.GlobalStatusOp" do
+      repeated :providers, :string, 1
+      repeated :states, :enum, 2, "hashicorp.vagrant.Operation.PhysicalState"
+      optional :prune, :bool, 3
+    end
+    add_message "hashicorp.vagrant.Job.GlobalStatusResult" do
+      repeated :targets, :message, 1, "hashicorp.vagrant.Job.GlobalStatusResult.Entry"
+      repeated :pruned, :message, 2, "hashicorp.vagrant.Job.GlobalStatusResult.Entry"
+      repeated :orphaned, :message, 3, "hashicorp.vagrant.Job.GlobalStatusResult.Entry"
+    end
+    add_message "hashicorp.vagrant.Job.GlobalStatusResult.Entry" do
+      optional :uuid, :string, 1
+      optional :name, :string, 2
+      optional :provider, :string, 3
+      optional :state, :enum, 4, "hashicorp.vagrant.Operation.PhysicalState"
+      optional :project_path, :string, 5
+      optional :updated_at, :message, 6, "google.protobuf.Timestamp"
+    end
+    add_message "hashicorp.vagrant.Job.ProviderListOp" do
+    end
+    add_message "hashicorp.vagrant.Job.ProviderListResult" do
+      optional :default_provider, :string, 1
+      repeated :providers, :message, 2, "hashicorp.vagrant.Job.ProviderListResult.Provider"
+    end
+    add_message "hashicorp.vagrant.Job.ProviderListResult.Provider" do
+      optional :name, :string, 1
+      optional :priority, :int32, 2
+      optional :defaultable, :bool, 3
+      optional :installed, :bool, 4
+      optional :usable, :bool, 5
+      optional :chosen, :bool, 6
+      optional :reason, :string, 7
+    end
     add_enum "hashicorp.vagrant.Job.State" do
       value :UNKNOWN, 0
       value :QUEUED, 1
@@ -590,6 +684,41 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
     add_message "hashicorp.vagrant.ListTargetsResponse" do
       repeated :targets, :message, 1, "hashicorp.vagrant.sdk.Ref.Target"
     end
+    add_message "hashicorp.vagrant.TargetLease" do
+      optional :id, :string, 1
+      optional :target, :message, 2, "hashicorp.vagrant.sdk.Ref.Target"
+      optional :job_id, :string, 3
+      optional :holder, :string, 4
+      optional :expire_time, :message, 5, "google.protobuf.Timestamp"
+    end
+    add_message "hashicorp.vagrant.AcquireTargetLeaseRequest" do
+      optional :target, :message, 1, "hashicorp.vagrant.sdk.Ref.Target"
+      optional :job_id, :string, 2
+      optional :holder, :string, 3
+      optional :ttl, :string, 4
+    end
+    add_message "hashicorp.vagrant.AcquireTargetLeaseResponse" do
+      optional :lease, :message, 1, "hashicorp.vagrant.TargetLease"
+    end
+    add_message "hashicorp.vagrant.RenewTargetLeaseRequest" do
+      optional :lease_id, :string, 1
+      optional :ttl, :string, 2
+    end
+    add_message "hashicorp.vagrant.RenewTargetLeaseResponse" do
+      optional :lease, :message, 1, "hashicorp.vagrant.TargetLease"
+    end
+    add_message "hashicorp.vagrant.ReleaseTargetLeaseRequest" do
+      optional :lease_id, :string, 1
+    end
+    add_message "hashicorp.vagrant.GarbageCollectRequest" do
+      optional :dry_run, :bool, 1
+      optional :projects_only, :bool, 2
+    end
+    add_message "hashicorp.vagrant.GarbageCollectResponse" do
+      repeated :projects, :message, 1, "hashicorp.vagrant.sdk.Ref.Project"
+      repeated :targets, :message, 2, "hashicorp.vagrant.sdk.Ref.Target"
+      repeated :orphaned, :message, 3, "hashicorp.vagrant.sdk.Ref.Target"
+    end
     add_message "hashicorp.vagrant.UpsertBoxRequest" do
       optional :box, :message, 1, "hashicorp.vagrant.Box"
     end
@@ -783,10 +912,42 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
     add_message "hashicorp.vagrant.HMACKey" do
       optional :id, :string, 1
       optional :key, :bytes, 2
+      optional :created_at, :message, 3, "google.protobuf.Timestamp"
+      optional :valid_until, :message, 4, "google.protobuf.Timestamp"
+    end
+    add_message "hashicorp.vagrant.TokenInfo" do
+      optional :id, :string, 1
+      optional :key_id, :string, 2
+      optional :user, :string, 3
+      optional :login, :bool, 4
+      optional :invite, :bool, 5
+      optional :issued_at, :message, 6, "google.protobuf.Timestamp"
+      optional :valid_until, :message, 7, "google.protobuf.Timestamp"
+      optional :revoked_at, :message, 8, "google.protobuf.Timestamp"
+    end
+    add_message "hashicorp.vagrant.LoginTokenRequest" do
+      optional :duration, :string, 1
+    end
+    add_message "hashicorp.vagrant.ListTokensResponse" do
+      repeated :tokens, :message, 1, "hashicorp.vagrant.TokenInfo"
+    end
+    add_message "hashicorp.vagrant.RevokeTokenRequest" do
+      oneof :target do
+        optional :id, :string, 1
+        optional :token, :string, 2
+      end
+    end
+    add_message "hashicorp.vagrant.RotateHMACKeyRequest" do
+      optional :grace_period, :string, 1
+    end
+    add_message "hashicorp.vagrant.RotateHMACKeyResponse" do
+      optional :key_id, :string, 1
+      optional :previous_valid_until, :message, 2, "google.protobuf.Timestamp"
     end
     add_message "hashicorp.vagrant.InviteTokenRequest" do
       optional :duration, :string, 1
       optional :entrypoint, :message, 2, "hashicorp.vagrant.Token.Entrypoint"
+      optional :username, :string, 3
     end
     add_message "hashicorp.vagrant.NewTokenResponse" do
       optional :token, :string, 1
@@ -794,6 +955,32 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
     add_message "hashicorp.vagrant.ConvertInviteTokenRequest" do
       optional :token, :string, 1
     end
+    add_message "hashicorp.vagrant.User" do
+      optional :username, :string, 1
+      optional :created_at, :message, 2, "google.protobuf.Timestamp"
+    end
+    add_message "hashicorp.vagrant.ListUsersResponse" do
+      repeated :users, :message, 1, "hashicorp.vagrant.User"
+    end
+    add_message "hashicorp.vagrant.DeleteUserRequest" do
+      optional :username, :string, 1
+    end
+    add_message "hashicorp.vagrant.AuditEvent" do
+      optional :id, :string, 1
+      optional :user, :string, 2
+      optional :endpoint, :string, 3
+      optional :time, :message, 4, "google.protobuf.Timestamp"
+      optional :error, :message, 5, "google.rpc.Status"
+    end
+    add_message "hashicorp.vagrant.ListAuditEventsRequest" do
+      optional :user, :string, 1
+      optional :endpoint, :string, 2
+      optional :since, :message, 3, "google.protobuf.Timestamp"
+      optional :limit, :uint32, 4
+    end
+    add_message "hashicorp.vagrant.ListAuditEventsResponse" do
+      repeated :events, :message, 1, "hashicorp.vagrant.AuditEvent"
+    end
     add_message "hashicorp.vagrant.CreateSnapshotResponse" do
       oneof :event do
         optional :open, :message, 1, "hashicorp.vagrant.CreateSnapshotResponse.Open"
@@ -831,6 +1018,30 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
       map :items, :string, :bytes, 2
       optional :final, :bool, 3
     end
+    add_message "hashicorp.vagrant.UploadBlobRequest" do
+      oneof :event do
+        optional :open, :message, 1, "hashicorp.vagrant.UploadBlobRequest.Open"
+        optional :chunk, :bytes, 2
+      end
+    end
+    add_message "hashicorp.vagrant.UploadBlobRequest.Open" do
+      optional :digest, :string, 1
+      optional :size, :int64, 2
+    end
+    add_message "hashicorp.vagrant.UploadBlobResponse" do
+      optional :digest, :string, 1
+    end
+    add_message "hashicorp.vagrant.GetBlobRequest" do
+      optional :digest, :string, 1
+    end
+    add_message "hashicorp.vagrant.GetBlobResponse" do
+      optional :chunk, :bytes, 1
+    end
+    add_message "hashicorp.vagrant.Blob" do
+      optional :digest, :string, 1
+      optional :size, :int64, 2
+      optional :create_time, :message, 3, "google.protobuf.Timestamp"
+    end
     add_message "hashicorp.vagrant.UpsertTaskRequest" do
       optional :task, :message, 1, "hashicorp.vagrant.Task"
     end
@@ -872,6 +1083,11 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
       optional :cli_args, :message, 12, "hashicorp.vagrant.sdk.Command.Arguments"
       optional :command_name, :string, 13
       optional :vagrantfile, :message, 14, "hashicorp.vagrant.Vagrantfile"
+      optional :user, :string, 15
+      optional :exit_code, :int32, 16
+      repeated :targets, :message, 17, "hashicorp.vagrant.sdk.Ref.Target"
+      optional :parallelism, :uint32, 18
+      optional :continue_on_error, :bool, 19
       oneof :scope do
         optional :target, :message, 1, "hashicorp.vagrant.sdk.Ref.Target"
         optional :project, :message, 2, "hashicorp.vagrant.sdk.Ref.Project"
@@ -888,6 +1104,10 @@ module Hashicorp
     VersionInfo::ProtocolVersion = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.VersionInfo.ProtocolVersion").msgclass
     Vagrantfile = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Vagrantfile").msgclass
     Vagrantfile::Format = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Vagrantfile.Format").enummodule
+    VagrantfileCache = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.VagrantfileCache").msgclass
+    GetVagrantfileCacheRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.GetVagrantfileCacheRequest").msgclass
+    GetVagrantfileCacheResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.GetVagrantfileCacheResponse").msgclass
+    UpsertVagrantfileCacheRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.UpsertVagrantfileCacheRequest").msgclass
     Basis = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Basis").msgclass
     Project = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Project").msgclass
     Box = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Box").msgclass
@@ -923,9 +1143,13 @@ module Hashicorp
     Job::DataSource = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.DataSource").msgclass
     Job::Local = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.Local").msgclass
     Job::Git = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.Git").msgclass
+    Job::Archive = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.Archive").msgclass
+    Job::Upload = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.Upload").msgclass
     Job::Noop = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.Noop").msgclass
     Job::ValidateOp = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.ValidateOp").msgclass
     Job::ValidateResult = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.ValidateResult").msgclass
+    Job::ValidateResult::Target = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.ValidateResult.Target").msgclass
+    Job::ValidateResult::Problem = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.ValidateResult.Problem").msgclass
     Job::InitOp = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.InitOp").msgclass
     Job::InitResult = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.InitResult").msgclass
     Job::Action = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.Action").msgclass
@@ -933,12 +1157,21 @@ module Hashicorp
     Job::Hook::Location = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.Hook.Location").enummodule
     Job::RunOp = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.RunOp").msgclass
     Job::RunResult = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.RunResult").msgclass
+    Job::RunResult::TargetResult = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.RunResult.TargetResult").msgclass
     Job::AuthOp = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.AuthOp").msgclass
     Job::AuthResult = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.AuthResult").msgclass
     Job::AuthResult::Result = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.AuthResult.Result").msgclass
     Job::DocsOp = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.DocsOp").msgclass
     Job::DocsResult = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.DocsResult").msgclass
     Job::DocsResult::Result = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.DocsResult.Result").msgclass
+    Job::ConfigOp = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.ConfigOp").msgclass
+    Job::ConfigResult = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.ConfigResult").msgclass
+    Job::GlobalStatusOp = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.GlobalStatusOp").msgclass
+    Job::GlobalStatusResult = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.GlobalStatusResult").msgclass
+    Job::GlobalStatusResult::Entry = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.GlobalStatusResult.Entry").msgclass
+    Job::ProviderListOp = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.ProviderListOp").msgclass
+    Job::ProviderListResult = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.ProviderListResult").msgclass
+    Job::ProviderListResult::Provider = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.ProviderListResult.Provider").msgclass
     Job::State = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Job.State").enummodule
     Documentation = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Documentation").msgclass
     Documentation::Field = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Documentation.Field").msgclass
@@ -1007,6 +1240,14 @@ module Hashicorp
     FindTargetRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.FindTargetRequest").msgclass
     FindTargetResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.FindTargetResponse").msgclass
     ListTargetsResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.ListTargetsResponse").msgclass
+    TargetLease = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.TargetLease").msgclass
+    AcquireTargetLeaseRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.AcquireTargetLeaseRequest").msgclass
+    AcquireTargetLeaseResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.AcquireTargetLeaseResponse").msgclass
+    RenewTargetLeaseRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.RenewTargetLeaseRequest").msgclass
+    RenewTargetLeaseResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.RenewTargetLeaseResponse").msgclass
+    ReleaseTargetLeaseRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.ReleaseTargetLeaseRequest").msgclass
+    GarbageCollectRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.GarbageCollectRequest").msgclass
+    GarbageCollectResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.GarbageCollectResponse").msgclass
     UpsertBoxRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.UpsertBoxRequest").msgclass
     UpsertBoxResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.UpsertBoxResponse").msgclass
     DeleteBoxRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.DeleteBoxRequest").msgclass
@@ -1050,9 +1291,21 @@ module Hashicorp
     Token = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Token").msgclass
     Token::Entrypoint = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Token.Entrypoint").msgclass
     HMACKey = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.HMACKey").msgclass
+    TokenInfo = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.TokenInfo").msgclass
+    LoginTokenRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.LoginTokenRequest").msgclass
+    ListTokensResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.ListTokensResponse").msgclass
+    RevokeTokenRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.RevokeTokenRequest").msgclass
+    RotateHMACKeyRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.RotateHMACKeyRequest").msgclass
+    RotateHMACKeyResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.RotateHMACKeyResponse").msgclass
     InviteTokenRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.InviteTokenRequest").msgclass
     NewTokenResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.NewTokenResponse").msgclass
     ConvertInviteTokenRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.ConvertInviteTokenRequest").msgclass
+    User = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.User").msgclass
+    ListUsersResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.ListUsersResponse").msgclass
+    DeleteUserRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.DeleteUserRequest").msgclass
+    AuditEvent = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.AuditEvent").msgclass
+    ListAuditEventsRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.ListAuditEventsRequest").msgclass
+    ListAuditEventsResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.ListAuditEventsResponse").msgclass
     CreateSnapshotResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.CreateSnapshotResponse").msgclass
     CreateSnapshotResponse::Open = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.CreateSnapshotResponse.Open").msgclass
     RestoreSnapshotRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.RestoreSnapshotRequest").msgclass
@@ -1062,6 +1315,12 @@ module Hashicorp
     Snapshot::Header::Format = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Snapshot.Header.Format").enummodule
     Snapshot::Trailer = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Snapshot.Trailer").msgclass
     Snapshot::BoltChunk = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Snapshot.BoltChunk").msgclass
+    UploadBlobRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.UploadBlobRequest").msgclass
+    UploadBlobRequest::Open = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.UploadBlobRequest.Open").msgclass
+    UploadBlobResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.UploadBlobResponse").msgclass
+    GetBlobRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.GetBlobRequest").msgclass
+    GetBlobResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.GetBlobResponse").msgclass
+    Blob = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.Blob").msgclass
     UpsertTaskRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.UpsertTaskRequest").msgclass
     UpsertTaskResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.UpsertTaskResponse").msgclass
     GetLatestTaskRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("hashicorp.vagrant.GetLatestTaskRequest").msgclass
@@ -49,12 +49,30 @@ module Hashicorp
         rpc :GetTarget, ::Hashicorp::Vagrant::GetTargetRequest, ::Hashicorp::Vagrant::GetTargetResponse
         rpc :FindTarget, ::Hashicorp::Vagrant::FindTargetRequest, ::Hashicorp::Vagrant::FindTargetResponse
         rpc :ListTargets, ::Google::Protobuf::Empty, ::Hashicorp::Vagrant::ListTargetsResponse
+        # AcquireTargetLease takes an advisory lease on a target so that only
+        # one operation runs against it at a time. The lease expires unless it
+        # is renewed before its TTL passes, so a lease is not held forever by
+        # a process which dies. If another holder has an unexpired lease on the
+        # target, this fails with a FailedPrecondition error naming the holder.
+        rpc :AcquireTargetLease, ::Hashicorp::Vagrant::AcquireTargetLeaseRequest, ::Hashicorp::Vagrant::AcquireTargetLeaseResponse
+        # RenewTargetLease extends the expiration of a lease. This fails with a
+        # NotFound error if the lease has been released or has expired.
+        rpc :RenewTargetLease, ::Hashicorp::Vagrant::RenewTargetLeaseRequest, ::Hashicorp::Vagrant::RenewTargetLeaseResponse
+        # ReleaseTargetLease releases a lease. Releasing a lease which does not
+        # exist, such as one which has expired, does nothing.
+        rpc :ReleaseTargetLease, ::Hashicorp::Vagrant::ReleaseTargetLeaseRequest, ::Google::Protobuf::Empty
         # CRUD operations for box
         rpc :UpsertBox, ::Hashicorp::Vagrant::UpsertBoxRequest, ::Hashicorp::Vagrant::UpsertBoxResponse
         rpc :DeleteBox, ::Hashicorp::Vagrant::DeleteBoxRequest, ::Google::Protobuf::Empty
         rpc :GetBox, ::Hashicorp::Vagrant::GetBoxRequest, ::Hashicorp::Vagrant::GetBoxResponse
         rpc :ListBoxes, ::Google::Protobuf::Empty, ::Hashicorp::Vagrant::ListBoxesResponse
         rpc :FindBox, ::Hashicorp::Vagrant::FindBoxRequest, ::Hashicorp::Vagrant::FindBoxResponse
+        # GetVagrantfileCache returns the cached parse of a Vagrantfile by
+        # its key. This returns a NotFound error if there is no such entry.
+        rpc :GetVagrantfileCache, ::Hashicorp::Vagrant::GetVagrantfileCacheRequest, ::Hashicorp::Vagrant::GetVagrantfileCacheResponse
+        # UpsertVagrantfileCache caches the parse of a Vagrantfile. This replaces
+        # any entry cached for the same Vagrantfile path.
+        rpc :UpsertVagrantfileCache, ::Hashicorp::Vagrant::UpsertVagrantfileCacheRequest, ::Google::Protobuf::Empty
         # GetLogStream reads the log stream for a deployment. This will immediately
         # send a single LogEntry with the lines we have so far. If there are no
         # available lines this will NOT block and instead will return an error.
@@ -92,6 +110,9 @@ module Hashicorp
         rpc :GetJobStream, ::Hashicorp::Vagrant::GetJobStreamRequest, stream(::Hashicorp::Vagrant::GetJobStreamResponse)
         # Clean out old jobs from the job database
         rpc :PruneOldJobs, ::Google::Protobuf::Empty, ::Google::Protobuf::Empty
+        # Remove projects whose path no longer exists and targets which are
+        # no longer defined by the Vagrantfile of their project
+        rpc :GarbageCollect, ::Hashicorp::Vagrant::GarbageCollectRequest, ::Hashicorp::Vagrant::GarbageCollectResponse
         # GetRunner gets information about a single runner.
         rpc :GetRunner, ::Hashicorp::Vagrant::GetRunnerRequest, ::Hashicorp::Vagrant::Runner
         # GetServerConfig sets configuration for the Vagrant server.
@@ -105,6 +126,12 @@ module Hashicorp
         # for the next server start to finalize the restore. See the arguments for
         # more information.
         rpc :RestoreSnapshot, stream(::Hashicorp::Vagrant::RestoreSnapshotRequest), ::Google::Protobuf::Empty
+        # UploadBlob stores a blob of data on the server. Blobs are content
+        # addressed by their SHA-256 digest and are removed once no jobs
+        # reference them.
+        rpc :UploadBlob, stream(::Hashicorp::Vagrant::UploadBlobRequest), ::Hashicorp::Vagrant::UploadBlobResponse
+        # GetBlob streams the contents of a previously uploaded blob.
+        rpc :GetBlob, ::Hashicorp::Vagrant::GetBlobRequest, stream(::Hashicorp::Vagrant::GetBlobResponse)
         # BootstrapToken returns the initial token for the server. This can only
         # be requested once on first startup. After initial request this will
         # always return a PermissionDenied error.
@@ -112,9 +139,26 @@ module Hashicorp
         # Generate a new invite token that users can exchange for a login token.
         rpc :GenerateInviteToken, ::Hashicorp::Vagrant::InviteTokenRequest, ::Hashicorp::Vagrant::NewTokenResponse
         # Generate a new login token that users can use to login directly.
-        rpc :GenerateLoginToken, ::Google::Protobuf::Empty, ::Hashicorp::Vagrant::NewTokenResponse
+        rpc :GenerateLoginToken, ::Hashicorp::Vagrant::LoginTokenRequest, ::Hashicorp::Vagrant::NewTokenResponse
         # Exchange a invite token for a login token.
         rpc :ConvertInviteToken, ::Hashicorp::Vagrant::ConvertInviteTokenRequest, ::Hashicorp::Vagrant::NewTokenResponse
+        # ListTokens returns the tokens issued by the server. The tokens themselves
+        # are never stored, only information about them.
+        rpc :ListTokens, ::Google::Protobuf::Empty, ::Hashicorp::Vagrant::ListTokensResponse
+        # RevokeToken revokes a single token. A revoked token is rejected even
+        # if it has not expired.
+        rpc :RevokeToken, ::Hashicorp::Vagrant::RevokeTokenRequest, ::Google::Protobuf::Empty
+        # RotateHMACKey creates a new key to sign tokens with. Tokens signed
+        # with the previous keys remain valid for the requested grace period.
+        rpc :RotateHMACKey, ::Hashicorp::Vagrant::RotateHMACKeyRequest, ::Hashicorp::Vagrant::RotateHMACKeyResponse
+        # ListUsers returns the users known to the server. Users are created
+        # when they exchange an invite token for a login token.
+        rpc :ListUsers, ::Google::Protobuf::Empty, ::Hashicorp::Vagrant::ListUsersResponse
+        # DeleteUser deletes a user. Any tokens of the user are rejected from then on.
+        rpc :DeleteUser, ::Hashicorp::Vagrant::DeleteUserRequest, ::Google::Protobuf::Empty
+        # ListAuditEvents returns the recorded calls to endpoints which mutate
+        # data, most recent first.
+        rpc :ListAuditEvents, ::Hashicorp::Vagrant::ListAuditEventsRequest, ::Hashicorp::Vagrant::ListAuditEventsResponse
         # ----------------------------------------------------------------------
         # Runner endpoints. These are expected to be called only by a runner.
         # These are not meant to be public endpoints.